@@ -27,9 +27,12 @@ import (
 	"image"
 	"sync"
 
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/private/damage"
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
@@ -45,9 +48,17 @@ type Container struct {
 	first  *Container
 	second *Container
 
+	// tabs are the tabs of a tabbed container. The container of the active
+	// tab is also the first sub container.
+	tabs []*tab
+	// activeTab is the index of the active tab.
+	activeTab int
+
 	// term is the terminal this container is placed on.
-	// All containers in the tree share the same terminal.
-	term terminalapi.Terminal
+	// All containers in the tree share the same terminal. The terminal
+	// provided by the user is wrapped so that only the cells that changed
+	// since the last Draw are set on it.
+	term *damage.Terminal
 
 	// focusTracker tracks the active (focused) container.
 	// All containers in the tree share the same tracker.
@@ -60,6 +71,30 @@ type Container struct {
 	// opts are the options provided to the container.
 	opts *options
 
+	// invalidator is used to request a redraw of the terminal.
+	// Only set on the root container.
+	invalidator widgetapi.Invalidator
+
+	// overlays are the overlays shown on top of the containers, ordered from
+	// the bottom one to the top one.
+	// Only set on the root container.
+	overlays []*overlay
+
+	// maximized is the container that occupies the whole terminal, nil if
+	// no container is maximized.
+	// Only set on the root container.
+	maximized *Container
+
+	// drag is the container whose split line is being dragged with the
+	// mouse, nil if no drag is in progress.
+	// Only set on the root container.
+	drag *Container
+	// dragResized indicates whether the current drag resized the split.
+	dragResized bool
+	// dragPerc is the last size of the first container set by the current
+	// drag.
+	dragPerc int
+
 	// clearNeeded indicates if the terminal needs to be cleared next time we
 	// are clearNeeded the container.
 	// This is required if the container was updated and thus the layout might
@@ -81,7 +116,7 @@ func (c *Container) String() string {
 // applies the provided options.
 func New(t terminalapi.Terminal, opts ...Option) (*Container, error) {
 	root := &Container{
-		term: t,
+		term: damage.New(t),
 		opts: newOptions( /* parent = */ nil),
 		mu:   &sync.Mutex{},
 	}
@@ -122,6 +157,11 @@ func (c *Container) hasWidget() bool {
 	return c.opts.widget != nil
 }
 
+// hasTabs determines if this container is a tabbed container.
+func (c *Container) hasTabs() bool {
+	return len(c.tabs) > 0
+}
+
 // isLeaf determines if this container is a leaf container in the binary tree of containers.
 // Only leaf containers are guaranteed to be "visible" on the screen, because
 // they are on the top of other non-leaf containers.
@@ -135,6 +175,10 @@ func (c *Container) usable() image.Rectangle {
 	if c.hasBorder() {
 		return area.ExcludeBorder(c.area)
 	}
+	if c.hasTabs() && c.area.Dy() > 0 {
+		// The top line is reserved for the tab strip.
+		return image.Rect(c.area.Min.X, c.area.Min.Y+1, c.area.Max.X, c.area.Max.Y)
+	}
 	return c.area
 }
 
@@ -173,11 +217,15 @@ func (c *Container) widgetArea() (image.Rectangle, error) {
 
 // split splits the container's usable area into child areas.
 // Panics if the container isn't configured for a split.
+// The container of the active tab in a tabbed container gets all the area.
 func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
 	ar, err := c.opts.padding.apply(c.usable())
 	if err != nil {
 		return image.ZR, image.ZR, err
 	}
+	if c.hasTabs() {
+		return ar, image.ZR, nil
+	}
 	if c.opts.splitFixed > DefaultSplitFixed {
 		if c.opts.split == splitTypeVertical {
 			if c.opts.splitReversed {
@@ -242,7 +290,13 @@ func (c *Container) Draw() error {
 		return err
 	}
 	c.focusTracker.updateArea(ar)
-	return drawTree(c)
+	if err := drawTree(c); err != nil {
+		return err
+	}
+	if err := drawOverlays(c); err != nil {
+		return err
+	}
+	return c.term.Push()
 }
 
 // Update updates container with the specified id by setting the provided
@@ -260,6 +314,8 @@ func (c *Container) Update(id string, opts ...Option) error {
 		return err
 	}
 	c.clearNeeded = true
+	// The layout might change, abort any drag of a split line.
+	c.drag = nil
 
 	if err := applyOptions(target, opts...); err != nil {
 		return err
@@ -273,6 +329,7 @@ func (c *Container) Update(id string, opts ...Option) error {
 	if !c.focusTracker.reachableFrom(c) {
 		c.focusTracker.setActive(target)
 	}
+	c.updateMaximized()
 	return nil
 }
 
@@ -280,6 +337,9 @@ func (c *Container) Update(id string, opts ...Option) error {
 // the focused container.
 // Caller must hold c.mu.
 func (c *Container) updateFocusFromMouse(m *terminalapi.Mouse) {
+	if c.focusTracker.trapped() {
+		return
+	}
 	target := pointCont(c, m.Position)
 	if target == nil { // Ignore mouse clicks where no containers are.
 		return
@@ -301,17 +361,21 @@ func (c *Container) inFocusGroup(fg FocusGroup) bool {
 // changes the focused container.
 // Caller must hold c.mu.
 func (c *Container) updateFocusFromKeyboard(k *terminalapi.Keyboard) {
+	if c.focusTracker.trapped() {
+		return
+	}
 	active := c.focusTracker.active()
-	nextGroupsForKey, isGroupKeyForNext := active.opts.global.keyFocusGroupsNext[k.Key]
-	prevGroupsForKey, isGroupKeyForPrev := active.opts.global.keyFocusGroupsPrevious[k.Key]
+	sc := k.Shortcut()
+	nextGroupsForKey, isGroupKeyForNext := active.opts.global.keyFocusGroupsNext[sc]
+	prevGroupsForKey, isGroupKeyForPrev := active.opts.global.keyFocusGroupsPrevious[sc]
 
 	nextMatchesContGroup, nextG := nextGroupsForKey.firstMatching(active.opts.keyFocusGroups)
 	prevMatchesContGroup, prevG := prevGroupsForKey.firstMatching(active.opts.keyFocusGroups)
 
 	switch {
-	case active.opts.global.keyFocusNext != nil && *active.opts.global.keyFocusNext == k.Key:
+	case active.opts.global.keyFocusNext != nil && *active.opts.global.keyFocusNext == sc:
 		c.focusTracker.next( /* group = */ nil)
-	case active.opts.global.keyFocusPrevious != nil && *active.opts.global.keyFocusPrevious == k.Key:
+	case active.opts.global.keyFocusPrevious != nil && *active.opts.global.keyFocusPrevious == sc:
 		c.focusTracker.previous( /* group = */ nil)
 	case isGroupKeyForNext && nextMatchesContGroup:
 		c.focusTracker.next(&nextG)
@@ -330,27 +394,48 @@ func (c *Container) processEvent(ev terminalapi.Event) error {
 	//    because some widgets might try to mutate the container when they
 	//    receive the event, like dynamically change the layout.
 	c.mu.Lock()
-	sendFn, err := c.prepareEvTargets(ev)
+	sendFn, redraw, err := c.prepareEvTargets(ev)
+	invalidator := rootCont(c).invalidator
 	c.mu.Unlock()
 	if err != nil {
 		return err
 	}
+	if redraw {
+		// The event very likely changed the content of the widgets or the
+		// layout, e.g. zooming a LineChart. Request a redraw once the widgets
+		// processed it.
+		defer invalidator.Invalidate()
+	}
 	return sendFn()
 }
 
 // prepareEvTargets returns a closure, that when called delivers the event to
 // widgets that registered for it.
 // Also processes the event on behalf of the container (tracks keyboard focus).
+// The returned bool indicates if the terminal should be redrawn, i.e. if at
+// least one widget receives the event or if the event changed the focus, the
+// active tabs or the layout.
 // Caller must hold c.mu.
-func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error) {
+func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, bool, error) {
+	focused := c.focusTracker.active()
 	switch e := ev.(type) {
 	case *terminalapi.Mouse:
+		dragged, dragFn, err := c.dragSplit(e)
+		if err != nil {
+			return nil, false, err
+		}
+		if dragged {
+			return dragFn, true, nil
+		}
+
+		tabsChanged := c.updateTabsFromMouse(e)
 		c.updateFocusFromMouse(ev.(*terminalapi.Mouse))
 
-		targets, err := c.mouseEvTargets(e)
+		targets, err := c.evTargetsRoot().mouseEvTargets(e)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		redraw := len(targets) > 0 || tabsChanged || c.focusTracker.active() != focused
 		return func() error {
 			for _, mt := range targets {
 				if err := mt.widget.Mouse(mt.ev, mt.meta); err != nil {
@@ -358,12 +443,15 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 				}
 			}
 			return nil
-		}, nil
+		}, redraw, nil
 
 	case *terminalapi.Keyboard:
+		maximized := c.updateMaximizeFromKeyboard(e)
+		tabsChanged := c.updateTabsFromKeyboard(e)
 		c.updateFocusFromKeyboard(ev.(*terminalapi.Keyboard))
 
-		targets := c.keyEvTargets()
+		targets := c.evTargetsRoot().keyEvTargets()
+		redraw := len(targets) > 0 || maximized || tabsChanged || c.focusTracker.active() != focused
 		return func() error {
 			for _, kt := range targets {
 				if err := kt.widget.Keyboard(e, kt.meta); err != nil {
@@ -371,11 +459,40 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 				}
 			}
 			return nil
-		}, nil
+		}, redraw, nil
+
+	case *terminalapi.Paste:
+		// Pasted text goes to the same widgets as keyboard events.
+		targets := c.evTargetsRoot().keyEvTargets()
+		return func() error {
+			for _, kt := range targets {
+				if err := pasteTo(kt.widget, e, kt.meta); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, len(targets) > 0, nil
+
+	case *terminalapi.Resize:
+		// The content of the terminal might not survive the resize, make sure
+		// all the cells get set on the next Draw.
+		c.clearNeeded = true
+		return func() error { return nil }, true, nil
 
 	default:
-		return nil, fmt.Errorf("container received an unsupported event type %T", ev)
+		return nil, false, fmt.Errorf("container received an unsupported event type %T", ev)
+	}
+}
+
+// evTargetsRoot returns the container whose widgets receive the keyboard and
+// mouse events. This is the container of the top overlay if any overlays are
+// shown, otherwise the root of the visible containers.
+// Caller must hold c.mu.
+func (c *Container) evTargetsRoot() *Container {
+	if o := c.topOverlay(); o != nil {
+		return o.cont
 	}
+	return visibleRoot(c)
 }
 
 // keyEvTarget contains a widget that should receive an event and the metadata
@@ -447,6 +564,30 @@ func (c *Container) keyEvTargets() []*keyEvTarget {
 	return targets
 }
 
+// pasteTo delivers the paste event to the widget. Widgets that don't
+// implement widgetapi.Paster receive the pasted text as keyboard events.
+func pasteTo(w widgetapi.Widget, p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	if paster, ok := w.(widgetapi.Paster); ok {
+		return paster.Paste(p, meta)
+	}
+
+	for _, r := range p.Text {
+		var k keyboard.Key
+		switch r {
+		case '\n':
+			k = keyboard.KeyEnter
+		case '\t':
+			k = keyboard.KeyTab
+		default:
+			k = keyboard.Key(r)
+		}
+		if err := w.Keyboard(&terminalapi.Keyboard{Key: k}, meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // mouseEvTarget contains a mouse event adjusted relative to the widget's area,
 // the widget that should receive it and metadata about the event.
 type mouseEvTarget struct {
@@ -489,6 +630,11 @@ func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, erro
 			return err
 		}
 
+		if m.Motion && m.Button == mouse.ButtonNone && !wOpts.WantMouseMotion {
+			// Widget doesn't want to know when the mouse hovers.
+			return nil
+		}
+
 		meta := &widgetapi.EventMeta{
 			Focused: cur.focusTracker.isActive(cur),
 		}
@@ -534,10 +680,13 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	// before we throttle them.
 	const maxReps = 10
 
-	// Subscriber the container itself in order to track keyboard focus.
+	// Subscriber the container itself in order to track keyboard focus and
+	// terminal resizes.
 	want := []terminalapi.Event{
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
+		&terminalapi.Paste{},
+		&terminalapi.Resize{},
 	}
 	eds.Subscribe(want, func(ev terminalapi.Event) {
 		if err := c.processEvent(ev); err != nil {
@@ -546,20 +695,27 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	}, event.MaxRepetitive(maxReps))
 }
 
+// SetInvalidator sets the invalidator that the container and the widgets it
+// contains use to request a redraw of the terminal.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) SetInvalidator(i widgetapi.Invalidator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rootCont(c).invalidator = i
+}
+
 // adjustMouseEv adjusts the mouse event relative to the widget area.
 func adjustMouseEv(m *terminalapi.Mouse, wArea image.Rectangle) *terminalapi.Mouse {
 	// The sent mouse coordinate is relative to the widget canvas, i.e. zero
 	// based, even though the widget might not be in the top left corner on the
 	// terminal.
 	offset := wArea.Min
+	adjusted := *m
 	if m.Position.In(wArea) {
-		return &terminalapi.Mouse{
-			Position: m.Position.Sub(offset),
-			Button:   m.Button,
-		}
-	}
-	return &terminalapi.Mouse{
-		Position: image.Point{-1, -1},
-		Button:   m.Button,
+		adjusted.Position = m.Position.Sub(offset)
+	} else {
+		adjusted.Position = image.Point{-1, -1}
 	}
+	return &adjusted
 }
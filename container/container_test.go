@@ -1374,6 +1374,18 @@ func (eh *errorHandler) handle(err error) {
 	eh.err = err
 }
 
+// pasteMirror is a fakewidget.Mirror that implements widgetapi.Paster.
+// It displays the pasted text after the canvas size.
+type pasteMirror struct {
+	*fakewidget.Mirror
+}
+
+// Paste implements widgetapi.Paster.Paste.
+func (pm *pasteMirror) Paste(p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	pm.Text(p.Text)
+	return nil
+}
+
 func TestKeyboard(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -1460,6 +1472,75 @@ func TestKeyboard(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "paste forwarded as keyboard events to widgets that don't implement Paster",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Paste{Text: "a\tb\n"},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "paste forwarded to the focused widget that implements Paster",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(&pasteMirror{fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})}),
+						),
+						Right(
+							PlaceWidget(&pasteMirror{fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})}),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the target container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				&terminalapi.Paste{Text: "hello"},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+
+				mirror := fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})
+				mirror.Text("hello")
+				fakewidget.MustDrawWithMirror(
+					mirror,
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+				)
+				return ft
+			},
+		},
 		{
 			desc:     "event forwarded to all widgets that requested global key scope",
 			termSize: image.Point{40, 20},
@@ -2628,6 +2709,78 @@ func TestMouse(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:     "hover events are only forwarded to widgets that want mouse motion",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{
+								WantMouse:       widgetapi.MouseScopeGlobal,
+								WantMouseMotion: true,
+							})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{25, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{5, 1}, Button: mouse.ButtonNone, Motion: true},
+						Meta: &widgetapi.EventMeta{},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "drag events and modifiers are forwarded to all widgets",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft, Motion: true, Modifiers: keyboard.ModCtrl},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft, Motion: true, Modifiers: keyboard.ModCtrl},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -3157,3 +3310,164 @@ func TestUpdate(t *testing.T) {
 	}
 
 }
+
+func TestRequestsRedrawOnEvents(t *testing.T) {
+	tests := []struct {
+		desc      string
+		container func(ft *faketerm.Terminal) (*Container, error)
+		events    []terminalapi.Event
+		// want is the number of redraws requested by the container.
+		want int
+	}{
+		{
+			desc: "doesn't redraw when the mouse hovers over widgets that don't want motion",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+			want: 0,
+		},
+		{
+			desc: "redraws when the mouse hovers over a widget that wants motion",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{
+						WantMouse:       widgetapi.MouseScopeWidget,
+						WantMouseMotion: true,
+					})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+			want: 1,
+		},
+		{
+			desc: "doesn't redraw when no widget receives the keyboard event",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: 0,
+		},
+		{
+			desc: "redraws when a widget receives the keyboard event",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: 1,
+		},
+		{
+			desc: "redraws when the keyboard event moves the focus",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyFocusNext(keyboard.KeyTab),
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: 1,
+		},
+		{
+			desc: "redraws when the keyboard event maximizes a container",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyMaximize('m'),
+					SplitVertical(
+						Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Focus the left container and maximize it.
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: 'm'},
+			},
+			want: 2,
+		},
+		{
+			desc: "redraws when the terminal is resized",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{30, 20}},
+			},
+			want: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{30, 20})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(ft)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			var (
+				mu       sync.Mutex
+				requests int
+			)
+			c.SetInvalidator(func() {
+				mu.Lock()
+				defer mu.Unlock()
+				requests++
+			})
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if got := requests; got != tc.want {
+				t.Errorf("container requested %d redraws, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// drag.go contains code that resizes splits when the user drags the split
+// line with the mouse.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// splitLine returns the area of the split line of this container, i.e. the
+// adjacent borders of its sub containers. Returns a zero area if the split
+// isn't draggable.
+func (c *Container) splitLine() image.Rectangle {
+	if !c.opts.splitDraggable || c.first == nil || c.second == nil {
+		return image.ZR
+	}
+	if !c.first.hasBorder() || !c.second.hasBorder() {
+		return image.ZR
+	}
+
+	f, s := c.first.area, c.second.area
+	if f.Empty() || s.Empty() {
+		return image.ZR
+	}
+	if c.opts.split == splitTypeVertical {
+		return image.Rect(f.Max.X-1, f.Min.Y, f.Max.X, f.Max.Y).Union(
+			image.Rect(s.Min.X, s.Min.Y, s.Min.X+1, s.Max.Y))
+	}
+	return image.Rect(f.Min.X, f.Max.Y-1, f.Max.X, f.Max.Y).Union(
+		image.Rect(s.Min.X, s.Min.Y, s.Max.X, s.Min.Y+1))
+}
+
+// dragPercent returns the size of the first container as a percentage of the
+// available space if the split line was dragged to the provided point.
+// Honors the limits set by SplitDragLimits.
+func (c *Container) dragPercent(p image.Point) (int, error) {
+	ar, err := c.opts.padding.apply(c.usable())
+	if err != nil {
+		return 0, err
+	}
+
+	offset, size := p.X-ar.Min.X, ar.Dx()
+	if c.opts.split == splitTypeHorizontal {
+		offset, size = p.Y-ar.Min.Y, ar.Dy()
+	}
+	if size <= 0 {
+		return c.opts.splitMinPercent, nil
+	}
+
+	// Round up so that the second container starts at the point.
+	perc := (offset*100 + size - 1) / size
+	if perc < c.opts.splitMinPercent {
+		perc = c.opts.splitMinPercent
+	}
+	if perc > c.opts.splitMaxPercent {
+		perc = c.opts.splitMaxPercent
+	}
+	return perc, nil
+}
+
+// resizeSplit changes the size of the first container to the provided
+// percentage of the available space. Returns true if the layout changed.
+func (c *Container) resizeSplit(perc int) (bool, error) {
+	fixed, percent := c.opts.splitFixed, c.opts.splitPercent
+
+	so := SplitPercent(perc)
+	if c.opts.splitReversed {
+		so = SplitPercentFromEnd(100 - perc)
+	}
+	c.opts.splitFixed = DefaultSplitFixed
+	if err := so.setSplit(c.opts); err != nil {
+		return false, err
+	}
+	return fixed != c.opts.splitFixed || percent != c.opts.splitPercent, nil
+}
+
+// dragSplit processes the mouse event and determines if it starts, continues
+// or finishes dragging of a split line.
+// Returns true if the mouse event was consumed by the drag, in which case it
+// must not be processed further. When the drag finishes, the returned function
+// calls the SplitOnResize function if any. The returned function must be
+// called after c.mu is released.
+// Caller must hold c.mu.
+func (c *Container) dragSplit(m *terminalapi.Mouse) (bool, func() error, error) {
+	root := rootCont(c)
+	noop := func() error { return nil }
+	if root.focusTracker.trapped() {
+		root.drag = nil
+		return false, nil, nil
+	}
+
+	if root.drag == nil {
+		// Moving the mouse onto the split line with the button held doesn't
+		// start a drag.
+		if m.Button != mouse.ButtonLeft || m.Motion {
+			return false, nil, nil
+		}
+
+		var (
+			errStr string
+			target *Container
+		)
+		// The deepest container wins if the split lines of nested containers
+		// overlap.
+		preOrder(visibleRoot(root), &errStr, visitFunc(func(cur *Container) error {
+			if m.Position.In(cur.splitLine()) {
+				target = cur
+			}
+			return nil
+		}))
+		if target == nil {
+			return false, nil, nil
+		}
+		root.drag = target
+		root.dragResized = false
+		return true, noop, nil
+	}
+
+	d := root.drag
+	switch m.Button {
+	case mouse.ButtonLeft:
+		perc, err := d.dragPercent(m.Position)
+		if err != nil {
+			return false, nil, err
+		}
+		resized, err := d.resizeSplit(perc)
+		if err != nil {
+			return false, nil, err
+		}
+		if resized {
+			// The damage tracked terminal only sets the cells that changed, so
+			// the terminal isn't cleared while dragging to avoid flicker.
+			root.dragResized = true
+			root.dragPerc = perc
+		}
+		return true, noop, nil
+
+	case mouse.ButtonRelease:
+		root.drag = nil
+		if !root.dragResized {
+			return true, noop, nil
+		}
+		// Parts of the terminal might not be covered by the resized
+		// containers, clear it once the layout settles.
+		root.clearNeeded = true
+		if d.opts.splitOnResize == nil {
+			return true, noop, nil
+		}
+		fn, perc := d.opts.splitOnResize, root.dragPerc
+		return true, func() error { return fn(perc) }, nil
+
+	default:
+		// Other buttons don't interrupt the drag.
+		return true, noop, nil
+	}
+}
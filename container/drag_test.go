@@ -0,0 +1,449 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// resizeRecorder records the values passed to SplitOnResize.
+type resizeRecorder struct {
+	percs []int
+	err   error
+	mu    sync.Mutex
+}
+
+// onResize implements the function passed to SplitOnResize.
+func (rr *resizeRecorder) onResize(perc int) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.percs = append(rr.percs, perc)
+	return rr.err
+}
+
+// get returns the recorded values.
+func (rr *resizeRecorder) get() []int {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	return rr.percs
+}
+
+// mustBorders draws unfocused borders in the provided areas.
+func mustBorders(ft *faketerm.Terminal, areas ...image.Rectangle) {
+	for _, ar := range areas {
+		cvs := testcanvas.MustNew(ar)
+		testdraw.MustBorder(cvs, cvs.Area())
+		testcanvas.MustApply(cvs, ft)
+	}
+}
+
+// mustFocusedBorder draws a focused border in the provided area.
+func mustFocusedBorder(ft *faketerm.Terminal, ar image.Rectangle) {
+	cvs := testcanvas.MustNew(ar)
+	testdraw.MustBorder(cvs, cvs.Area(), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+	testcanvas.MustApply(cvs, ft)
+}
+
+// drag returns mouse events that drag from one point to the other.
+func drag(from, to image.Point) []terminalapi.Event {
+	return []terminalapi.Event{
+		&terminalapi.Mouse{Position: from, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: to, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: to, Button: mouse.ButtonRelease},
+	}
+}
+
+func TestDragSplit(t *testing.T) {
+	tests := []struct {
+		desc     string
+		termSize image.Point
+		// container creates the container, the recorder must be passed to
+		// SplitOnResize.
+		container func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error)
+		// resizeErr is returned from SplitOnResize.
+		resizeErr   error
+		events      []terminalapi.Event
+		want        func(size image.Point) *faketerm.Terminal
+		wantResized []int
+		wantErr     bool
+	}{
+		{
+			desc:     "fails on drag limits outside of the range",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), SplitDraggable(), SplitDragLimits(0, 50)),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on minimum drag limit larger than the maximum",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(Left(), Right(), SplitDraggable(), SplitDragLimits(60, 50)),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "drags vertical split",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{9, 2}, image.Point{14, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 14, 5), image.Rect(14, 0, 20, 5))
+				return ft
+			},
+			wantResized: []int{70},
+		},
+		{
+			desc:     "drags vertical split from the border of the second container",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{10, 2}, image.Point{4, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 4, 5), image.Rect(4, 0, 20, 5))
+				return ft
+			},
+			wantResized: []int{20},
+		},
+		{
+			desc:     "drags horizontal split",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(Border(linestyle.Light)),
+						Bottom(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{5, 4}, image.Point{5, 7}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 7), image.Rect(0, 7, 10, 10))
+				return ft
+			},
+			wantResized: []int{70},
+		},
+		{
+			desc:     "honors the drag limits",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitDragLimits(30, 60),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{9, 2}, image.Point{18, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 12, 5), image.Rect(12, 0, 20, 5))
+				return ft
+			},
+			wantResized: []int{60},
+		},
+		{
+			desc:     "converts fixed split into a percentage",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitFixedFromEnd(4),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{16, 2}, image.Point{10, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 5), image.Rect(10, 0, 20, 5))
+				return ft
+			},
+			wantResized: []int{50},
+		},
+		{
+			desc:     "doesn't call SplitOnResize when the size didn't change",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{9, 2}, image.Point{10, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 5), image.Rect(10, 0, 20, 5))
+				return ft
+			},
+		},
+		{
+			desc:     "split isn't draggable by default",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{9, 2}, image.Point{14, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 5))
+				// The mouse events are treated as a click.
+				mustFocusedBorder(ft, image.Rect(10, 0, 20, 5))
+				return ft
+			},
+		},
+		{
+			desc:     "split isn't draggable if a sub container has no border",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			events: drag(image.Point{10, 2}, image.Point{14, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				// The mouse events are treated as a click.
+				mustFocusedBorder(ft, image.Rect(10, 0, 20, 5))
+				return ft
+			},
+		},
+		{
+			desc:     "error from SplitOnResize is reported",
+			termSize: image.Point{20, 5},
+			container: func(ft *faketerm.Terminal, rr *resizeRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(Border(linestyle.Light)),
+						Right(Border(linestyle.Light)),
+						SplitDraggable(),
+						SplitOnResize(rr.onResize),
+					),
+				)
+			},
+			resizeErr: errors.New("resize failed"),
+			events:    drag(image.Point{9, 2}, image.Point{14, 2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 14, 5), image.Rect(14, 0, 20, 5))
+				return ft
+			},
+			wantResized: []int{70},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			rr := &resizeRecorder{err: tc.resizeErr}
+			c, err := tc.container(got, rr)
+			if err != nil {
+				if !tc.wantErr {
+					t.Errorf("tc.container => unexpected error: %v", err)
+				}
+				return
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			wantEv := len(tc.events)
+			if tc.wantErr {
+				wantEv++ // The error is also an event.
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), wantEv; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+			if diff := pretty.Compare(tc.wantResized, rr.get()); diff != "" {
+				t.Errorf("SplitOnResize => unexpected values (-want, +got):\n%s", diff)
+			}
+			if err := eh.get(); (err != nil) != tc.wantErr {
+				t.Errorf("errorHandler => unexpected error %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// clearCountingTerm is a fake terminal that counts the calls to Clear.
+type clearCountingTerm struct {
+	*faketerm.Terminal
+
+	clears int
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (cct *clearCountingTerm) Clear(opts ...cell.Option) error {
+	cct.clears++
+	return cct.Terminal.Clear(opts...)
+}
+
+func TestDragClearsOnlyOnRelease(t *testing.T) {
+	ft, err := faketerm.New(image.Point{20, 5})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	term := &clearCountingTerm{Terminal: ft}
+	c, err := New(
+		term,
+		SplitVertical(
+			Left(Border(linestyle.Light)),
+			Right(Border(linestyle.Light)),
+			SplitDraggable(),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	eds := event.NewDistributionSystem()
+	c.Subscribe(eds)
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	term.clears = 0
+
+	events := []*terminalapi.Mouse{
+		{Position: image.Point{10, 2}, Button: mouse.ButtonLeft},
+		{Position: image.Point{8, 2}, Button: mouse.ButtonLeft, Motion: true},
+		{Position: image.Point{6, 2}, Button: mouse.ButtonLeft, Motion: true},
+		{Position: image.Point{6, 2}, Button: mouse.ButtonRelease},
+	}
+	for i, ev := range events {
+		eds.Event(ev)
+		if err := testevent.WaitFor(5*time.Second, func() error {
+			if got, want := eds.Processed(), i+1; got != want {
+				return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("testevent.WaitFor => %v", err)
+		}
+		if err := c.Draw(); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+
+		want := 0
+		if ev.Button == mouse.ButtonRelease {
+			want = 1
+		}
+		if got := term.clears; got != want {
+			t.Errorf("after event %d => terminal cleared %d times, want %d", i, got, want)
+		}
+	}
+}
@@ -29,6 +29,8 @@ import (
 )
 
 // drawTree draws this container and all of its sub containers.
+// If a container is maximized, only the maximized container and its sub
+// containers are drawn.
 func drawTree(c *Container) error {
 	var errStr string
 
@@ -38,9 +40,18 @@ func drawTree(c *Container) error {
 	if err != nil {
 		return err
 	}
-	root.area = ar
+	top := visibleRoot(root)
+	if top != root {
+		// Containers hidden by the maximized container don't occupy any area,
+		// so they cannot be targets of mouse events.
+		preOrderAll(root, &errStr, visitFunc(func(c *Container) error {
+			c.area = image.ZR
+			return nil
+		}))
+	}
+	top.area = ar
 
-	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+	preOrder(top, &errStr, visitFunc(func(c *Container) error {
 		first, second, err := c.split()
 		if err != nil {
 			return err
@@ -101,12 +112,18 @@ func drawBorder(c *Container) error {
 		}
 	}
 
-	if err := draw.Border(cvs, ar,
+	bOpts := []draw.BorderOption{
 		draw.BorderLineStyle(c.opts.border),
-		draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, titleCOpts...),
-		draw.BorderTitleAlign(c.opts.borderTitleHAlign),
 		draw.BorderCellOpts(cOpts...),
-	); err != nil {
+	}
+	if !c.hasTabs() {
+		// The tab strip replaces the border title.
+		bOpts = append(bOpts,
+			draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, titleCOpts...),
+			draw.BorderTitleAlign(c.opts.borderTitleHAlign),
+		)
+	}
+	if err := draw.Border(cvs, ar, bOpts...); err != nil {
 		return err
 	}
 	return cvs.Apply(c.term)
@@ -142,7 +159,8 @@ func drawWidget(c *Container) error {
 	}
 
 	meta := &widgetapi.Meta{
-		Focused: c.focusTracker.isActive(c),
+		Focused:     c.focusTracker.isActive(c),
+		Invalidator: rootCont(c).invalidator,
 	}
 
 	if err := c.opts.widget.Draw(cvs, meta); err != nil {
@@ -178,6 +196,10 @@ func drawCont(c *Container) error {
 		return fmt.Errorf("unable to draw container border: %v", err)
 	}
 
+	if err := drawTabs(c); err != nil {
+		return fmt.Errorf("unable to draw the tab strip: %v", err)
+	}
+
 	if err := drawWidget(c); err != nil {
 		return fmt.Errorf("unable to draw widget %T: %v", c.opts.widget, err)
 	}
@@ -1183,3 +1183,53 @@ func TestDrawHandlesTerminalResize(t *testing.T) {
 		})
 	}
 }
+
+// setCellCounter is a fake terminal that counts calls to SetCell.
+type setCellCounter struct {
+	*faketerm.Terminal
+
+	calls int
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (scc *setCellCounter) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	scc.calls++
+	return scc.Terminal.SetCell(p, r, opts...)
+}
+
+func TestDrawOnlySetsChangedCells(t *testing.T) {
+	ft := &setCellCounter{Terminal: faketerm.MustNew(image.Point{30, 10})}
+	mi := fakewidget.New(widgetapi.Options{})
+	cont, err := New(
+		ft,
+		Border(linestyle.Light),
+		PlaceWidget(mi),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := ft.calls, 30*10; got != want {
+		t.Errorf("initial Draw => made %d calls to SetCell, want %d", got, want)
+	}
+
+	ft.calls = 0
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := ft.calls, 0; got != want {
+		t.Errorf("Draw of an unchanged frame => made %d calls to SetCell, want %d", got, want)
+	}
+
+	ft.calls = 0
+	mi.Text("a")
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got, want := ft.calls, 1; got != want {
+		t.Errorf("Draw after a widget change => made %d calls to SetCell, want %d", got, want)
+	}
+}
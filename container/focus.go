@@ -25,14 +25,14 @@ import (
 )
 
 // pointCont finds the top-most (on the screen) container whose area contains
-// the given point. Returns nil if none of the containers in the tree contain
-// this point.
+// the given point. Returns nil if none of the visible containers in the tree
+// contain this point.
 func pointCont(c *Container, p image.Point) *Container {
 	var (
 		errStr string
 		cont   *Container
 	)
-	postOrder(rootCont(c), &errStr, visitFunc(func(c *Container) error {
+	postOrder(visibleRoot(c), &errStr, visitFunc(func(c *Container) error {
 		if p.In(c.area) && cont == nil {
 			cont = c
 		}
@@ -55,6 +55,19 @@ type focusTracker struct {
 	// buttonFSM is a state machine tracking mouse clicks in containers and
 	// moving focus from one container to the next.
 	buttonFSM *button.FSM
+
+	// traps are the containers that currently trap the focus, e.g. open
+	// overlays. The last trap holds the focus. While any trap exists, the
+	// focus cannot be moved to other containers.
+	traps []*focusTrap
+}
+
+// focusTrap is a container that traps the focus.
+type focusTrap struct {
+	// cont is the container that traps the focus.
+	cont *Container
+	// prev is the container that was focused before the trap was set.
+	prev *Container
 }
 
 // newFocusTracker returns a new focus tracker with focus set at the provided
@@ -79,10 +92,49 @@ func (ft *focusTracker) isActive(c *Container) bool {
 }
 
 // setActive sets the currently active container to the one provided.
+// Does nothing while the focus is trapped.
 func (ft *focusTracker) setActive(c *Container) {
+	if ft.trapped() {
+		return
+	}
 	ft.container = c
 }
 
+// trapped asserts whether the focus is currently trapped in a container.
+func (ft *focusTracker) trapped() bool {
+	return len(ft.traps) > 0
+}
+
+// trap moves the focus to the provided container and keeps it there until
+// the trap is released.
+func (ft *focusTracker) trap(c *Container) {
+	ft.traps = append(ft.traps, &focusTrap{
+		cont: c,
+		prev: ft.container,
+	})
+	ft.container = c
+}
+
+// release releases the trap set on the provided container. If the container
+// holds the focus, the focus returns to the container that was focused before
+// the trap was set. Does nothing if the container doesn't trap the focus.
+func (ft *focusTracker) release(c *Container) {
+	for i, t := range ft.traps {
+		if t.cont != c {
+			continue
+		}
+
+		if i == len(ft.traps)-1 {
+			ft.container = t.prev
+		} else {
+			// The trap above was set while this container was focused.
+			ft.traps[i+1].prev = t.prev
+		}
+		ft.traps = append(ft.traps[:i], ft.traps[i+1:]...)
+		return
+	}
+}
+
 // next moves focus to the next container.
 // If group is not nil, focus will only move between containers with a matching
 // focus group number.
@@ -93,7 +145,7 @@ func (ft *focusTracker) next(group *FocusGroup) {
 		nextCont  *Container
 		focusNext bool
 	)
-	preOrder(rootCont(ft.container), &errStr, visitFunc(func(c *Container) error {
+	preOrder(visibleRoot(ft.container), &errStr, visitFunc(func(c *Container) error {
 		if nextCont != nil {
 			// Already found the next container, nothing to do.
 			return nil
@@ -147,7 +199,7 @@ func (ft *focusTracker) previous(group *FocusGroup) {
 		lastCont    *Container
 		visitedCurr bool
 	)
-	preOrder(rootCont(ft.container), &errStr, visitFunc(func(c *Container) error {
+	preOrder(visibleRoot(ft.container), &errStr, visitFunc(func(c *Container) error {
 		if ft.container == c {
 			visitedCurr = true
 		}
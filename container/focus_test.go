@@ -629,6 +629,61 @@ func TestFocusTrackerNextAndPrevious(t *testing.T) {
 			wantFocused:   contLocB,
 			wantProcessed: 1,
 		},
+		{
+			desc: "keyNext pressed with a modifier doesn't move the focus",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+					),
+					KeyFocusNext(keyNext),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext, Modifiers: keyboard.ModAlt},
+			},
+			wantFocused:   contLocA,
+			wantProcessed: 1,
+		},
+		{
+			desc: "shortcut for next focuses the first container",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+					),
+					ShortcutFocusNext(keyboard.Shortcut{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl}),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyArrowRight},
+				{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl},
+			},
+			wantFocused:   contLocB,
+			wantProcessed: 2,
+		},
+		{
+			desc: "shortcut for previous focuses the last container",
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+					),
+					ShortcutFocusPrevious(keyboard.Shortcut{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl | keyboard.ModShift}),
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl | keyboard.ModShift},
+			},
+			wantFocused:   contLocC,
+			wantProcessed: 1,
+		},
 		{
 			desc: "two keyNext presses focuses the second container",
 			container: func(ft *faketerm.Terminal) (*Container, error) {
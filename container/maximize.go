@@ -0,0 +1,133 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// maximize.go contains code that maximizes a single container.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Maximize maximizes the container with the specified ID, so that it occupies
+// the whole terminal. All the other containers are hidden until Restore is
+// called. Hidden containers don't receive the keyboard focus and their
+// widgets don't receive any events.
+// If the focused container isn't the maximized container or one of its sub
+// containers, the focus moves to the maximized container.
+//
+// The argument id must match exactly one container that was created with the
+// ID() option and is currently visible, i.e. it isn't in an inactive tab.
+func (c *Container) Maximize(id string) error {
+	c.mu.Lock()
+	root := rootCont(c)
+	target, err := findID(root, id)
+	if err == nil && !contains(root, target) {
+		err = fmt.Errorf("cannot maximize container with ID %q, it is in an inactive tab", id)
+	}
+	if err == nil {
+		root.maximize(target)
+	}
+	invalidator := root.invalidator
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	invalidator.Invalidate()
+	return nil
+}
+
+// Restore restores the layout after a call to Maximize.
+// Does nothing if no container is maximized.
+func (c *Container) Restore() {
+	c.mu.Lock()
+	root := rootCont(c)
+	root.maximize(nil)
+	invalidator := root.invalidator
+	c.mu.Unlock()
+	invalidator.Invalidate()
+}
+
+// maximize maximizes the target container or restores the layout if the
+// target is nil.
+// Caller must hold c.mu and c must be the root container.
+func (c *Container) maximize(target *Container) {
+	if target == c {
+		target = nil
+	}
+	if target == c.maximized {
+		return
+	}
+
+	c.maximized = target
+	// A drag of a split line might now be hidden.
+	c.drag = nil
+	c.updateMaximized()
+	// Parts of the terminal might not be covered by the visible containers.
+	c.clearNeeded = true
+}
+
+// updateMaximized ensures that the maximized container is still part of the
+// container tree and that the focused container is visible. Must be called
+// whenever the layout changes.
+// Caller must hold c.mu and c must be the root container.
+func (c *Container) updateMaximized() {
+	if c.maximized != nil && !contains(c, c.maximized) {
+		c.maximized = nil
+	}
+	if top := visibleRoot(c); !c.focusTracker.reachableFrom(top) {
+		c.focusTracker.setActive(top)
+	}
+}
+
+// updateMaximizeFromKeyboard processes the keyboard event and determines if it
+// maximizes the focused container or restores the layout.
+// Returns true if the layout changed.
+// Caller must hold c.mu.
+func (c *Container) updateMaximizeFromKeyboard(k *terminalapi.Keyboard) bool {
+	if c.focusTracker.trapped() {
+		return false
+	}
+
+	root := rootCont(c)
+	key := root.opts.global.keyMaximize
+	if key == nil || *key != k.Shortcut() {
+		return false
+	}
+	prev := root.maximized
+	if prev != nil {
+		root.maximize(nil)
+	} else {
+		root.maximize(c.focusTracker.active())
+	}
+	return root.maximized != prev
+}
+
+// contains asserts whether the target container is part of the tree under the
+// provided node, excluding containers in inactive tabs.
+func contains(node, target *Container) bool {
+	var (
+		errStr string
+		found  bool
+	)
+	preOrder(node, &errStr, visitFunc(func(c *Container) error {
+		if c == target {
+			found = true
+		}
+		return nil
+	}))
+	return found
+}
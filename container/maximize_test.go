@@ -0,0 +1,378 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestMaximize(t *testing.T) {
+	mouseOpts := widgetapi.Options{
+		WantMouse: widgetapi.MouseScopeGlobal,
+	}
+
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// maximize is called after the initial draw.
+		maximize func(c *Container) error
+		events   []terminalapi.Event
+		want     func(size image.Point) *faketerm.Terminal
+		wantErr  bool
+	}{
+		{
+			desc:     "fails on a container that doesn't exist",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			maximize: func(c *Container) error {
+				return c.Maximize("missing")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a container in an inactive tab",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(Tab("a"), Tab("b", ID("b"))))
+			},
+			maximize: func(c *Container) error {
+				return c.Maximize("b")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "maximized container occupies the whole terminal",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			maximize: func(c *Container) error {
+				return c.Maximize("right")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				// The focus moves to the maximized container.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "restore draws all the containers",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			maximize: func(c *Container) error {
+				if err := c.Maximize("right"); err != nil {
+					return err
+				}
+				if err := c.Draw(); err != nil {
+					return err
+				}
+				c.Restore()
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 0, 20, 10)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "key maximizes the focused container and restores the layout",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyMaximize(keyboard.KeyF2),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{15, 5}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{15, 5}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: keyboard.KeyF2},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFocusedBorder(ft, ft.Area())
+				return ft
+			},
+		},
+		{
+			desc:     "pressing the key again restores the layout",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyMaximize(keyboard.KeyF2),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{15, 5}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{15, 5}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: keyboard.KeyF2},
+				&terminalapi.Keyboard{Key: keyboard.KeyF2},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 10))
+				mustFocusedBorder(ft, image.Rect(10, 0, 20, 10))
+				return ft
+			},
+		},
+		{
+			desc:     "focus only moves between the visible containers",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyFocusNext(keyboard.KeyTab),
+					SplitHorizontal(
+						Top(
+							Border(linestyle.Light),
+						),
+						Bottom(
+							ID("bottom"),
+							SplitVertical(
+								Left(
+									Border(linestyle.Light),
+								),
+								Right(
+									Border(linestyle.Light),
+								),
+							),
+						),
+					),
+				)
+			},
+			maximize: func(c *Container) error {
+				return c.Maximize("bottom")
+			},
+			events: []terminalapi.Event{
+				// From the maximized container to the left, right and back to
+				// the left.
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFocusedBorder(ft, image.Rect(0, 0, 10, 10))
+				mustBorders(ft, image.Rect(10, 0, 20, 10))
+				return ft
+			},
+		},
+		{
+			desc:     "widgets in hidden containers don't receive mouse events",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+					),
+				)
+			},
+			maximize: func(c *Container) error {
+				if err := c.Maximize("right"); err != nil {
+					return err
+				}
+				// Event positions are relative to the areas from the last draw.
+				return c.Draw()
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					mouseOpts,
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "layout is restored when the maximized container is removed",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID("root"),
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							ID("right"),
+							Border(linestyle.Light),
+						),
+					),
+				)
+			},
+			maximize: func(c *Container) error {
+				if err := c.Maximize("right"); err != nil {
+					return err
+				}
+				return c.Update("root", SplitVertical(
+					Left(
+						Border(linestyle.Light),
+					),
+					Right(
+						Border(linestyle.Light),
+					),
+				))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustBorders(ft, image.Rect(0, 0, 10, 10), image.Rect(10, 0, 20, 10))
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if tc.maximize != nil {
+				err := tc.maximize(c)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("tc.maximize => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
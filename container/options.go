@@ -64,17 +64,31 @@ func validateSplits(c *Container) error {
 	return nil
 }
 
+// ensure the keys that switch tabs are only set on tabbed containers.
+func validateTabs(c *Container) error {
+	if c.hasTabs() {
+		return nil
+	}
+	if c.opts.keyTabNext != nil || c.opts.keyTabPrevious != nil {
+		return errors.New("the KeyTabNext and KeyTabPrevious options can only be used on a container with the Tabs option")
+	}
+	return nil
+}
+
 // validateOptions validates options set in the container tree.
 func validateOptions(c *Container) error {
 	var errStr string
 	seenID := map[string]bool{}
-	preOrder(c, &errStr, func(c *Container) error {
+	preOrderAll(c, &errStr, func(c *Container) error {
 		if err := validateIds(c, seenID); err != nil {
 			return err
 		}
 		if err := validateSplits(c); err != nil {
 			return err
 		}
+		if err := validateTabs(c); err != nil {
+			return err
+		}
 
 		return nil
 	})
@@ -113,6 +127,16 @@ type options struct {
 	splitPercent  int
 	splitFixed    int
 
+	// splitDraggable indicates whether the split can be resized by dragging
+	// the split line with the mouse.
+	splitDraggable bool
+	// splitMinPercent and splitMaxPercent limit the size of the first
+	// container when the split is dragged.
+	splitMinPercent int
+	splitMaxPercent int
+	// splitOnResize is called when the split was resized by dragging.
+	splitOnResize func(perc int) error
+
 	// widget is the widget in the container.
 	// A container can have either two sub containers (left and right) or a
 	// widget. But not both.
@@ -139,6 +163,13 @@ type options struct {
 	keyFocusSkip bool
 	// keyFocusGroups are the focus groups this container belongs to.
 	keyFocusGroups []FocusGroup
+
+	// keyTabNext when set is the key that activates the next tab of this
+	// container.
+	keyTabNext *keyboard.Shortcut
+	// keyTabPrevious when set is the key that activates the previous tab of
+	// this container.
+	keyTabPrevious *keyboard.Shortcut
 }
 
 // margin stores the configured margin for the container.
@@ -223,17 +254,20 @@ func (fg focusGroups) firstMatching(contGroups []FocusGroup) (bool, FocusGroup)
 // effect on all the containers in the tree.
 type globalOptions struct {
 	// keyFocusNext when set is the key that moves the focus to the next container.
-	keyFocusNext *keyboard.Key
+	keyFocusNext *keyboard.Shortcut
 	// keyFocusPrevious when set is the key that moves the focus to the previous container.
-	keyFocusPrevious *keyboard.Key
+	keyFocusPrevious *keyboard.Shortcut
+	// keyMaximize when set is the key that maximizes the focused container
+	// or restores the layout.
+	keyMaximize *keyboard.Shortcut
 	// keysFocusGroupNext maps keyboard keys that move to the next container
 	// within a focus group to the focus groups they should work on in the
 	// order they were configured.
-	keyFocusGroupsNext map[keyboard.Key]focusGroups
+	keyFocusGroupsNext map[keyboard.Shortcut]focusGroups
 	// keysFocusGroupPrevious maps keyboard keys that move to the previous
 	// container within a focus group to the focus groups they should work on
 	// in the order they were configured.
-	keyFocusGroupsPrevious map[keyboard.Key]focusGroups
+	keyFocusGroupsPrevious map[keyboard.Shortcut]focusGroups
 }
 
 // newOptions returns a new options instance with the default values.
@@ -242,8 +276,8 @@ type globalOptions struct {
 func newOptions(parent *options) *options {
 	opts := &options{
 		global: &globalOptions{
-			keyFocusGroupsNext:     map[keyboard.Key]focusGroups{},
-			keyFocusGroupsPrevious: map[keyboard.Key]focusGroups{},
+			keyFocusGroupsNext:     map[keyboard.Shortcut]focusGroups{},
+			keyFocusGroupsPrevious: map[keyboard.Shortcut]focusGroups{},
 		},
 		inherited: inherited{
 			focusedColor: cell.ColorYellow,
@@ -253,6 +287,9 @@ func newOptions(parent *options) *options {
 		splitReversed: DefaultSplitReversed,
 		splitPercent:  DefaultSplitPercent,
 		splitFixed:    DefaultSplitFixed,
+
+		splitMinPercent: DefaultSplitMinPercent,
+		splitMaxPercent: DefaultSplitMaxPercent,
 	}
 	if parent != nil {
 		opts.global = parent.global
@@ -370,6 +407,64 @@ func SplitFixedFromEnd(cells int) SplitOption {
 	})
 }
 
+// SplitDraggable allows the user to resize the split by dragging the split
+// line with the left mouse button. The split line is formed by the adjacent
+// borders of the two sub containers, so both of them must have a border.
+// Dragging converts a split created with SplitFixed or SplitFixedFromEnd into
+// a percentage based split, i.e. the containers keep their relative sizes when
+// the terminal is resized afterwards.
+// Mouse events that start or continue the drag aren't delivered to widgets.
+func SplitDraggable() SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.splitDraggable = true
+		return nil
+	})
+}
+
+// DefaultSplitMinPercent is the default value for the minimum in the
+// SplitDragLimits option.
+const DefaultSplitMinPercent = 1
+
+// DefaultSplitMaxPercent is the default value for the maximum in the
+// SplitDragLimits option.
+const DefaultSplitMaxPercent = 99
+
+// SplitDragLimits limits how far the split line can be dragged when the split
+// is created with SplitDraggable. The limits are the smallest and the largest
+// size of the first (left or top) container as a percentage of the available
+// space, the second container gets the remainder. I.e. the limits of the second
+// container are 100-maxPerc and 100-minPerc.
+// The values must be in the range 0 < minPerc <= maxPerc < 100.
+// If not provided, defaults to DefaultSplitMinPercent and
+// DefaultSplitMaxPercent.
+func SplitDragLimits(minPerc, maxPerc int) SplitOption {
+	return splitOption(func(opts *options) error {
+		if min, max := 0, 100; minPerc <= min || maxPerc >= max || minPerc > maxPerc {
+			return fmt.Errorf("invalid split drag limits %d and %d, must be in range %d < minPerc <= maxPerc < %d", minPerc, maxPerc, min, max)
+		}
+		opts.splitMinPercent = minPerc
+		opts.splitMaxPercent = maxPerc
+		return nil
+	})
+}
+
+// SplitOnResize sets a function that is called when the user finishes
+// dragging the split line of a split created with SplitDraggable and the size
+// of the containers changed. The function receives the new size of the first
+// (left or top) container as a percentage of the available space, e.g. in
+// order to save the layout. The same layout can be restored by passing that
+// value to SplitPercent.
+//
+// The function is called synchronously, while the mouse event is being
+// processed. Any errors returned by the function are delivered to the
+// subscribers of terminalapi.Error events.
+func SplitOnResize(fn func(perc int) error) SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.splitOnResize = fn
+		return nil
+	})
+}
+
 // SplitVertical splits the container along the vertical axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
@@ -377,6 +472,7 @@ func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeVertical
 		c.opts.widget = nil
+		c.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -397,6 +493,7 @@ func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeHorizontal
 		c.opts.widget = nil
+		c.tabs = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -411,6 +508,105 @@ func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	})
 }
 
+// TabOption is used to provide a tab to a tabbed container.
+type TabOption interface {
+	// tab returns the name of the tab and the options of its container.
+	tab() (string, []Option)
+}
+
+// tabOption implements TabOption.
+type tabOption func() (string, []Option)
+
+// tab implements TabOption.tab.
+func (to tabOption) tab() (string, []Option) {
+	return to()
+}
+
+// Tab creates a tab with the provided name. The options are applied to the
+// container that holds the content of the tab, e.g. a widget or a split tree
+// built by the grid package.
+func Tab(name string, opts ...Option) TabOption {
+	return tabOption(func() (string, []Option) {
+		return name, opts
+	})
+}
+
+// Tabs places the provided tabs into the container. Only the content of the
+// active tab is displayed, the first tab is active initially. The content of
+// inactive tabs is kept including the state of their widgets and the
+// container that was focused when the tab was deactivated.
+//
+// The names of the tabs are displayed in a tab strip. If the container has a
+// border, the tab strip replaces the border title. Otherwise the top line of
+// the container is reserved for the tab strip. Clicking on a name with the
+// left mouse button activates the tab, see also KeyTabNext and
+// KeyTabPrevious.
+//
+// At least one tab must be provided and all tabs must have unique non-empty
+// names. The use of this option removes any widget or sub containers.
+func Tabs(tabs ...TabOption) Option {
+	return option(func(c *Container) error {
+		if len(tabs) == 0 {
+			return errors.New("the Tabs option requires at least one tab")
+		}
+
+		var created []*tab
+		seen := map[string]bool{}
+		for _, to := range tabs {
+			name, opts := to.tab()
+			if name == "" {
+				return errors.New("the tab name cannot be an empty string")
+			}
+			if seen[name] {
+				return fmt.Errorf("duplicate tab name %q", name)
+			}
+			seen[name] = true
+
+			cont, err := newChild(c, opts)
+			if err != nil {
+				return err
+			}
+			created = append(created, &tab{
+				name: name,
+				cont: cont,
+			})
+		}
+
+		c.opts.widget = nil
+		c.second = nil
+		c.tabs = created
+		c.activeTab = 0
+		c.first = created[0].cont
+		return nil
+	})
+}
+
+// KeyTabNext configures a key that activates the next tab when pressed. If the
+// last tab is active, the first tab gets activated.
+//
+// Can only be used on a container with the Tabs option. The key applies while
+// the focused container is this container or any container in its active tab.
+// When tabbed containers are nested, the innermost container with a matching
+// key activates its tab. The key is still delivered to the widgets.
+func KeyTabNext(key keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.keyTabNext = &keyboard.Shortcut{Key: key}
+		return nil
+	})
+}
+
+// KeyTabPrevious configures a key that activates the previous tab when
+// pressed. If the first tab is active, the last tab gets activated.
+//
+// Can only be used on a container with the Tabs option. See KeyTabNext for
+// details on when the key applies.
+func KeyTabPrevious(key keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.keyTabPrevious = &keyboard.Shortcut{Key: key}
+		return nil
+	})
+}
+
 // ID sets an identifier for this container.
 // This ID can be later used to perform dynamic layout changes by passing new
 // options to this container. When provided, it must be a non-empty string that
@@ -433,6 +629,7 @@ func Clear() Option {
 		c.opts.widget = nil
 		c.first = nil
 		c.second = nil
+		c.tabs = nil
 		return nil
 	})
 }
@@ -445,6 +642,7 @@ func PlaceWidget(w widgetapi.Widget) Option {
 		c.opts.widget = w
 		c.first = nil
 		c.second = nil
+		c.tabs = nil
 		return nil
 	})
 }
@@ -952,9 +1150,21 @@ func Bottom(opts ...Option) BottomOption {
 // This option is global and applies to all created containers.
 // If neither of (KeyFocusNext, KeyFocusPrevious) is specified, the keyboard
 // focus can only be changed by using the mouse.
+//
+// The key only moves the focus when pressed without any modifier keys, use
+// ShortcutFocusNext to configure a key with modifiers.
 func KeyFocusNext(key keyboard.Key) Option {
+	return ShortcutFocusNext(keyboard.Shortcut{Key: key})
+}
+
+// ShortcutFocusNext is like KeyFocusNext, but the key must be pressed together
+// with the specified modifier keys.
+//
+// This option is global and applies to all created containers. It replaces
+// the key configured by KeyFocusNext.
+func ShortcutFocusNext(s keyboard.Shortcut) Option {
 	return option(func(c *Container) error {
-		c.opts.global.keyFocusNext = &key
+		c.opts.global.keyFocusNext = &s
 		return nil
 	})
 }
@@ -970,9 +1180,33 @@ func KeyFocusNext(key keyboard.Key) Option {
 // This option is global and applies to all created containers.
 // If neither of (KeyFocusNext, KeyFocusPrevious) is specified, the keyboard
 // focus can only be changed by using the mouse.
+//
+// The key only moves the focus when pressed without any modifier keys, use
+// ShortcutFocusPrevious to configure a key with modifiers.
 func KeyFocusPrevious(key keyboard.Key) Option {
+	return ShortcutFocusPrevious(keyboard.Shortcut{Key: key})
+}
+
+// ShortcutFocusPrevious is like KeyFocusPrevious, but the key must be pressed
+// together with the specified modifier keys.
+//
+// This option is global and applies to all created containers. It replaces
+// the key configured by KeyFocusPrevious.
+func ShortcutFocusPrevious(s keyboard.Shortcut) Option {
+	return option(func(c *Container) error {
+		c.opts.global.keyFocusPrevious = &s
+		return nil
+	})
+}
+
+// KeyMaximize configures a key that maximizes the focused container when
+// pressed, so that it occupies the whole terminal. Pressing the key again
+// restores the layout. See Container.Maximize for details.
+//
+// This option is global and applies to all created containers.
+func KeyMaximize(key keyboard.Key) Option {
 	return option(func(c *Container) error {
-		c.opts.global.keyFocusPrevious = &key
+		c.opts.global.keyMaximize = &keyboard.Shortcut{Key: key}
 		return nil
 	})
 }
@@ -1044,6 +1278,7 @@ func KeyFocusGroups(groups ...FocusGroup) Option {
 // any container regardless of its focus group.
 func KeyFocusGroupsNext(key keyboard.Key, groups ...FocusGroup) Option {
 	return option(func(c *Container) error {
+		key := keyboard.Shortcut{Key: key}
 		for _, g := range groups {
 			if min := FocusGroup(0); g < min {
 				return fmt.Errorf("invalid group %d in KeyFocusGroupsNext for key %q, must be 0 <= group", g, key)
@@ -1085,6 +1320,7 @@ func KeyFocusGroupsNext(key keyboard.Key, groups ...FocusGroup) Option {
 // any container regardless of its focus group.
 func KeyFocusGroupsPrevious(key keyboard.Key, groups ...FocusGroup) Option {
 	return option(func(c *Container) error {
+		key := keyboard.Shortcut{Key: key}
 		for _, g := range groups {
 			if min := FocusGroup(0); g < min {
 				return fmt.Errorf("invalid group %d in KeyFocusGroupsNext for key %q, must be 0 <= group", g, key)
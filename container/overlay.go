@@ -0,0 +1,326 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// overlay.go contains code that displays widgets on top of the containers.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Placement determines where on the terminal an overlay is drawn.
+// Use one of the functions Centered or Anchored to create a Placement.
+type Placement struct {
+	// anchorID is the ID of the container the overlay is anchored to.
+	// Empty if the overlay is centered on the terminal.
+	anchorID string
+
+	// widthPerc and heightPerc are the size of the overlay as a percentage of
+	// the area it is placed in.
+	widthPerc  int
+	heightPerc int
+}
+
+// Centered places the overlay at the center of the terminal.
+// The size of the overlay is specified as a percentage of the terminal
+// width and height, both must be in the range 0 < perc <= 100.
+func Centered(widthPerc, heightPerc int) Placement {
+	return Placement{
+		widthPerc:  widthPerc,
+		heightPerc: heightPerc,
+	}
+}
+
+// Anchored places the overlay at the center of the container with the
+// specified ID. The container must have been created with the ID option.
+// The size of the overlay is specified as a percentage of the container
+// width and height, both must be in the range 0 < perc <= 100.
+func Anchored(id string, widthPerc, heightPerc int) Placement {
+	return Placement{
+		anchorID:   id,
+		widthPerc:  widthPerc,
+		heightPerc: heightPerc,
+	}
+}
+
+// validate validates the placement.
+func (p Placement) validate(root *Container) error {
+	if min, max := 0, 100; p.widthPerc <= min || p.widthPerc > max {
+		return fmt.Errorf("invalid overlay width percentage %d, must be in range %d < perc <= %d", p.widthPerc, min, max)
+	}
+	if min, max := 0, 100; p.heightPerc <= min || p.heightPerc > max {
+		return fmt.Errorf("invalid overlay height percentage %d, must be in range %d < perc <= %d", p.heightPerc, min, max)
+	}
+	if p.anchorID != "" {
+		if _, err := findID(root, p.anchorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// area returns the area the overlay occupies on the terminal.
+func (p Placement) area(root *Container) (image.Rectangle, error) {
+	base := root.area
+	if p.anchorID != "" {
+		anchor, err := findID(root, p.anchorID)
+		if err != nil {
+			return image.ZR, fmt.Errorf("unable to place overlay: %v", err)
+		}
+		base = anchor.area
+	}
+
+	width := base.Dx() * p.widthPerc / 100
+	height := base.Dy() * p.heightPerc / 100
+	if width <= 0 || height <= 0 {
+		return image.ZR, nil
+	}
+	ar := image.Rect(base.Min.X, base.Min.Y, base.Min.X+width, base.Min.Y+height)
+	return alignfor.Rectangle(base, ar, align.HorizontalCenter, align.VerticalMiddle)
+}
+
+// overlay is a widget displayed on top of the containers.
+type overlay struct {
+	// id identifies the overlay.
+	id string
+	// cont is the container that holds the overlay's widget.
+	cont *Container
+	// placement determines where the overlay is drawn.
+	placement Placement
+	// dim indicates whether the content beneath the overlay should be dimmed.
+	dim bool
+}
+
+// updateArea updates the area of the overlay's container according to the
+// current layout. Returns the area the overlay occupies on the terminal.
+func (o *overlay) updateArea(root *Container) (image.Rectangle, error) {
+	ar, err := o.placement.area(root)
+	if err != nil {
+		return image.ZR, err
+	}
+	if ar.Dx() <= 0 || ar.Dy() <= 0 {
+		o.cont.area = image.ZR
+		return ar, nil
+	}
+
+	contAr, err := o.cont.opts.margin.apply(ar)
+	if err != nil {
+		return image.ZR, err
+	}
+	o.cont.area = contAr
+	return ar, nil
+}
+
+// OverlayOption is used to provide options to ShowOverlay.
+type OverlayOption interface {
+	// set sets the provided option.
+	set(*overlay) error
+}
+
+// overlayOption implements OverlayOption.
+type overlayOption func(*overlay) error
+
+// set implements OverlayOption.set.
+func (oo overlayOption) set(o *overlay) error {
+	return oo(o)
+}
+
+// OverlayDim dims the content beneath the overlay, i.e. all the containers
+// and any overlays shown before this one.
+func OverlayDim() OverlayOption {
+	return overlayOption(func(o *overlay) error {
+		o.dim = true
+		return nil
+	})
+}
+
+// OverlayContainer applies the provided options to the container that holds
+// the overlay's widget, e.g. Border or BorderTitle. Options that split the
+// container aren't allowed.
+func OverlayContainer(opts ...Option) OverlayOption {
+	return overlayOption(func(o *overlay) error {
+		return applyOptions(o.cont, opts...)
+	})
+}
+
+// ShowOverlay displays the widget on top of all the containers. Overlays are
+// stacked, the last shown overlay is on top of all the others.
+//
+// While an overlay is shown, the focus is trapped in it. The widget in the
+// top overlay receives all the keyboard and mouse events according to the
+// scopes it requested, no other widgets receive any events.
+//
+// The argument id identifies the overlay when calling HideOverlay and must be
+// a non-empty string that is unique among the shown overlays.
+func (c *Container) ShowOverlay(id string, w widgetapi.Widget, p Placement, opts ...OverlayOption) error {
+	c.mu.Lock()
+	root := rootCont(c)
+	err := root.showOverlay(id, w, p, opts...)
+	invalidator := root.invalidator
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	invalidator.Invalidate()
+	return nil
+}
+
+// showOverlay implements ShowOverlay.
+// Caller must hold c.mu and c must be the root container.
+func (c *Container) showOverlay(id string, w widgetapi.Widget, p Placement, opts ...OverlayOption) error {
+	if id == "" {
+		return errors.New("the overlay ID must not be empty")
+	}
+	if w == nil {
+		return errors.New("the overlay widget must not be nil")
+	}
+	if _, err := c.findOverlay(id); err == nil {
+		return fmt.Errorf("overlay with ID %q is already shown", id)
+	}
+	if err := p.validate(c); err != nil {
+		return err
+	}
+
+	cont, err := newChild(c, nil)
+	if err != nil {
+		return err
+	}
+	o := &overlay{
+		id:        id,
+		cont:      cont,
+		placement: p,
+	}
+	for _, opt := range opts {
+		if err := opt.set(o); err != nil {
+			return err
+		}
+	}
+	if !cont.isLeaf() {
+		return fmt.Errorf("the container of overlay %q cannot be split", id)
+	}
+	if err := applyOptions(cont, PlaceWidget(w)); err != nil {
+		return err
+	}
+	// Position the overlay right away, so that it can receive mouse events
+	// even before the next redraw.
+	if _, err := o.updateArea(c); err != nil {
+		return err
+	}
+
+	c.overlays = append(c.overlays, o)
+	c.focusTracker.trap(cont)
+	return nil
+}
+
+// HideOverlay hides the overlay with the specified ID. If the hidden overlay
+// had the focus, the focus returns to the container that was focused before
+// the overlay was shown.
+func (c *Container) HideOverlay(id string) error {
+	c.mu.Lock()
+	root := rootCont(c)
+	err := root.hideOverlay(id)
+	invalidator := root.invalidator
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	invalidator.Invalidate()
+	return nil
+}
+
+// hideOverlay implements HideOverlay.
+// Caller must hold c.mu and c must be the root container.
+func (c *Container) hideOverlay(id string) error {
+	i, err := c.findOverlay(id)
+	if err != nil {
+		return err
+	}
+	o := c.overlays[i]
+	c.overlays = append(c.overlays[:i], c.overlays[i+1:]...)
+	c.focusTracker.release(o.cont)
+
+	// The previously focused container might not be visible anymore if the
+	// layout was updated while the overlay was shown.
+	if !c.focusTracker.trapped() {
+		c.updateMaximized()
+	}
+	// Parts of the terminal previously covered by the overlay might not be
+	// redrawn by the containers.
+	c.clearNeeded = true
+	return nil
+}
+
+// findOverlay returns the index of the overlay with the specified ID.
+// Returns an error if no such overlay is shown.
+// Caller must hold c.mu and c must be the root container.
+func (c *Container) findOverlay(id string) (int, error) {
+	for i, o := range c.overlays {
+		if o.id == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("cannot find overlay with ID %q", id)
+}
+
+// topOverlay returns the overlay shown on top of all the others or nil if no
+// overlays are shown.
+// Caller must hold c.mu.
+func (c *Container) topOverlay() *overlay {
+	root := rootCont(c)
+	if len(root.overlays) == 0 {
+		return nil
+	}
+	return root.overlays[len(root.overlays)-1]
+}
+
+// drawOverlays draws all the overlays on top of the containers.
+// Caller must hold c.mu.
+func drawOverlays(c *Container) error {
+	root := rootCont(c)
+	for _, o := range root.overlays {
+		if o.dim {
+			size := root.term.Size()
+			root.term.SetAreaCellOpts(image.Rect(0, 0, size.X, size.Y), cell.Dim())
+		}
+
+		ar, err := o.updateArea(root)
+		if err != nil {
+			return err
+		}
+		if ar.Dx() <= 0 || ar.Dy() <= 0 {
+			continue
+		}
+		// Clear the area, so that no content beneath shows through.
+		cvs, err := canvas.New(ar)
+		if err != nil {
+			return err
+		}
+		if err := cvs.Apply(root.term); err != nil {
+			return err
+		}
+		if err := drawCont(o.cont); err != nil {
+			return fmt.Errorf("unable to draw overlay %q: %v", o.id, err)
+		}
+	}
+	return nil
+}
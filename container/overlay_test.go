@@ -0,0 +1,549 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// dimTerm applies the dim cell option to all the cells on the terminal.
+func dimTerm(ft *faketerm.Terminal) {
+	for _, col := range ft.BackBuffer() {
+		for _, c := range col {
+			c.Apply(cell.Dim())
+		}
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	keyOpts := widgetapi.Options{
+		WantKeyboard: widgetapi.KeyScopeGlobal,
+		WantMouse:    widgetapi.MouseScopeGlobal,
+	}
+
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// overlays shows and hides overlays after the initial draw.
+		overlays func(c *Container) error
+		events   []terminalapi.Event
+		want     func(size image.Point) *faketerm.Terminal
+		wantErr  bool
+	}{
+		{
+			desc:     "fails on an empty ID",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("", fakewidget.New(widgetapi.Options{}), Centered(50, 50))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a nil widget",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", nil, Centered(50, 50))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a duplicate ID",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				if err := c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 50)); err != nil {
+					return err
+				}
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 50))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a zero width percentage",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(0, 50))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a height percentage above 100",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 101))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails when anchored to a container that doesn't exist",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Anchored("missing", 50, 50))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails when the overlay container is split",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay(
+					"o",
+					fakewidget.New(widgetapi.Options{}),
+					Centered(50, 50),
+					OverlayContainer(SplitVertical(Left(), Right())),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails to hide an overlay that isn't shown",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			overlays: func(c *Container) error {
+				return c.HideOverlay("o")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "draws overlay centered on the terminal",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 60))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "draws overlay anchored to a container",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Anchored("right", 100, 50))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 0, 20, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 2, 20, 7)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "dims the content beneath the overlay",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 60), OverlayDim())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				dimTerm(ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "applies container options to the overlay",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay(
+					"o",
+					fakewidget.New(widgetapi.Options{}),
+					Centered(60, 80),
+					OverlayContainer(Border(linestyle.Light)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+
+				cvs := testcanvas.MustNew(image.Rect(4, 1, 16, 9))
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "stacks multiple overlays",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				if err := c.ShowOverlay("bottom", fakewidget.New(widgetapi.Options{}), Centered(80, 80)); err != nil {
+					return err
+				}
+				return c.ShowOverlay("top", fakewidget.New(widgetapi.Options{}), Centered(50, 60))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(2, 1, 18, 9)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "hiding the overlay restores the content and the focus",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				if err := c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 60), OverlayDim()); err != nil {
+					return err
+				}
+				if err := c.Draw(); err != nil {
+					return err
+				}
+				return c.HideOverlay("o")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "hiding the bottom overlay keeps the focus in the top one",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			overlays: func(c *Container) error {
+				if err := c.ShowOverlay("bottom", fakewidget.New(widgetapi.Options{}), Centered(80, 80)); err != nil {
+					return err
+				}
+				if err := c.ShowOverlay("top", fakewidget.New(widgetapi.Options{}), Centered(50, 60)); err != nil {
+					return err
+				}
+				return c.HideOverlay("bottom")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "overlay receives all keyboard and mouse events",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					PlaceWidget(fakewidget.New(keyOpts)),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(keyOpts), Centered(50, 50))
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{11, 6}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					// Drawn focused before the overlay was shown.
+					&widgetapi.Meta{Focused: true},
+					keyOpts,
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 5, 30, 15)),
+					&widgetapi.Meta{Focused: true},
+					keyOpts,
+					&fakewidget.Event{
+						Ev:   &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+					&fakewidget.Event{
+						Ev:   &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+						Meta: &widgetapi.EventMeta{Focused: true},
+					},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "focus doesn't move while the overlay is shown",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyFocusNext(keyboard.KeyTab),
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			overlays: func(c *Container) error {
+				return c.ShowOverlay("o", fakewidget.New(widgetapi.Options{}), Centered(50, 60))
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 0, 20, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(5, 2, 15, 8)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			err = tc.overlays(c)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.overlays => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
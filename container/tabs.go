@@ -0,0 +1,223 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// tabs.go contains code that manages tabbed containers.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// tab is a single tab of a tabbed container.
+type tab struct {
+	// name is the name of the tab displayed in the tab strip.
+	name string
+	// cont is the container that holds the content of the tab.
+	cont *Container
+	// focused is the container in this tab that was focused when the tab got
+	// deactivated. Nil if the focus wasn't in this tab.
+	focused *Container
+}
+
+// tabLabel is the label of a single tab in the tab strip.
+type tabLabel struct {
+	// index is the index of the tab.
+	index int
+	// text is the text of the label.
+	text string
+	// ar is the area the label occupies on the terminal.
+	ar image.Rectangle
+}
+
+// tabLabels returns the labels of the tabs that fit into the tab strip of the
+// container. The last label might be cut short.
+func (c *Container) tabLabels() []*tabLabel {
+	if !c.hasTabs() || c.area.Dy() <= 0 {
+		return nil
+	}
+
+	minX, maxX := c.area.Min.X, c.area.Max.X
+	if c.hasBorder() {
+		// Don't draw over the corners of the border.
+		minX++
+		maxX--
+	}
+
+	var labels []*tabLabel
+	x := minX
+	for i, t := range c.tabs {
+		if x >= maxX {
+			break
+		}
+		text := fmt.Sprintf(" %s ", t.name)
+		width := runewidth.StringWidth(text)
+		if x+width > maxX {
+			width = maxX - x
+		}
+		labels = append(labels, &tabLabel{
+			index: i,
+			text:  text,
+			ar:    image.Rect(x, c.area.Min.Y, x+width, c.area.Min.Y+1),
+		})
+		x += width
+	}
+	return labels
+}
+
+// activateTab activates the tab with the provided index.
+// If the focus was in the previously active tab, it moves to the container
+// that was focused when the newly active tab was last deactivated.
+// Caller must hold c.mu.
+func (c *Container) activateTab(index int) {
+	if index == c.activeTab || index < 0 || index >= len(c.tabs) {
+		return
+	}
+
+	ft := c.focusTracker
+	prev := c.tabs[c.activeTab]
+	hadFocus := ft.isActive(c) || ft.reachableFrom(prev.cont)
+	if ft.reachableFrom(prev.cont) {
+		prev.focused = ft.active()
+	} else {
+		prev.focused = nil
+	}
+
+	next := c.tabs[index]
+	c.activeTab = index
+	c.first = next.cont
+	if hadFocus {
+		ft.setActive(c)
+		if next.focused != nil {
+			ft.setActive(next.focused)
+			// The layout of the tab might have been updated since.
+			if !ft.reachableFrom(next.cont) {
+				ft.setActive(c)
+			}
+		}
+	}
+	// Parts of the terminal might not be covered by the newly active tab.
+	rootCont(c).clearNeeded = true
+}
+
+// updateTabsFromKeyboard processes the keyboard event and determines if it
+// changes the active tab of a tabbed container. Only the innermost tabbed
+// container that contains the focused container and has a matching key
+// configured activates its tab.
+// Returns true if the active tab changed.
+// Caller must hold c.mu.
+func (c *Container) updateTabsFromKeyboard(k *terminalapi.Keyboard) bool {
+	if c.focusTracker.trapped() {
+		return false
+	}
+
+	top := visibleRoot(c)
+	sc := k.Shortcut()
+	for cur := c.focusTracker.active(); cur != nil; cur = cur.parent {
+		if cur.hasTabs() {
+			prev := cur.activeTab
+			switch {
+			case cur.opts.keyTabNext != nil && *cur.opts.keyTabNext == sc:
+				cur.activateTab((cur.activeTab + 1) % len(cur.tabs))
+				return cur.activeTab != prev
+			case cur.opts.keyTabPrevious != nil && *cur.opts.keyTabPrevious == sc:
+				cur.activateTab((cur.activeTab + len(cur.tabs) - 1) % len(cur.tabs))
+				return cur.activeTab != prev
+			}
+		}
+		if cur == top {
+			// Containers above the maximized container are hidden.
+			return false
+		}
+	}
+	return false
+}
+
+// updateTabsFromMouse processes the mouse event and determines if it changes
+// the active tab of a tabbed container, i.e. if the left mouse button was
+// pressed on a tab label.
+// Returns true if the active tab changed.
+// Caller must hold c.mu.
+func (c *Container) updateTabsFromMouse(m *terminalapi.Mouse) bool {
+	if c.focusTracker.trapped() || m.Button != mouse.ButtonLeft || m.Motion {
+		return false
+	}
+
+	var (
+		errStr string
+		target *Container
+		index  int
+	)
+	preOrder(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
+		for _, l := range cur.tabLabels() {
+			if m.Position.In(l.ar) {
+				target = cur
+				index = l.index
+			}
+		}
+		return nil
+	}))
+	if target == nil {
+		return false
+	}
+	prev := target.activeTab
+	target.activateTab(index)
+	return target.activeTab != prev
+}
+
+// drawTabs draws the tab strip of a tabbed container.
+func drawTabs(c *Container) error {
+	labels := c.tabLabels()
+	if len(labels) == 0 {
+		return nil
+	}
+
+	color := c.opts.inherited.borderColor
+	if c.focusTracker.isActive(c) {
+		color = c.opts.inherited.focusedColor
+	}
+
+	for _, l := range labels {
+		cvs, err := canvas.New(l.ar)
+		if err != nil {
+			return err
+		}
+
+		cOpts := []cell.Option{cell.FgColor(color)}
+		if l.index == c.activeTab {
+			cOpts = append(cOpts, cell.Inverse())
+		}
+		if err := cvs.SetAreaCells(cvs.Area(), ' ', cOpts...); err != nil {
+			return err
+		}
+		if err := draw.Text(cvs, l.text, image.Point{0, 0},
+			draw.TextCellOpts(cOpts...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+		if err := cvs.Apply(c.term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// twoBorders returns options for a vertical split into two containers with
+// borders.
+func twoBorders() []Option {
+	return []Option{
+		SplitVertical(
+			Left(Border(linestyle.Light)),
+			Right(Border(linestyle.Light)),
+		),
+	}
+}
+
+// mustTwoBorders draws the expected content of twoBorders into the area.
+// The argument focused is the index of the focused container or -1.
+func mustTwoBorders(ft *faketerm.Terminal, ar image.Rectangle, focused int) {
+	left, right := image.Rect(ar.Min.X, ar.Min.Y, ar.Min.X+ar.Dx()/2, ar.Max.Y), image.Rect(ar.Min.X+ar.Dx()/2, ar.Min.Y, ar.Max.X, ar.Max.Y)
+	for i, r := range []image.Rectangle{left, right} {
+		var cOpts []cell.Option
+		if i == focused {
+			cOpts = append(cOpts, cell.FgColor(cell.ColorYellow))
+		}
+		cvs := testcanvas.MustNew(r)
+		testdraw.MustBorder(cvs, cvs.Area(), draw.BorderCellOpts(cOpts...))
+		testcanvas.MustApply(cvs, ft)
+	}
+}
+
+// mustTabStrip draws the expected tab strip starting at the point.
+func mustTabStrip(ft *faketerm.Terminal, start image.Point, color cell.Color, active int, names ...string) {
+	x := start.X
+	for i, n := range names {
+		cOpts := []cell.Option{cell.FgColor(color)}
+		if i == active {
+			cOpts = append(cOpts, cell.Inverse())
+		}
+		text := fmt.Sprintf(" %s ", n)
+		cvs := testcanvas.MustNew(image.Rect(x, start.Y, x+len(text), start.Y+1))
+		testdraw.MustText(cvs, text, image.Point{0, 0}, draw.TextCellOpts(cOpts...))
+		testcanvas.MustApply(cvs, ft)
+		x += len(text)
+	}
+}
+
+func TestTabs(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// update if specified is called after the initial draw.
+		update  func(c *Container) error
+		events  []terminalapi.Event
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:     "fails without tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs())
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on an empty tab name",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(Tab("")))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on a duplicate tab name",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(Tab("a"), Tab("a")))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on KeyTabNext without tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, KeyTabNext(keyboard.KeyTab))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on KeyTabPrevious without tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, KeyTabPrevious(keyboard.KeyTab))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails on duplicate IDs across tabs",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, Tabs(Tab("a", ID("id")), Tab("b", ID("id"))))
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "draws the tab strip in the border",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderTitle("ignored"),
+					Tabs(
+						Tab("a", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Tab("b", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, cvs.Area(), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(cvs, ft)
+				mustTabStrip(ft, image.Point{1, 0}, cell.ColorYellow, 0, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(1, 1, 19, 5)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "reserves the top line for the tab strip without a border",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Tabs(
+						Tab("a", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+						Tab("b", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 0, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "cuts the tab strip short when it doesn't fit",
+			termSize: image.Point{8, 3},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					Tabs(
+						Tab("ab"),
+						Tab("cd"),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, cvs.Area(), draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)))
+				testdraw.MustText(cvs, " ab ", image.Point{1, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow), cell.Inverse()))
+				testdraw.MustText(cvs, " …", image.Point{5, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "key activates the next tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					Tabs(
+						Tab("a"),
+						Tab("b", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 1, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "key activates the previous tab and wraps around",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabPrevious(keyboard.KeyBacktab),
+					Tabs(
+						Tab("a"),
+						Tab("b"),
+						Tab("c", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 2, "a", "b", "c")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse click on a label activates the tab",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Tabs(
+						Tab("a"),
+						Tab("b", PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{4, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{4, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 1, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "inactive tab keeps the focused container",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					Tabs(
+						Tab("a", twoBorders()...),
+						Tab("b", twoBorders()...),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Focus the right container in tab a.
+				&terminalapi.Mouse{Position: image.Point{15, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{15, 3}, Button: mouse.ButtonRelease},
+				// Activate tab b and back to tab a.
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorDefault, 0, "a", "b")
+				mustTwoBorders(ft, image.Rect(0, 1, 20, 6), 1)
+				return ft
+			},
+		},
+		{
+			desc:     "moves focus to the tabbed container if the tab had no focus",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					Tabs(
+						Tab("a", twoBorders()...),
+						Tab("b", twoBorders()...),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{15, 3}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{15, 3}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 1, "a", "b")
+				mustTwoBorders(ft, image.Rect(0, 1, 20, 6), -1)
+				return ft
+			},
+		},
+		{
+			desc:     "containers in inactive tabs can be updated",
+			termSize: image.Point{20, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					KeyTabNext(keyboard.KeyTab),
+					Tabs(
+						Tab("a"),
+						Tab("b", ID("b")),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.Update("b", PlaceWidget(fakewidget.New(widgetapi.Options{})))
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTabStrip(ft, image.Point{0, 0}, cell.ColorYellow, 1, "a", "b")
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 20, 6)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.container => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if tc.update != nil {
+				if err := tc.update(c); err != nil {
+					t.Fatalf("tc.update => unexpected error: %v", err)
+				}
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
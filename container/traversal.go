@@ -29,6 +29,16 @@ func rootCont(c *Container) *Container {
 	return c
 }
 
+// visibleRoot returns the root of the visible part of the container tree.
+// This is the maximized container if any, otherwise the root container.
+func visibleRoot(c *Container) *Container {
+	root := rootCont(c)
+	if root.maximized != nil {
+		return root.maximized
+	}
+	return root
+}
+
 // visitFunc is executed during traversals when node is visited.
 // If the visit function returns an error, the traversal terminates and the
 // errStr is set to the text of the returned error.
@@ -48,6 +58,27 @@ func preOrder(c *Container, errStr *string, visit visitFunc) {
 	preOrder(c.second, errStr, visit)
 }
 
+// preOrderAll performs pre-order DFS traversal on the container tree
+// including the containers in inactive tabs, which aren't part of the tree
+// until their tab gets activated.
+func preOrderAll(c *Container, errStr *string, visit visitFunc) {
+	if c == nil || *errStr != "" {
+		return
+	}
+
+	if err := visit(c); err != nil {
+		*errStr = err.Error()
+		return
+	}
+	preOrderAll(c.first, errStr, visit)
+	preOrderAll(c.second, errStr, visit)
+	for i, t := range c.tabs {
+		if i != c.activeTab {
+			preOrderAll(t.cont, errStr, visit)
+		}
+	}
+}
+
 // postOrder performs post-order DFS traversal on the container tree.
 func postOrder(c *Container, errStr *string, visit visitFunc) {
 	if c == nil || *errStr != "" {
@@ -62,7 +93,8 @@ func postOrder(c *Container, errStr *string, visit visitFunc) {
 	}
 }
 
-// findID finds container with the provided ID.
+// findID finds container with the provided ID, including containers in
+// inactive tabs.
 // Returns an error of there is no container with the specified ID.
 func findID(root *Container, id string) (*Container, error) {
 	if id == "" {
@@ -73,7 +105,7 @@ func findID(root *Container, id string) (*Container, error) {
 		errStr string
 		cont   *Container
 	)
-	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+	preOrderAll(root, &errStr, visitFunc(func(c *Container) error {
 		if c.opts.id == id {
 			cont = c
 		}
@@ -0,0 +1,88 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyboard
+
+// modifiers.go defines modifier keys and shortcuts.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Modifier is a bit mask of modifier keys held while a key was pressed.
+// Modifiers can be combined, e.g. ModCtrl|ModShift.
+//
+// Control keys like KeyCtrlA already include the Ctrl key and are reported
+// without the ModCtrl modifier, similarly upper case characters are reported
+// without the ModShift modifier. Not all terminals report all modifiers.
+type Modifier int
+
+// String implements fmt.Stringer()
+func (m Modifier) String() string {
+	if m == ModNone {
+		return "ModNone"
+	}
+
+	var names []string
+	for _, mod := range []Modifier{ModShift, ModCtrl, ModAlt} {
+		if m&mod != 0 {
+			names = append(names, modifierNames[mod])
+			m &^= mod
+		}
+	}
+	if m != 0 {
+		names = append(names, "ModUnknown")
+	}
+	return strings.Join(names, "|")
+}
+
+// modifierNames maps Modifier values to human readable names.
+var modifierNames = map[Modifier]string{
+	ModShift: "ModShift",
+	ModCtrl:  "ModCtrl",
+	ModAlt:   "ModAlt",
+}
+
+// ModNone indicates that no modifier keys were held.
+const ModNone Modifier = 0
+
+// Modifier keys.
+const (
+	// ModShift indicates that the Shift key was held.
+	ModShift Modifier = 1 << iota
+	// ModCtrl indicates that the Ctrl key was held.
+	ModCtrl
+	// ModAlt indicates that the Alt (or Meta) key was held.
+	ModAlt
+)
+
+// Shortcut is a key pressed while holding the specified modifier keys.
+// A shortcut only matches a keyboard event if both the key and the modifiers
+// are equal, i.e. a shortcut without modifiers doesn't match the key pressed
+// together with the Alt key.
+type Shortcut struct {
+	// Key is the pressed key.
+	Key Key
+	// Modifiers are the modifier keys that must be held.
+	Modifiers Modifier
+}
+
+// String implements fmt.Stringer()
+func (s Shortcut) String() string {
+	if s.Modifiers == ModNone {
+		return s.Key.String()
+	}
+	return fmt.Sprintf("%v+%v", s.Modifiers, s.Key)
+}
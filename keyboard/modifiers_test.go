@@ -0,0 +1,81 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyboard
+
+import "testing"
+
+func TestModifierString(t *testing.T) {
+	tests := []struct {
+		desc string
+		mod  Modifier
+		want string
+	}{
+		{
+			desc: "no modifiers",
+			mod:  ModNone,
+			want: "ModNone",
+		},
+		{
+			desc: "single modifier",
+			mod:  ModAlt,
+			want: "ModAlt",
+		},
+		{
+			desc: "multiple modifiers",
+			mod:  ModAlt | ModShift | ModCtrl,
+			want: "ModShift|ModCtrl|ModAlt",
+		},
+		{
+			desc: "unknown modifier",
+			mod:  ModCtrl | Modifier(1<<10),
+			want: "ModCtrl|ModUnknown",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.mod.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShortcutString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		shortcut Shortcut
+		want     string
+	}{
+		{
+			desc:     "without modifiers",
+			shortcut: Shortcut{Key: KeyEnter},
+			want:     "KeyEnter",
+		},
+		{
+			desc:     "with modifiers",
+			shortcut: Shortcut{Key: 'a', Modifiers: ModCtrl | ModAlt},
+			want:     "ModCtrl|ModAlt+a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.shortcut.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gesture recognizes mouse gestures like double clicks and long
+// presses.
+//
+// Widgets can use the Recognizer to process the mouse events they receive in
+// their Mouse method.
+package gesture
+
+import (
+	"image"
+	"time"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/button"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Gesture is a mouse gesture recognized by the Recognizer.
+type Gesture int
+
+// String implements fmt.Stringer()
+func (g Gesture) String() string {
+	if n, ok := gestureNames[g]; ok {
+		return n
+	}
+	return "GestureUnknown"
+}
+
+// gestureNames maps Gesture values to human readable names.
+var gestureNames = map[Gesture]string{
+	None:        "GestureNone",
+	Click:       "GestureClick",
+	DoubleClick: "GestureDoubleClick",
+	TripleClick: "GestureTripleClick",
+	LongPress:   "GestureLongPress",
+}
+
+const (
+	// None indicates that the mouse event didn't complete any gesture.
+	None Gesture = iota
+
+	// Click is a press and a release of the mouse button within the area.
+	Click
+
+	// DoubleClick is the second click in a quick succession of clicks.
+	DoubleClick
+
+	// TripleClick is the third click in a quick succession of clicks.
+	TripleClick
+
+	// LongPress is a press of the mouse button held for at least the
+	// duration set by the LongPressDuration option and released within the
+	// area.
+	LongPress
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the provided options.
+type options struct {
+	multiClickInterval time.Duration
+	longPressDuration  time.Duration
+}
+
+// newOptions returns options with the default values set.
+func newOptions(opts ...Option) *options {
+	o := &options{
+		multiClickInterval: DefaultMultiClickInterval,
+		longPressDuration:  DefaultLongPressDuration,
+	}
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return o
+}
+
+// DefaultMultiClickInterval is the default value for the MultiClickInterval
+// option.
+const DefaultMultiClickInterval = 500 * time.Millisecond
+
+// MultiClickInterval sets the longest interval between the presses of two
+// clicks for the second click to be recognized as a DoubleClick or a
+// TripleClick. Setting a zero or a negative interval disables recognition of
+// double and triple clicks.
+// Defaults to DefaultMultiClickInterval.
+func MultiClickInterval(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.multiClickInterval = d
+	})
+}
+
+// DefaultLongPressDuration is the default value for the LongPressDuration
+// option.
+const DefaultLongPressDuration = 700 * time.Millisecond
+
+// LongPressDuration sets how long the mouse button must be held for the press
+// to be recognized as a LongPress instead of a Click. Setting a zero or a
+// negative duration disables recognition of long presses.
+// Defaults to DefaultLongPressDuration.
+func LongPressDuration(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.longPressDuration = d
+	})
+}
+
+// Recognizer recognizes gestures of a single mouse button within an area.
+//
+// A gesture is recognized when the button is released. Terminals don't report
+// any events while the mouse button is held still, so a LongPress is also
+// only reported once the button is released.
+//
+// Every click in a quick succession is reported, i.e. a double click is
+// reported as a Click followed by a DoubleClick. The click following a
+// TripleClick starts a new succession.
+//
+// This object is not thread-safe.
+type Recognizer struct {
+	// fsm tracks presses and releases of the mouse button.
+	fsm *button.FSM
+
+	// pressed indicates that the mouse button is currently held down.
+	pressed bool
+	// pressTime is the time when the mouse button was last pressed.
+	pressTime time.Time
+
+	// clicks is the number of clicks in the current succession.
+	clicks int
+	// lastClickTime is the time of the press that started the last click.
+	lastClickTime time.Time
+
+	// now returns the current time, can be replaced in tests.
+	now func() time.Time
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Recognizer that recognizes gestures of the specified
+// mouse button within the provided area.
+func New(b mouse.Button, area image.Rectangle, opts ...Option) *Recognizer {
+	return &Recognizer{
+		fsm:  button.NewFSM(b, area),
+		now:  time.Now,
+		opts: newOptions(opts...),
+	}
+}
+
+// UpdateArea informs the Recognizer of an area change.
+// This method is idempotent.
+func (r *Recognizer) UpdateArea(area image.Rectangle) {
+	r.fsm.UpdateArea(area)
+}
+
+// Pressed asserts whether the mouse button is currently held down after a
+// press within the area.
+func (r *Recognizer) Pressed() bool {
+	return r.pressed
+}
+
+// Event is used to forward mouse events to the Recognizer.
+// Returns the gesture completed by this event or None.
+func (r *Recognizer) Event(m *terminalapi.Mouse) Gesture {
+	clicked, bs := r.fsm.Event(m)
+	now := r.now()
+	if bs == button.Down {
+		if !r.pressed {
+			r.pressed = true
+			r.pressTime = now
+		}
+		return None
+	}
+
+	wasPressed := r.pressed
+	r.pressed = false
+	if !clicked {
+		if wasPressed {
+			// The press didn't result in a click, e.g. the button got released
+			// outside of the area.
+			r.clicks = 0
+		}
+		return None
+	}
+
+	if d := r.opts.longPressDuration; d > 0 && now.Sub(r.pressTime) >= d {
+		r.clicks = 0
+		return LongPress
+	}
+
+	if i := r.opts.multiClickInterval; i > 0 && r.clicks > 0 && r.clicks < 3 && r.pressTime.Sub(r.lastClickTime) <= i {
+		r.clicks++
+	} else {
+		r.clicks = 1
+	}
+	r.lastClickTime = r.pressTime
+
+	switch r.clicks {
+	case 2:
+		return DoubleClick
+	case 3:
+		return TripleClick
+	default:
+		return Click
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// eventTestCase is one mouse event and the output expectation.
+type eventTestCase struct {
+	// after is the time elapsed since the previous event.
+	after time.Duration
+
+	// event is the mouse event to send.
+	event *terminalapi.Mouse
+
+	// want is the expected gesture.
+	want Gesture
+
+	// wantPressed is the expected result of Pressed after the event.
+	wantPressed bool
+}
+
+// press returns a press of the left mouse button at the point.
+func press(x, y int) *terminalapi.Mouse {
+	return &terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonLeft}
+}
+
+// release returns a release of the mouse button at the point.
+func release(x, y int) *terminalapi.Mouse {
+	return &terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonRelease}
+}
+
+func TestRecognizer(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		eventCases []*eventTestCase
+	}{
+		{
+			desc: "recognizes a single click",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "no click when released outside of the area",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(5, 5), want: None},
+			},
+		},
+		{
+			desc: "ignores other buttons",
+			eventCases: []*eventTestCase{
+				{event: &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRight}, want: None},
+				{event: release(0, 0), want: None},
+			},
+		},
+		{
+			desc: "recognizes a double click",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: 50 * time.Millisecond, event: release(0, 0), want: Click},
+				{after: 100 * time.Millisecond, event: press(1, 1), want: None, wantPressed: true},
+				{after: 50 * time.Millisecond, event: release(1, 1), want: DoubleClick},
+			},
+		},
+		{
+			desc: "hovering between the clicks doesn't interrupt a double click",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{event: &terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonNone, Motion: true}, want: None},
+				{event: press(1, 0), want: None, wantPressed: true},
+				{event: release(1, 0), want: DoubleClick},
+			},
+		},
+		{
+			desc: "recognizes a triple click and starts over",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: DoubleClick},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: TripleClick},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "slow clicks are separate clicks",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{after: DefaultMultiClickInterval + time.Millisecond, event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "custom multi click interval",
+			opts: []Option{
+				MultiClickInterval(time.Second),
+			},
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{after: 900 * time.Millisecond, event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: DoubleClick},
+			},
+		},
+		{
+			desc: "zero multi click interval disables double clicks",
+			opts: []Option{
+				MultiClickInterval(0),
+			},
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "a press released outside of the area interrupts a double click",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(5, 5), want: None},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "recognizes a long press",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: DefaultLongPressDuration, event: release(0, 0), want: LongPress},
+			},
+		},
+		{
+			desc: "repeated press events while held don't restart the long press",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: DefaultLongPressDuration / 2, event: press(1, 1), want: None, wantPressed: true},
+				{after: DefaultLongPressDuration / 2, event: release(1, 1), want: LongPress},
+			},
+		},
+		{
+			desc: "long press interrupts a double click",
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: DefaultLongPressDuration, event: release(0, 0), want: LongPress},
+				{event: press(0, 0), want: None, wantPressed: true},
+				{event: release(0, 0), want: Click},
+			},
+		},
+		{
+			desc: "custom long press duration",
+			opts: []Option{
+				LongPressDuration(2 * time.Second),
+			},
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: DefaultLongPressDuration, event: release(0, 0), want: Click},
+				{after: DefaultMultiClickInterval + time.Millisecond, event: press(0, 0), want: None, wantPressed: true},
+				{after: 2 * time.Second, event: release(0, 0), want: LongPress},
+			},
+		},
+		{
+			desc: "zero long press duration disables long presses",
+			opts: []Option{
+				LongPressDuration(0),
+			},
+			eventCases: []*eventTestCase{
+				{event: press(0, 0), want: None, wantPressed: true},
+				{after: time.Hour, event: release(0, 0), want: Click},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := New(mouse.ButtonLeft, image.Rect(0, 0, 2, 2), tc.opts...)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			r.now = func() time.Time { return now }
+
+			for _, etc := range tc.eventCases {
+				now = now.Add(etc.after)
+				got := r.Event(etc.event)
+				t.Logf("Called r.Event(%v) => %v", etc.event, got)
+				if got != etc.want {
+					t.Errorf("r.Event(%v) => %v, want %v", etc.event, got, etc.want)
+				}
+				if got := r.Pressed(); got != etc.wantPressed {
+					t.Errorf("r.Pressed => %v, want %v", got, etc.wantPressed)
+				}
+			}
+		})
+	}
+}
+
+func TestGestureString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		gesture Gesture
+		want    string
+	}{
+		{
+			desc:    "known gesture",
+			gesture: DoubleClick,
+			want:    "GestureDoubleClick",
+		},
+		{
+			desc:    "unknown gesture",
+			gesture: Gesture(-1),
+			want:    "GestureUnknown",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.gesture.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -28,12 +28,15 @@ func (b Button) String() string {
 
 // buttonNames maps Button values to human readable names.
 var buttonNames = map[Button]string{
-	ButtonLeft:      "ButtonLeft",
-	ButtonRight:     "ButtonRight",
-	ButtonMiddle:    "ButtonMiddle",
-	ButtonRelease:   "ButtonRelease",
-	ButtonWheelUp:   "ButtonWheelUp",
-	ButtonWheelDown: "ButtonWheelDown",
+	ButtonLeft:       "ButtonLeft",
+	ButtonRight:      "ButtonRight",
+	ButtonMiddle:     "ButtonMiddle",
+	ButtonRelease:    "ButtonRelease",
+	ButtonWheelUp:    "ButtonWheelUp",
+	ButtonWheelDown:  "ButtonWheelDown",
+	ButtonWheelLeft:  "ButtonWheelLeft",
+	ButtonWheelRight: "ButtonWheelRight",
+	ButtonNone:       "ButtonNone",
 }
 
 // Buttons recognized on the mouse.
@@ -45,4 +48,10 @@ const (
 	ButtonRelease
 	ButtonWheelUp
 	ButtonWheelDown
+	ButtonWheelLeft
+	ButtonWheelRight
+
+	// ButtonNone is reported when the mouse moves without any button held,
+	// i.e. when it hovers. See terminalapi.Mouse.Motion.
+	ButtonNone
 )
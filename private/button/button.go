@@ -103,7 +103,8 @@ type stateFn func(fsm *FSM, m *terminalapi.Mouse) (bool, State, stateFn)
 
 // wantPress is the initial state, expecting a button press inside the area.
 func wantPress(fsm *FSM, m *terminalapi.Mouse) (bool, State, stateFn) {
-	if m.Button != fsm.button || !m.Position.In(fsm.area) {
+	// Dragging the mouse into the area with the button held isn't a press.
+	if m.Motion || m.Button != fsm.button || !m.Position.In(fsm.area) {
 		return false, Up, wantPress
 	}
 	return false, Down, wantRelease
@@ -294,6 +294,57 @@ func TestFSM(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "dragging into the area with the button held isn't a press",
+			button: mouse.ButtonLeft,
+			area:   image.Rect(0, 0, 1, 1),
+			eventCases: []*eventTestCase{
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft, Motion: true},
+					wantClick: false,
+					wantState: Up,
+				},
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+					wantClick: false,
+					wantState: Up,
+				},
+			},
+		},
+		{
+			desc:   "dragging within the area keeps the button down",
+			button: mouse.ButtonLeft,
+			area:   image.Rect(0, 0, 2, 2),
+			eventCases: []*eventTestCase{
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+					wantClick: false,
+					wantState: Down,
+				},
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft, Motion: true},
+					wantClick: false,
+					wantState: Down,
+				},
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+					wantClick: true,
+					wantState: Up,
+				},
+			},
+		},
+		{
+			desc:   "ignores hovering in state wantPress",
+			button: mouse.ButtonLeft,
+			area:   image.Rect(0, 0, 1, 1),
+			eventCases: []*eventTestCase{
+				{
+					event:     &terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonNone, Motion: true},
+					wantClick: false,
+					wantState: Up,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
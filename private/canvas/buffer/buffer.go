@@ -63,6 +63,14 @@ func (c *Cell) Copy() *Cell {
 	}
 }
 
+// Equal determines if the two cells have the same rune and options.
+func (c *Cell) Equal(other *Cell) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.Rune == other.Rune && *c.Opts == *other.Opts
+}
+
 // Apply applies the provided options to the cell.
 func (c *Cell) Apply(opts ...cell.Option) {
 	for _, opt := range opts {
@@ -171,6 +171,51 @@ func TestCellCopy(t *testing.T) {
 	}
 }
 
+func TestCellEqual(t *testing.T) {
+	tests := []struct {
+		desc  string
+		cell  *Cell
+		other *Cell
+		want  bool
+	}{
+		{
+			desc: "both nil",
+			want: true,
+		},
+		{
+			desc: "one nil",
+			cell: NewCell('a'),
+			want: false,
+		},
+		{
+			desc:  "same rune and options",
+			cell:  NewCell('a', cell.FgColor(cell.ColorRed)),
+			other: NewCell('a', cell.FgColor(cell.ColorRed)),
+			want:  true,
+		},
+		{
+			desc:  "different runes",
+			cell:  NewCell('a'),
+			other: NewCell('b'),
+			want:  false,
+		},
+		{
+			desc:  "different options",
+			cell:  NewCell('a', cell.FgColor(cell.ColorRed)),
+			other: NewCell('a', cell.FgColor(cell.ColorBlue)),
+			want:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.cell.Equal(tc.other); got != tc.want {
+				t.Errorf("Equal => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestCellApply(t *testing.T) {
 	tests := []struct {
 		desc string
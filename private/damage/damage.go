@@ -0,0 +1,238 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package damage implements damage tracked rendering onto a terminal.
+
+The Terminal provided by this package wraps another terminal. All cells are
+first drawn onto a back buffer. When pushed, only cells that changed since the
+previous push (the damaged cells) are set on the wrapped terminal.
+*/
+package damage
+
+import (
+	"context"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal is a terminal that only sets changed cells on the wrapped terminal.
+// Implements terminalapi.Terminal.
+// This object is thread-safe.
+type Terminal struct {
+	// term is the wrapped terminal.
+	term terminalapi.Terminal
+
+	// front holds the cells as they were last pushed to the wrapped terminal.
+	front buffer.Buffer
+	// back holds the cells as they were drawn since.
+	back buffer.Buffer
+
+	// dirty is the region of the back buffer that was modified since the
+	// last push.
+	dirty image.Rectangle
+
+	// full indicates that the content of the wrapped terminal is unknown and
+	// all the cells must be set on the next push.
+	full bool
+
+	// mu protects the Terminal.
+	mu sync.Mutex
+}
+
+// New returns a new damage tracking terminal that wraps the provided terminal.
+func New(t terminalapi.Terminal) *Terminal {
+	return &Terminal{
+		term: t,
+		full: true,
+	}
+}
+
+// resize reallocates the buffers if the size of the wrapped terminal changed.
+// The caller must hold t.mu.
+func (t *Terminal) resize(size image.Point) error {
+	if t.back != nil && t.back.Size() == size {
+		return nil
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		t.front, t.back = nil, nil
+		return nil
+	}
+
+	front, err := buffer.New(size)
+	if err != nil {
+		return err
+	}
+	back, err := buffer.New(size)
+	if err != nil {
+		return err
+	}
+	t.front, t.back = front, back
+	t.dirty = image.ZR
+	t.full = true
+	return nil
+}
+
+// Size implements terminalapi.Terminal.Size.
+// Reallocates the buffers if the size of the wrapped terminal changed, in
+// which case all cells will be set on the next push.
+func (t *Terminal) Size() image.Point {
+	size := t.term.Size()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// The buffer can only fail to allocate for invalid sizes, which resize
+	// handles by dropping the buffers.
+	_ = t.resize(size)
+	return size
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+// Clears the wrapped terminal, all cells will be set on the next push.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.term.Clear(opts...); err != nil {
+		return err
+	}
+	for col := range t.back {
+		for row := range t.back[col] {
+			t.back[col][row] = buffer.NewCell(0, opts...)
+		}
+	}
+	t.full = true
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+// Pushes the damaged cells and flushes the wrapped terminal.
+func (t *Terminal) Flush() error {
+	if err := t.Push(); err != nil {
+		return err
+	}
+	return t.term.Flush()
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (t *Terminal) SetCursor(p image.Point) {
+	t.term.SetCursor(p)
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (t *Terminal) HideCursor() {
+	t.term.HideCursor()
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+// The cell is only recorded in the back buffer, it is set on the wrapped
+// terminal on the next push if it differs from the previously pushed cell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.back == nil || !p.In(image.Rectangle{Max: t.back.Size()}) {
+		// The size of the terminal might have changed since the buffers were
+		// allocated. Let the wrapped terminal decide what to do with cells
+		// that fall outside, termdash will redraw on the resize event.
+		return t.term.SetCell(p, r, opts...)
+	}
+
+	t.back[p.X][p.Y] = buffer.NewCell(r, opts...)
+	t.dirty = t.dirty.Union(image.Rect(p.X, p.Y, p.X+1, p.Y+1))
+	return nil
+}
+
+// SetAreaCellOpts applies the provided cell options to all the cells drawn
+// within the area since the last push, e.g. in order to dim them. Cells that
+// fall outside of the terminal are ignored.
+func (t *Terminal) SetAreaCellOpts(ar image.Rectangle, opts ...cell.Option) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.back == nil {
+		return
+	}
+	ar = ar.Intersect(image.Rectangle{Max: t.back.Size()})
+	for col := ar.Min.X; col < ar.Max.X; col++ {
+		for row := ar.Min.Y; row < ar.Max.Y; row++ {
+			t.back[col][row].Apply(opts...)
+		}
+	}
+	t.dirty = t.dirty.Union(ar)
+}
+
+// Push sets all the cells that changed since the previous push on the
+// wrapped terminal. Doesn't flush the wrapped terminal.
+func (t *Terminal) Push() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.back == nil {
+		return nil
+	}
+
+	region := t.dirty
+	if t.full {
+		region = image.Rectangle{Max: t.back.Size()}
+	}
+	for row := region.Min.Y; row < region.Max.Y; row++ {
+		for col := region.Min.X; col < region.Max.X; col++ {
+			p := image.Point{col, row}
+			partial, err := t.back.IsPartial(p)
+			if err != nil {
+				return err
+			}
+			if partial {
+				// Skip over partial cells, i.e. cells that follow a cell
+				// containing a full-width rune. Setting them would overwrite
+				// the second half of the full-width rune. The terminal no
+				// longer displays what the front buffer holds for this cell.
+				t.front[col][row] = nil
+				continue
+			}
+
+			b := t.back[col][row]
+			if !t.full && b.Equal(t.front[col][row]) {
+				continue
+			}
+			if err := t.term.SetCell(p, b.Rune, b.Opts); err != nil {
+				return err
+			}
+			t.front[col][row] = b.Copy()
+			if runewidth.RuneWidth(b.Rune) == 2 && col+1 < len(t.front) {
+				// The full-width rune covers the next cell on the terminal.
+				t.front[col+1][row] = nil
+			}
+		}
+	}
+	t.dirty = image.ZR
+	t.full = false
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	return t.term.Event(ctx)
+}
+
+// Close implements terminalapi.Terminal.Close.
+func (t *Terminal) Close() {
+	t.term.Close()
+}
@@ -0,0 +1,356 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package damage
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/faketerm"
+)
+
+// countingTerm is a fake terminal that records the points of all the cells
+// set on it.
+type countingTerm struct {
+	*faketerm.Terminal
+
+	set     []image.Point
+	cleared int
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (ct *countingTerm) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	ct.set = append(ct.set, p)
+	return ct.Terminal.SetCell(p, r, opts...)
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (ct *countingTerm) Clear(opts ...cell.Option) error {
+	ct.cleared++
+	return ct.Terminal.Clear(opts...)
+}
+
+// cellSetter sets a single cell.
+type cellSetter struct {
+	p    image.Point
+	r    rune
+	opts []cell.Option
+}
+
+func TestPush(t *testing.T) {
+	tests := []struct {
+		desc string
+		size image.Point
+		// frames are the cells set before each call to Push. All frames
+		// but the last are pushed before the recording starts.
+		frames [][]cellSetter
+		// clearBefore when true, calls Clear before the last frame.
+		clearBefore bool
+		// resizeTo if not zero, resizes the terminal before the last frame.
+		resizeTo image.Point
+		// wantSet are the points set on the wrapped terminal when the last
+		// frame was pushed.
+		wantSet []image.Point
+		// wantRunes are the runes expected on the terminal at the end.
+		wantRunes map[image.Point]rune
+	}{
+		{
+			desc: "first push sets all the cells",
+			size: image.Point{2, 2},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+				},
+			},
+			wantSet: []image.Point{
+				{0, 0}, {1, 0},
+				{0, 1}, {1, 1},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+			},
+		},
+		{
+			desc: "no cells set on an unchanged frame",
+			size: image.Point{2, 2},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 1}, r: 'b'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 1}, r: 'b'},
+				},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 1}: 'b',
+			},
+		},
+		{
+			desc: "only the changed rune is set",
+			size: image.Point{3, 3},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 1}, r: 'b'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 1}, r: 'c'},
+				},
+			},
+			wantSet: []image.Point{
+				{1, 1},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 1}: 'c',
+			},
+		},
+		{
+			desc: "only the cell with changed options is set",
+			size: image.Point{3, 3},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{2, 2}, r: 'b'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{2, 2}, r: 'b', opts: []cell.Option{cell.FgColor(cell.ColorRed)}},
+				},
+			},
+			wantSet: []image.Point{
+				{2, 2},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+				{2, 2}: 'b',
+			},
+		},
+		{
+			desc: "all cells set after clear",
+			size: image.Point{2, 1},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+				},
+			},
+			clearBefore: true,
+			wantSet: []image.Point{
+				{0, 0}, {1, 0},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+			},
+		},
+		{
+			desc: "all cells set after resize",
+			size: image.Point{2, 1},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+				},
+			},
+			resizeTo: image.Point{3, 1},
+			wantSet: []image.Point{
+				{0, 0}, {1, 0}, {2, 0},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+			},
+		},
+		{
+			desc: "skips partial cells of full-width runes",
+			size: image.Point{3, 1},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 0}, r: 'b'},
+				},
+				{
+					{p: image.Point{0, 0}, r: '世'},
+				},
+			},
+			wantSet: []image.Point{
+				{0, 0},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: '世',
+				{1, 0}: 'b',
+			},
+		},
+		{
+			desc: "sets the cell after a full-width rune that was replaced",
+			size: image.Point{3, 1},
+			frames: [][]cellSetter{
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 0}, r: 'b'},
+				},
+				{
+					{p: image.Point{0, 0}, r: '世'},
+				},
+				{
+					{p: image.Point{0, 0}, r: 'a'},
+					{p: image.Point{1, 0}, r: 'b'},
+				},
+			},
+			wantSet: []image.Point{
+				{0, 0}, {1, 0},
+			},
+			wantRunes: map[image.Point]rune{
+				{0, 0}: 'a',
+				{1, 0}: 'b',
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &countingTerm{Terminal: faketerm.MustNew(tc.size)}
+			dt := New(ct)
+
+			for i, frame := range tc.frames {
+				last := i == len(tc.frames)-1
+				if last {
+					if tc.clearBefore {
+						if err := dt.Clear(); err != nil {
+							t.Fatalf("Clear => unexpected error: %v", err)
+						}
+					}
+					if tc.resizeTo != image.ZP {
+						if err := ct.Resize(tc.resizeTo); err != nil {
+							t.Fatalf("Resize => unexpected error: %v", err)
+						}
+					}
+					ct.set = nil
+				}
+
+				dt.Size()
+				for _, cs := range frame {
+					if err := dt.SetCell(cs.p, cs.r, cs.opts...); err != nil {
+						t.Fatalf("SetCell => unexpected error: %v", err)
+					}
+				}
+				if err := dt.Push(); err != nil {
+					t.Fatalf("Push => unexpected error: %v", err)
+				}
+			}
+
+			if diff := pretty.Compare(tc.wantSet, ct.set); diff != "" {
+				t.Errorf("Push => unexpected cells set on the terminal (-want, +got):\n%s", diff)
+			}
+
+			size := ct.Size()
+			for col := 0; col < size.X; col++ {
+				for row := 0; row < size.Y; row++ {
+					p := image.Point{col, row}
+					if got, want := ct.BackBuffer()[col][row].Rune, tc.wantRunes[p]; got != want {
+						t.Errorf("Push => terminal has rune %q at %v, want %q", got, p, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClearClearsTerminal(t *testing.T) {
+	ct := &countingTerm{Terminal: faketerm.MustNew(image.Point{2, 2})}
+	dt := New(ct)
+	if err := dt.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if got, want := ct.cleared, 1; got != want {
+		t.Errorf("Clear => cleared the wrapped terminal %d times, want %d", got, want)
+	}
+}
+
+func TestSetCellOutsideForwardsToTerminal(t *testing.T) {
+	ct := &countingTerm{Terminal: faketerm.MustNew(image.Point{2, 2})}
+	dt := New(ct)
+	dt.Size()
+
+	if err := dt.SetCell(image.Point{5, 5}, 'a'); err == nil {
+		t.Errorf("SetCell => got nil error, want the error from the wrapped terminal")
+	}
+	if got, want := ct.set, []image.Point{{5, 5}}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("SetCell => set %v on the wrapped terminal, want %v", got, want)
+	}
+}
+
+func TestSetCellReplacesOptions(t *testing.T) {
+	ct := &countingTerm{Terminal: faketerm.MustNew(image.Point{1, 1})}
+	dt := New(ct)
+	dt.Size()
+	if err := dt.SetCell(image.Point{0, 0}, 'a', cell.FgColor(cell.ColorRed), cell.Bold()); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := dt.Push(); err != nil {
+		t.Fatalf("Push => unexpected error: %v", err)
+	}
+
+	if err := dt.SetCell(image.Point{0, 0}, 'b'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := dt.Push(); err != nil {
+		t.Fatalf("Push => unexpected error: %v", err)
+	}
+
+	got := ct.BackBuffer()[0][0]
+	if diff := pretty.Compare(buffer.NewCell('b'), got); diff != "" {
+		t.Errorf("SetCell => unexpected cell on the terminal (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSetAreaCellOpts(t *testing.T) {
+	ct := &countingTerm{Terminal: faketerm.MustNew(image.Point{3, 1})}
+	dt := New(ct)
+	dt.Size()
+	for col := 0; col < 3; col++ {
+		if err := dt.SetCell(image.Point{col, 0}, 'a'); err != nil {
+			t.Fatalf("SetCell => unexpected error: %v", err)
+		}
+	}
+	if err := dt.Push(); err != nil {
+		t.Fatalf("Push => unexpected error: %v", err)
+	}
+
+	ct.set = nil
+	dt.SetAreaCellOpts(image.Rect(1, 0, 5, 1), cell.Dim())
+	if err := dt.Push(); err != nil {
+		t.Fatalf("Push => unexpected error: %v", err)
+	}
+
+	if diff := pretty.Compare([]image.Point{{1, 0}, {2, 0}}, ct.set); diff != "" {
+		t.Errorf("SetAreaCellOpts => unexpected cells set on the terminal (-want, +got):\n%s", diff)
+	}
+	for col, want := range []bool{false, true, true} {
+		if got := ct.BackBuffer()[col][0].Opts.Dim; got != want {
+			t.Errorf("SetAreaCellOpts => cell %d has Dim %v, want %v", col, got, want)
+		}
+	}
+}
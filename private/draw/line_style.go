@@ -127,3 +127,30 @@ const (
 	vAndRight
 	vAndH
 )
+
+// TreeLines are the runes used to draw the branches of a tree.
+type TreeLines struct {
+	// Vertical continues the branch past a node to its siblings below.
+	Vertical rune
+	// Branch leads to a node that has siblings below it.
+	Branch rune
+	// LastBranch leads to the last node among its siblings.
+	LastBranch rune
+	// Horizontal connects the branch with the node.
+	Horizontal rune
+}
+
+// TreeLineRunes returns the runes used to draw the branches of a tree in the
+// provided line style.
+func TreeLineRunes(ls linestyle.LineStyle) (*TreeLines, error) {
+	parts, err := lineParts(ls)
+	if err != nil {
+		return nil, err
+	}
+	return &TreeLines{
+		Vertical:   parts[vLine],
+		Branch:     parts[vAndRight],
+		LastBranch: parts[bottomLeftCorner],
+		Horizontal: parts[hLine],
+	}, nil
+}
@@ -131,6 +131,8 @@ func (mi *Mirror) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 // Text stores a text that should be displayed right after the canvas size on
 // the first line of the output.
 func (mi *Mirror) Text(txt string) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
 	mi.text = txt
 }
 
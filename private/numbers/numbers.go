@@ -134,10 +134,8 @@ func MinMaxInts(values []int) (min, max int) {
 	if len(values) == 0 {
 		return 0, 0
 	}
-	min = math.MaxInt32
-	max = -1 * math.MaxInt32
-
-	for _, v := range values {
+	min, max = values[0], values[0]
+	for _, v := range values[1:] {
 		if v < min {
 			min = v
 		}
@@ -215,6 +215,12 @@ func TestMinMaxInts(t *testing.T) {
 			wantMin: -11,
 			wantMax: 22,
 		},
+		{
+			desc:    "min and max outside of the int32 range",
+			values:  []int{1 << 40, 1 << 41, -(1 << 40)},
+			wantMin: -(1 << 40),
+			wantMax: 1 << 41,
+		},
 	}
 
 	for _, tc := range tests {
@@ -18,6 +18,8 @@ Package termdash implements a terminal based dashboard.
 While running, the terminal dashboard performs the following:
   - Periodic redrawing of the canvas and all the widgets.
   - Event based redrawing of the widgets (i.e. on Keyboard or Mouse events).
+  - Redrawing of the widgets when they request it, e.g. when their data
+    changes.
   - Forwards input events to widgets and optional subscribers.
   - Handles terminal resize events.
 */
@@ -38,6 +40,9 @@ import (
 // DefaultRedrawInterval is the default for the RedrawInterval option.
 const DefaultRedrawInterval = 250 * time.Millisecond
 
+// DefaultMaxFrameRate is the default for the MaxFrameRate option.
+const DefaultMaxFrameRate = 40
+
 // Option is used to provide options.
 type Option interface {
 	// set sets the provided option.
@@ -53,14 +58,29 @@ func (o option) set(td *termdash) {
 }
 
 // RedrawInterval sets how often termdash redraws the container and all the widgets.
-// Defaults to DefaultRedrawInterval. Use the controller to disable the
-// periodic redraw.
+// Defaults to DefaultRedrawInterval. Setting the interval to zero disables
+// the periodic redraw, in which case the terminal is only redrawn on input
+// events and when widgets request it, so an idle dashboard doesn't use any
+// CPU. The default keeps the periodic redraw for widgets that don't request
+// redraws. Use the controller to disable the periodic redraw and control
+// redrawing manually.
 func RedrawInterval(t time.Duration) Option {
 	return option(func(td *termdash) {
 		td.redrawInterval = t
 	})
 }
 
+// MaxFrameRate limits how many times per second termdash redraws the terminal
+// in response to input events and redraw requests from widgets. Requests that
+// arrive more frequently are coalesced into a single redraw.
+// Defaults to DefaultMaxFrameRate. Setting the frame rate to zero removes the
+// limit. Doesn't affect the periodic redraw or the Controller.Redraw method.
+func MaxFrameRate(fps int) Option {
+	return option(func(td *termdash) {
+		td.maxFrameRate = fps
+	})
+}
+
 // ErrorHandler is used to provide a function that will be called with all
 // errors that occur while the dashboard is running. If not provided, any
 // errors panic the application.
@@ -122,7 +142,8 @@ type Controller struct {
 
 // NewController initializes termdash and returns an instance of the controller.
 // Periodic redrawing is disabled when using the controller, the RedrawInterval
-// option is ignored.
+// option is ignored. The terminal is still redrawn on input events and when
+// widgets request it.
 // Close the controller when it isn't needed anymore.
 func NewController(t terminalapi.Terminal, c *container.Container, opts ...Option) (*Controller, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -133,7 +154,11 @@ func NewController(t terminalapi.Terminal, c *container.Container, opts ...Optio
 
 	// stops when Close() is called.
 	go ctrl.td.processEvents(ctx)
+	go ctrl.td.processInvalidations(ctx)
 	if err := ctrl.td.periodicRedraw(); err != nil {
+		// Don't leave the goroutines running, the caller can't Close.
+		cancel()
+		ctrl.td.stop()
 		return nil, err
 	}
 	return ctrl, nil
@@ -174,16 +199,24 @@ type termdash struct {
 	closeCh chan struct{}
 	// exitCh gets closed when the event collecting goroutine actually exits.
 	exitCh chan struct{}
+	// invalidateExitCh gets closed when termdash stops processing redraw
+	// requests.
+	invalidateExitCh chan struct{}
 
-	// clearNeeded indicates if the terminal needs to be cleared next time
-	// we're drawing it. Terminal needs to be cleared if its sized changed.
-	clearNeeded bool
+	// invalidateCh receives redraw requests.
+	// Has a capacity of one, so that requests that arrive before the redraw
+	// happens are coalesced.
+	invalidateCh chan struct{}
+
+	// lastRedraw is the time the terminal was last redrawn.
+	lastRedraw time.Time
 
 	// mu protects termdash.
 	mu sync.Mutex
 
 	// Options.
 	redrawInterval     time.Duration
+	maxFrameRate       int
 	errorHandler       func(error)
 	mouseSubscriber    func(*terminalapi.Mouse)
 	keyboardSubscriber func(*terminalapi.Keyboard)
@@ -192,12 +225,15 @@ type termdash struct {
 // newTermdash creates a new termdash.
 func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option) *termdash {
 	td := &termdash{
-		term:           t,
-		container:      c,
-		eds:            event.NewDistributionSystem(),
-		closeCh:        make(chan struct{}),
-		exitCh:         make(chan struct{}),
-		redrawInterval: DefaultRedrawInterval,
+		term:             t,
+		container:        c,
+		eds:              event.NewDistributionSystem(),
+		closeCh:          make(chan struct{}),
+		exitCh:           make(chan struct{}),
+		invalidateExitCh: make(chan struct{}),
+		invalidateCh:     make(chan struct{}, 1),
+		redrawInterval:   DefaultRedrawInterval,
+		maxFrameRate:     DefaultMaxFrameRate,
 	}
 
 	for _, opt := range opts {
@@ -205,6 +241,7 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 	}
 	td.subscribers()
 	c.Subscribe(td.eds)
+	c.SetInvalidator(td.invalidate)
 	return td
 }
 
@@ -215,21 +252,6 @@ func (td *termdash) subscribers() {
 		td.handleError(ev.(*terminalapi.Error).Error())
 	})
 
-	// Handles terminal resize events.
-	td.eds.Subscribe([]terminalapi.Event{&terminalapi.Resize{}}, func(terminalapi.Event) {
-		td.setClearNeeded()
-	})
-
-	// Redraws the screen on Keyboard and Mouse events.
-	// These events very likely change the content of the widgets (e.g. zooming
-	// a LineChart) so a redraw is needed to make that visible.
-	td.eds.Subscribe([]terminalapi.Event{
-		&terminalapi.Keyboard{},
-		&terminalapi.Mouse{},
-	}, func(terminalapi.Event) {
-		td.evRedraw()
-	}, event.MaxRepetitive(0)) // No repetitive events that cause terminal redraw.
-
 	// Keyboard and Mouse subscribers specified via options.
 	if td.keyboardSubscriber != nil {
 		td.eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
@@ -253,24 +275,11 @@ func (td *termdash) handleError(err error) {
 	}
 }
 
-// setClearNeeded flags that the terminal needs to be cleared next time we're
-// drawing it.
-func (td *termdash) setClearNeeded() {
-	td.mu.Lock()
-	defer td.mu.Unlock()
-	td.clearNeeded = true
-}
-
 // redraw redraws the container and its widgets.
+// The container only sets cells that changed since the previous redraw, the
+// container is also responsible for clearing the terminal after a resize.
 // The caller must hold td.mu.
 func (td *termdash) redraw() error {
-	if td.clearNeeded {
-		if err := td.term.Clear(); err != nil {
-			return fmt.Errorf("term.Clear => error: %v", err)
-		}
-		td.clearNeeded = false
-	}
-
 	if err := td.container.Draw(); err != nil {
 		return fmt.Errorf("container.Draw => error: %v", err)
 	}
@@ -278,22 +287,67 @@ func (td *termdash) redraw() error {
 	if err := td.term.Flush(); err != nil {
 		return fmt.Errorf("term.Flush => error: %v", err)
 	}
+	td.lastRedraw = time.Now()
 	return nil
 }
 
-// evRedraw redraws the container and its widgets.
-func (td *termdash) evRedraw() error {
+// invalidate requests a redraw of the terminal.
+// Never blocks, the request is dropped if another one is already pending.
+// Implements widgetapi.Invalidator.
+func (td *termdash) invalidate() {
+	select {
+	case td.invalidateCh <- struct{}{}:
+	default:
+	}
+}
+
+// invalidatedRedraw redraws the container and its widgets in response to a
+// redraw request. Waits before redrawing if needed in order to respect the
+// MaxFrameRate.
+func (td *termdash) invalidatedRedraw(ctx context.Context) error {
+	if td.maxFrameRate > 0 {
+		td.mu.Lock()
+		next := td.lastRedraw.Add(time.Second / time.Duration(td.maxFrameRate))
+		td.mu.Unlock()
+
+		if wait := time.Until(next); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return nil
+			case <-td.closeCh:
+				return nil
+			}
+		}
+	}
+
 	td.mu.Lock()
 	defer td.mu.Unlock()
-
-	// Don't redraw immediately, give widgets that are performing enough time
-	// to update.
-	// We don't want to actually synchronize until all widgets update, we are
-	// purposefully leaving slow widgets behind.
-	time.Sleep(25 * time.Millisecond)
 	return td.redraw()
 }
 
+// processInvalidations redraws the terminal on redraw requests when termdash
+// runs under the Controller.
+// This is the body of the invalidation processing goroutine.
+func (td *termdash) processInvalidations(ctx context.Context) {
+	defer close(td.invalidateExitCh)
+
+	for {
+		select {
+		case <-td.invalidateCh:
+			if err := td.invalidatedRedraw(ctx); err != nil {
+				td.handleError(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // periodicRedraw is called once each RedrawInterval.
 func (td *termdash) periodicRedraw() error {
 	td.mu.Lock()
@@ -323,14 +377,22 @@ func (td *termdash) processEvents(ctx context.Context) {
 // start starts the terminal dashboard. Blocks until the context expires or
 // until stop() is called.
 func (td *termdash) start(ctx context.Context) error {
+	// Under Run, redraw requests are processed by this goroutine.
+	defer close(td.invalidateExitCh)
+
 	// Redraw once to initialize the container sizes.
 	if err := td.periodicRedraw(); err != nil {
 		close(td.exitCh)
 		return err
 	}
 
-	redrawTimer := time.NewTicker(td.redrawInterval)
-	defer redrawTimer.Stop()
+	// A nil channel blocks forever, which disables the periodic redraw.
+	var redrawC <-chan time.Time
+	if td.redrawInterval > 0 {
+		redrawTimer := time.NewTicker(td.redrawInterval)
+		defer redrawTimer.Stop()
+		redrawC = redrawTimer.C
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -340,11 +402,16 @@ func (td *termdash) start(ctx context.Context) error {
 
 	for {
 		select {
-		case <-redrawTimer.C:
+		case <-redrawC:
 			if err := td.periodicRedraw(); err != nil {
 				return err
 			}
 
+		case <-td.invalidateCh:
+			if err := td.invalidatedRedraw(ctx); err != nil {
+				return err
+			}
+
 		case <-ctx.Done():
 			return nil
 
@@ -355,8 +422,9 @@ func (td *termdash) start(ctx context.Context) error {
 }
 
 // stop tells the event collecting goroutine to stop.
-// Blocks until it exits.
+// Blocks until it exits and until termdash stops processing redraw requests.
 func (td *termdash) stop() {
 	close(td.closeCh)
 	<-td.exitCh
+	<-td.invalidateExitCh
 }
@@ -16,6 +16,7 @@ package termdash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
 	"sync"
@@ -26,6 +27,7 @@ import (
 	"github.com/mum4k/termdash/container"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/private/event/eventqueue"
@@ -242,7 +244,7 @@ func TestRun(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
 			},
-			wantProcessed: 2,
+			wantProcessed: 1,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
@@ -272,7 +274,7 @@ func TestRun(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
 			},
-			wantProcessed: 2,
+			wantProcessed: 1,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
@@ -335,7 +337,7 @@ func TestRun(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: keyboard.KeyF1},
 			},
-			wantProcessed: 3,
+			wantProcessed: 2,
 			after: func(eh *eventHandlers) error {
 				want := terminalapi.Keyboard{Key: keyboard.KeyF1}
 				if diff := pretty.Compare(want, eh.keySub.get()); diff != "" {
@@ -373,7 +375,7 @@ func TestRun(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonWheelUp},
 			},
-			wantProcessed: 3,
+			wantProcessed: 2,
 			after: func(eh *eventHandlers) error {
 				want := terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonWheelUp}
 				if diff := pretty.Compare(want, eh.mouseSub.get()); diff != "" {
@@ -491,7 +493,7 @@ func TestController(t *testing.T) {
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
 			},
-			wantProcessed: 2,
+			wantProcessed: 1,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 
@@ -668,6 +670,16 @@ func TestController(t *testing.T) {
 					t.Errorf("controls => unexpected error: %v", err)
 				}
 			}
+
+			if len(tc.events) > 0 {
+				// Redraws triggered by events happen asynchronously.
+				testevent.WaitFor(5*time.Second, func() error {
+					if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+						return fmt.Errorf("the terminal doesn't match yet: %v", diff)
+					}
+					return nil
+				})
+			}
 			ctrl.Close()
 
 			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
@@ -676,3 +688,258 @@ func TestController(t *testing.T) {
 		})
 	}
 }
+
+// invalidatingWidget is a fake widget that retains the invalidator and
+// counts calls to Draw.
+type invalidatingWidget struct {
+	*fakewidget.Mirror
+
+	mu          sync.Mutex
+	invalidator widgetapi.Invalidator
+	draws       int
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (iw *invalidatingWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	iw.mu.Lock()
+	iw.invalidator = meta.Invalidator
+	iw.draws++
+	iw.mu.Unlock()
+	return iw.Mirror.Draw(cvs, meta)
+}
+
+// text sets the text on the widget and requests a redraw.
+func (iw *invalidatingWidget) text(txt string) {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	iw.Mirror.Text(txt)
+	iw.invalidator.Invalidate()
+}
+
+// drawCount returns the number of calls to Draw.
+func (iw *invalidatingWidget) drawCount() int {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	return iw.draws
+}
+
+func TestInvalidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc string
+		opts []Option
+		// controller when true, uses the Controller instead of Run.
+		controller bool
+	}{
+		{
+			desc: "redraws when requested by the widget under Run",
+			opts: []Option{
+				RedrawInterval(0),
+			},
+		},
+		{
+			desc: "redraws when requested by the widget under the Controller",
+			opts: []Option{
+				RedrawInterval(0),
+			},
+			controller: true,
+		},
+		{
+			desc: "redraws with frame rate limit disabled",
+			opts: []Option{
+				RedrawInterval(0),
+				MaxFrameRate(0),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+
+			ft, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eventqueue.New()))
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			iw := &invalidatingWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+			cont, err := container.New(ft, container.PlaceWidget(iw))
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if tc.controller {
+				ctrl, err := NewController(ft, cont, tc.opts...)
+				if err != nil {
+					t.Fatalf("NewController => unexpected error: %v", err)
+				}
+				defer ctrl.Close()
+			} else {
+				errCh := make(chan error, 1)
+				go func() {
+					errCh <- Run(ctx, ft, cont, tc.opts...)
+				}()
+				defer func() {
+					cancel()
+					if err := <-errCh; err != nil {
+						t.Errorf("Run => unexpected error: %v", err)
+					}
+				}()
+			}
+
+			// Wait for the initial draw.
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if iw.drawCount() == 0 {
+					return errors.New("the widget wasn't drawn yet")
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+			iw.text("hello")
+
+			want := faketerm.MustNew(ft.Size())
+			mirror := fakewidget.New(widgetapi.Options{})
+			mirror.Text("hello")
+			fakewidget.MustDrawWithMirror(
+				mirror,
+				want,
+				testcanvas.MustNew(want.Area()),
+				&widgetapi.Meta{Focused: true},
+			)
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if diff := faketerm.Diff(want, ft); diff != "" {
+					return fmt.Errorf("the terminal wasn't redrawn: %v", diff)
+				}
+				return nil
+			}); err != nil {
+				t.Errorf("testevent.WaitFor => %v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidationRespectsMaxFrameRate(t *testing.T) {
+	t.Parallel()
+
+	ft, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	iw := &invalidatingWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	cont, err := container.New(ft, container.PlaceWidget(iw))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewController(ft, cont, MaxFrameRate(1))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	// All these requests arrive within the same frame and get coalesced.
+	for i := 0; i < 100; i++ {
+		iw.text(fmt.Sprintf("%d", i))
+	}
+	time.Sleep(500 * time.Millisecond)
+	// One draw from NewController, the requests wait for the next frame.
+	if got, want := iw.drawCount(), 1; got != want {
+		t.Errorf("before the next frame => widget drawn %d times, want %d", got, want)
+	}
+
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if got, want := iw.drawCount(), 2; got != want {
+			return fmt.Errorf("widget drawn %d times, want %d", got, want)
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("testevent.WaitFor => %v", err)
+	}
+}
+
+func TestControllerCloseWaitsForInvalidations(t *testing.T) {
+	t.Parallel()
+
+	ft, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	iw := &invalidatingWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	cont, err := container.New(ft, container.PlaceWidget(iw))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewController(ft, cont, MaxFrameRate(1))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	td := ctrl.td
+
+	// The request waits for the next frame when the controller is closed.
+	iw.text("hello")
+	ctrl.Close()
+
+	select {
+	case <-td.invalidateExitCh:
+	default:
+		t.Fatalf("Close => returned before the invalidation processing goroutine exited")
+	}
+	if got, want := iw.drawCount(), 1; got != want {
+		t.Errorf("after Close => widget drawn %d times, want %d", got, want)
+	}
+}
+
+// eventsTerm is a fake terminal that counts the calls to Event in progress.
+type eventsTerm struct {
+	*faketerm.Terminal
+
+	mu      sync.Mutex
+	pending int
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (et *eventsTerm) Event(ctx context.Context) terminalapi.Event {
+	et.mu.Lock()
+	et.pending++
+	et.mu.Unlock()
+	defer func() {
+		et.mu.Lock()
+		et.pending--
+		et.mu.Unlock()
+	}()
+	return et.Terminal.Event(ctx)
+}
+
+func TestNewControllerStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	ft, err := faketerm.New(image.Point{1, 1}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	et := &eventsTerm{Terminal: ft}
+
+	cont, err := container.New(et, container.PlaceWidget(fakewidget.New(widgetapi.Options{})))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	if _, err := NewController(et, cont); err == nil {
+		t.Fatalf("NewController => got nil error, want the error from the first redraw")
+	}
+	// Give a leaked goroutine time to call Event.
+	time.Sleep(100 * time.Millisecond)
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	if et.pending != 0 {
+		t.Errorf("NewController => returned with %d calls to Event in progress, want the event collecting goroutine stopped", et.pending)
+	}
+}
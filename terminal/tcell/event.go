@@ -16,6 +16,8 @@ package tcell
 
 import (
 	"image"
+	"strings"
+	"unicode"
 
 	tcell "github.com/gdamore/tcell/v2"
 	"github.com/mum4k/termdash/keyboard"
@@ -86,6 +88,40 @@ var tcellToTd = map[tcell.Key]keyboard.Key{
 	tcell.KeyCtrlSpace:      keyboard.KeyCtrlSpace,
 }
 
+// convModifiers converts the tcell modifier mask of the keyboard event to the
+// termdash format.
+// Modifiers that are already part of the key are dropped, e.g. the Ctrl key
+// for tcell.KeyCtrlA or the Shift key for tcell.KeyBacktab. The Shift key is
+// also dropped for printable characters, since it is part of the character,
+// e.g. 'A' or '+'.
+func convModifiers(event *tcell.EventKey) keyboard.Modifier {
+	tcellMod := event.Modifiers()
+	tcellKey := event.Key()
+	if tcellKey != tcell.KeyRune && (tcellKey < tcellSpaceKey || tcellKey == tcell.KeyDEL) {
+		tcellMod &^= tcell.ModCtrl
+	}
+	if tcellKey == tcell.KeyBacktab || (tcellKey == tcell.KeyRune && unicode.IsPrint(event.Rune())) {
+		tcellMod &^= tcell.ModShift
+	}
+	return convModMask(tcellMod)
+}
+
+// convModMask converts the tcell modifier mask to the termdash format.
+func convModMask(tcellMod tcell.ModMask) keyboard.Modifier {
+	var mod keyboard.Modifier
+	if tcellMod&tcell.ModShift != 0 {
+		mod |= keyboard.ModShift
+	}
+	if tcellMod&tcell.ModCtrl != 0 {
+		mod |= keyboard.ModCtrl
+	}
+	// tcell reports the Alt key as ModMeta on some platforms.
+	if tcellMod&(tcell.ModAlt|tcell.ModMeta) != 0 {
+		mod |= keyboard.ModAlt
+	}
+	return mod
+}
+
 // convKey converts a tcell keyboard event to the termdash format.
 func convKey(event *tcell.EventKey) terminalapi.Event {
 	tcellKey := event.Key()
@@ -93,7 +129,8 @@ func convKey(event *tcell.EventKey) terminalapi.Event {
 	if tcellKey == tcell.KeyRune {
 		ch := event.Rune()
 		return &terminalapi.Keyboard{
-			Key: keyboard.Key(ch),
+			Key:       keyboard.Key(ch),
+			Modifiers: convModifiers(event),
 		}
 	}
 
@@ -103,7 +140,8 @@ func convKey(event *tcell.EventKey) terminalapi.Event {
 	}
 
 	return &terminalapi.Keyboard{
-		Key: k,
+		Key:       k,
+		Modifiers: convModifiers(event),
 	}
 }
 
@@ -122,17 +160,23 @@ func convMouse(event *tcell.EventMouse) terminalapi.Event {
 	}
 
 	// Get wheel events
-	if tcellBtn&tcell.WheelUp != 0 {
+	switch {
+	case tcellBtn&tcell.WheelUp != 0:
 		button = mouse.ButtonWheelUp
-	} else if tcellBtn&tcell.WheelDown != 0 {
+	case tcellBtn&tcell.WheelDown != 0:
 		button = mouse.ButtonWheelDown
+	case tcellBtn&tcell.WheelLeft != 0:
+		button = mouse.ButtonWheelLeft
+	case tcellBtn&tcell.WheelRight != 0:
+		button = mouse.ButtonWheelRight
 	}
 
 	// Return wheel event if found
 	if button > 0 {
 		return &terminalapi.Mouse{
-			Position: image.Point{X: x, Y: y},
-			Button:   button,
+			Position:  image.Point{X: x, Y: y},
+			Button:    button,
+			Modifiers: convModMask(event.Modifiers()),
 		}
 	}
 
@@ -151,8 +195,47 @@ func convMouse(event *tcell.EventMouse) terminalapi.Event {
 	}
 
 	return &terminalapi.Mouse{
-		Position: image.Point{X: x, Y: y},
-		Button:   button,
+		Position:  image.Point{X: x, Y: y},
+		Button:    button,
+		Modifiers: convModMask(event.Modifiers()),
+	}
+}
+
+// motionTracker recognizes mouse events generated by moving the mouse.
+// Tcell reports the buttons held at the time of each mouse event, so a mouse
+// event reporting the same buttons as the previous event is a motion event.
+// This object isn't thread-safe.
+// The zero value is ready to use.
+type motionTracker struct {
+	// prev is the button reported by the previous mouse event.
+	prev mouse.Button
+	// seen is true if the tracker has seen at least one mouse event.
+	seen bool
+}
+
+// track marks the mouse event as a motion event if it was generated by moving
+// the mouse. Hovering without any buttons held is reported with the
+// mouse.ButtonNone button.
+func (mt *motionTracker) track(m *terminalapi.Mouse) {
+	switch m.Button {
+	case mouse.ButtonWheelUp, mouse.ButtonWheelDown, mouse.ButtonWheelLeft, mouse.ButtonWheelRight:
+		// Wheel events don't change the held buttons.
+		return
+	}
+
+	prev := mt.prev
+	if !mt.seen {
+		// No buttons are held initially.
+		prev = mouse.ButtonRelease
+	}
+	mt.prev = m.Button
+	mt.seen = true
+	if m.Button != prev {
+		return
+	}
+	m.Motion = true
+	if m.Button == mouse.ButtonRelease {
+		m.Button = mouse.ButtonNone
 	}
 }
 
@@ -168,6 +251,65 @@ func convResize(event *tcell.EventResize) terminalapi.Event {
 	}
 }
 
+// pasteCollector collects the keyboard events tcell reports during a
+// bracketed paste and turns them into a single paste event.
+// This object isn't thread-safe.
+type pasteCollector struct {
+	// active is true between the start and the end of a paste.
+	active bool
+	// text is the text pasted so far.
+	text strings.Builder
+	// lastCR is true if the last pasted rune was a carriage return.
+	lastCR bool
+}
+
+// collect processes the tcell event. Returns true if the event is part of a
+// paste and must not be converted on its own. Returns the paste event once
+// the paste ends.
+func (pc *pasteCollector) collect(event tcell.Event) (bool, terminalapi.Event) {
+	switch e := event.(type) {
+	case *tcell.EventPaste:
+		if e.Start() {
+			pc.active = true
+			pc.text.Reset()
+			pc.lastCR = false
+			return true, nil
+		}
+		if !pc.active {
+			return true, nil
+		}
+		pc.active = false
+		return true, &terminalapi.Paste{Text: pc.text.String()}
+
+	case *tcell.EventKey:
+		if !pc.active {
+			return false, nil
+		}
+
+		cr := false
+		switch e.Key() {
+		case tcell.KeyRune:
+			pc.text.WriteRune(e.Rune())
+		case tcell.KeyCR:
+			// Line breaks are normalized to LF.
+			pc.text.WriteRune('\n')
+			cr = true
+		case tcell.KeyLF:
+			if !pc.lastCR {
+				pc.text.WriteRune('\n')
+			}
+		case tcell.KeyTab:
+			pc.text.WriteRune('\t')
+		}
+		// Other control keys cannot be part of pasted text.
+		pc.lastCR = cr
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
 // toTermdashEvents converts a tcell event to the termdash event format.
 // This function returns nil if the event is unsupported by termdash.
 func toTermdashEvents(event tcell.Event) []terminalapi.Event {
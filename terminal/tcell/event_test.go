@@ -109,6 +109,180 @@ func TestToTermdashEvents(t *testing.T) {
 	}
 }
 
+func TestKeyboardModifiers(t *testing.T) {
+	tests := []struct {
+		desc  string
+		event *tcell.EventKey
+		want  terminalapi.Event
+	}{
+		{
+			desc:  "no modifiers",
+			event: tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+			want:  &terminalapi.Keyboard{Key: 'a'},
+		},
+		{
+			desc:  "alt and a character",
+			event: tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModAlt),
+			want:  &terminalapi.Keyboard{Key: 'a', Modifiers: keyboard.ModAlt},
+		},
+		{
+			desc:  "meta is reported as alt",
+			event: tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModMeta),
+			want:  &terminalapi.Keyboard{Key: 'a', Modifiers: keyboard.ModAlt},
+		},
+		{
+			desc:  "shift and an arrow",
+			event: tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModShift),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyArrowUp, Modifiers: keyboard.ModShift},
+		},
+		{
+			desc:  "ctrl and an arrow",
+			event: tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModCtrl),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl},
+		},
+		{
+			desc:  "multiple modifiers",
+			event: tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModCtrl|tcell.ModShift|tcell.ModAlt),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl | keyboard.ModShift | keyboard.ModAlt},
+		},
+		{
+			desc:  "ctrl is part of a control key",
+			event: tcell.NewEventKey(tcell.KeyRune, 1, tcell.ModNone),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyCtrlA},
+		},
+		{
+			desc:  "alt is reported with a control key",
+			event: tcell.NewEventKey(tcell.KeyCtrlA, 0, tcell.ModCtrl|tcell.ModAlt),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyCtrlA, Modifiers: keyboard.ModAlt},
+		},
+		{
+			desc:  "shift is part of an upper-case character",
+			event: tcell.NewEventKey(tcell.KeyRune, 'A', tcell.ModShift),
+			want:  &terminalapi.Keyboard{Key: 'A'},
+		},
+		{
+			desc:  "shift is part of a symbol",
+			event: tcell.NewEventKey(tcell.KeyRune, '+', tcell.ModShift),
+			want:  &terminalapi.Keyboard{Key: '+'},
+		},
+		{
+			desc:  "alt is reported with a shifted character",
+			event: tcell.NewEventKey(tcell.KeyRune, 'A', tcell.ModShift|tcell.ModAlt),
+			want:  &terminalapi.Keyboard{Key: 'A', Modifiers: keyboard.ModAlt},
+		},
+		{
+			desc:  "shift is part of backtab",
+			event: tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModShift),
+			want:  &terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := convKey(tc.event)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("convKey => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPasteCollector(t *testing.T) {
+	tests := []struct {
+		desc       string
+		events     []tcell.Event
+		wantPasted []bool
+		want       []terminalapi.Event
+	}{
+		{
+			desc: "keys outside of a paste aren't collected",
+			events: []tcell.Event{
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventResize(1, 1),
+			},
+			wantPasted: []bool{false, false},
+			want:       []terminalapi.Event{nil, nil},
+		},
+		{
+			desc: "collects the pasted text",
+			events: []tcell.Event{
+				tcell.NewEventPaste(true),
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+				tcell.NewEventPaste(false),
+				tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+			},
+			wantPasted: []bool{true, true, true, true, true, false},
+			want: []terminalapi.Event{
+				nil,
+				nil,
+				nil,
+				nil,
+				&terminalapi.Paste{Text: "a\tb"},
+				nil,
+			},
+		},
+		{
+			desc: "normalizes line breaks and drops control keys",
+			events: []tcell.Event{
+				tcell.NewEventPaste(true),
+				tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyCR, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyLF, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyLF, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyCR, 0, tcell.ModNone),
+				tcell.NewEventPaste(false),
+			},
+			wantPasted: []bool{true, true, true, true, true, true, true, true, true, true},
+			want: []terminalapi.Event{
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				&terminalapi.Paste{Text: "a\nb\nc\n"},
+			},
+		},
+		{
+			desc: "ignores the end of a paste that didn't start",
+			events: []tcell.Event{
+				tcell.NewEventPaste(false),
+			},
+			wantPasted: []bool{true},
+			want:       []terminalapi.Event{nil},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				pc        pasteCollector
+				gotPasted []bool
+				gotEvents []terminalapi.Event
+			)
+			for _, ev := range tc.events {
+				pasted, got := pc.collect(ev)
+				gotPasted = append(gotPasted, pasted)
+				gotEvents = append(gotEvents, got)
+			}
+			if diff := pretty.Compare(tc.wantPasted, gotPasted); diff != "" {
+				t.Errorf("collect => unexpected pasted diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.want, gotEvents); diff != "" {
+				t.Errorf("collect => unexpected events diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMouseButtons(t *testing.T) {
 	tests := []struct {
 		btnMask tcell.ButtonMask
@@ -122,6 +296,8 @@ func TestMouseButtons(t *testing.T) {
 		{btnMask: tcell.ButtonNone, want: []mouse.Button{mouse.ButtonRelease}},
 		{btnMask: tcell.WheelUp, want: []mouse.Button{mouse.ButtonWheelUp}},
 		{btnMask: tcell.WheelDown, want: []mouse.Button{mouse.ButtonWheelDown}},
+		{btnMask: tcell.WheelLeft, want: []mouse.Button{mouse.ButtonWheelLeft}},
+		{btnMask: tcell.WheelRight, want: []mouse.Button{mouse.ButtonWheelRight}},
 		{btnMask: tcell.Button1 | tcell.Button2, want: nil},
 	}
 
@@ -167,6 +343,91 @@ func TestMouseButtons(t *testing.T) {
 	}
 }
 
+func TestMouseModifiers(t *testing.T) {
+	got := convMouse(tcell.NewEventMouse(1, 2, tcell.Button1, tcell.ModCtrl|tcell.ModShift))
+	want := &terminalapi.Mouse{
+		Position:  image.Point{1, 2},
+		Button:    mouse.ButtonLeft,
+		Modifiers: keyboard.ModCtrl | keyboard.ModShift,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("convMouse => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMotionTracker(t *testing.T) {
+	tests := []struct {
+		desc   string
+		events []*terminalapi.Mouse
+		want   []*terminalapi.Mouse
+	}{
+		{
+			desc: "press and release aren't motion",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+			},
+		},
+		{
+			desc: "moving without a button held is hover",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+		},
+		{
+			desc: "moving with a button held is drag",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRight},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonRight},
+				{Position: image.Point{3, 1}, Button: mouse.ButtonRight},
+				{Position: image.Point{3, 1}, Button: mouse.ButtonRelease},
+				{Position: image.Point{4, 1}, Button: mouse.ButtonRelease},
+			},
+			want: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonRight},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonRight, Motion: true},
+				{Position: image.Point{3, 1}, Button: mouse.ButtonRight, Motion: true},
+				{Position: image.Point{3, 1}, Button: mouse.ButtonRelease},
+				{Position: image.Point{4, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+		},
+		{
+			desc: "wheel events don't interrupt a drag",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonWheelUp},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+			},
+			want: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 1}, Button: mouse.ButtonWheelUp},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft, Motion: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var mt motionTracker
+			for _, m := range tc.events {
+				mt.track(m)
+			}
+			if diff := pretty.Compare(tc.want, tc.events); diff != "" {
+				t.Errorf("track => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestKeyboardKeys(t *testing.T) {
 	tests := []struct {
 		key     tcell.Key
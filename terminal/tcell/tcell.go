@@ -75,6 +75,12 @@ type Terminal struct {
 	// the tcell terminal window
 	screen tcell.Screen
 
+	// paste collects the text of a bracketed paste.
+	paste pasteCollector
+
+	// motion recognizes mouse motion events.
+	motion motionTracker
+
 	// Options.
 	colorMode  terminalapi.ColorMode
 	clearStyle *cell.Options
@@ -123,6 +129,7 @@ func New(opts ...Option) (*Terminal, error) {
 
 	clearStyle := cellOptsToStyle(t.clearStyle, t.colorMode)
 	t.screen.EnableMouse()
+	t.screen.EnablePaste()
 	t.screen.SetStyle(clearStyle)
 
 	go t.pollEvents() // Stops when Close() is called.
@@ -179,8 +186,19 @@ func (t *Terminal) pollEvents() {
 		default:
 		}
 
-		events := toTermdashEvents(t.screen.PollEvent())
+		tcellEv := t.screen.PollEvent()
+		if pasted, ev := t.paste.collect(tcellEv); pasted {
+			if ev != nil {
+				t.events.Push(ev)
+			}
+			continue
+		}
+
+		events := toTermdashEvents(tcellEv)
 		for _, ev := range events {
+			if m, ok := ev.(*terminalapi.Mouse); ok {
+				t.motion.track(m)
+			}
 			t.events.Push(ev)
 		}
 	}
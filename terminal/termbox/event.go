@@ -91,9 +91,17 @@ func convKey(tbxEv tbx.Event) terminalapi.Event {
 		return terminalapi.NewErrorf("the key event contain both a key(%v) and a character(%v)", tbxEv.Key, tbxEv.Ch)
 	}
 
+	// Termbox only reports the Alt key and only if the AltModifier option
+	// was used.
+	var mod keyboard.Modifier
+	if tbxEv.Mod&tbx.ModAlt != 0 {
+		mod = keyboard.ModAlt
+	}
+
 	if tbxEv.Ch != 0 {
 		return &terminalapi.Keyboard{
-			Key: keyboard.Key(tbxEv.Ch),
+			Key:       keyboard.Key(tbxEv.Ch),
+			Modifiers: mod,
 		}
 	}
 
@@ -102,7 +110,8 @@ func convKey(tbxEv tbx.Event) terminalapi.Event {
 		return terminalapi.NewErrorf("unknown keyboard key '%v' in a keyboard event", k)
 	}
 	return &terminalapi.Keyboard{
-		Key: k,
+		Key:       k,
+		Modifiers: mod,
 	}
 }
 
@@ -127,9 +136,19 @@ func convMouse(tbxEv tbx.Event) terminalapi.Event {
 		return terminalapi.NewErrorf("unknown mouse key %v in a mouse event", k)
 	}
 
+	motion := tbxEv.Mod&tbx.ModMotion != 0
+	if motion && button == mouse.ButtonRelease {
+		button = mouse.ButtonNone
+	}
+	var mod keyboard.Modifier
+	if tbxEv.Mod&tbx.ModAlt != 0 {
+		mod = keyboard.ModAlt
+	}
 	return &terminalapi.Mouse{
-		Position: image.Point{tbxEv.MouseX, tbxEv.MouseY},
-		Button:   button,
+		Position:  image.Point{tbxEv.MouseX, tbxEv.MouseY},
+		Button:    button,
+		Motion:    motion,
+		Modifiers: mod,
 	}
 }
 
@@ -119,6 +119,40 @@ func TestToTermdashEvents(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "mouse motion event",
+			event: tbx.Event{
+				Type:   tbx.EventMouse,
+				Key:    tbx.MouseLeft,
+				Mod:    tbx.ModMotion,
+				MouseX: 100,
+				MouseY: 200,
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Mouse{
+					Position: image.Point{100, 200},
+					Button:   mouse.ButtonLeft,
+					Motion:   true,
+				},
+			},
+		},
+		{
+			desc: "mouse motion event without a button",
+			event: tbx.Event{
+				Type:   tbx.EventMouse,
+				Key:    tbx.MouseRelease,
+				Mod:    tbx.ModMotion,
+				MouseX: 100,
+				MouseY: 200,
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Mouse{
+					Position: image.Point{100, 200},
+					Button:   mouse.ButtonNone,
+					Motion:   true,
+				},
+			},
+		},
 		{
 			desc: "keyboard event",
 			event: tbx.Event{
@@ -131,6 +165,20 @@ func TestToTermdashEvents(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "keyboard event with the alt modifier",
+			event: tbx.Event{
+				Type: tbx.EventKey,
+				Ch:   'a',
+				Mod:  tbx.ModAlt,
+			},
+			want: []terminalapi.Event{
+				&terminalapi.Keyboard{
+					Key:       'a',
+					Modifiers: keyboard.ModAlt,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -51,6 +51,17 @@ func ColorMode(cm terminalapi.ColorMode) Option {
 	})
 }
 
+// AltModifier configures termbox to report the Alt key as the
+// keyboard.ModAlt modifier of keyboard events.
+// Termbox cannot distinguish the Esc key from the Alt key, so when this option
+// is used, pressing the Esc key is interpreted as holding the Alt key while
+// pressing the following key and keyboard.KeyEsc isn't reliably reported.
+func AltModifier() Option {
+	return option(func(t *Terminal) {
+		t.altModifier = true
+	})
+}
+
 // Terminal provides input and output to a real terminal. Wraps the
 // nsf/termbox-go terminal implementation. This object is not thread-safe.
 //
@@ -65,7 +76,8 @@ type Terminal struct {
 	done chan struct{}
 
 	// Options.
-	colorMode terminalapi.ColorMode
+	colorMode   terminalapi.ColorMode
+	altModifier bool
 }
 
 // newTerminal creates the terminal and applies the options.
@@ -87,9 +99,13 @@ func New(opts ...Option) (*Terminal, error) {
 	if err := tbx.Init(); err != nil {
 		return nil, err
 	}
-	tbx.SetInputMode(tbx.InputEsc | tbx.InputMouse)
-
 	t := newTerminal(opts...)
+	if t.altModifier {
+		tbx.SetInputMode(tbx.InputAlt | tbx.InputMouse)
+	} else {
+		tbx.SetInputMode(tbx.InputEsc | tbx.InputMouse)
+	}
+
 	om, err := colorMode(t.colorMode)
 	if err != nil {
 		return nil, err
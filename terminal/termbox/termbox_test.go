@@ -42,6 +42,16 @@ func TestNewTerminal(t *testing.T) {
 				colorMode: terminalapi.ColorModeNormal,
 			},
 		},
+		{
+			desc: "reports the alt modifier",
+			opts: []Option{
+				AltModifier(),
+			},
+			want: &Terminal{
+				colorMode:   terminalapi.ColorMode256,
+				altModifier: true,
+			},
+		},
 	}
 
 	for _, tc := range tests {
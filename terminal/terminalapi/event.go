@@ -35,15 +35,41 @@ type Event interface {
 type Keyboard struct {
 	// Key is the pressed key.
 	Key keyboard.Key
+	// Modifiers are the modifier keys held while the key was pressed.
+	Modifiers keyboard.Modifier
 }
 
 func (*Keyboard) isEvent() {}
 
+// Shortcut returns the pressed key together with the held modifier keys.
+func (k Keyboard) Shortcut() keyboard.Shortcut {
+	return keyboard.Shortcut{Key: k.Key, Modifiers: k.Modifiers}
+}
+
 // String implements fmt.Stringer.
 func (k Keyboard) String() string {
+	if k.Modifiers != keyboard.ModNone {
+		return fmt.Sprintf("Keyboard{Key: %v, Modifiers: %v}", k.Key, k.Modifiers)
+	}
 	return fmt.Sprintf("Keyboard{Key: %v}", k.Key)
 }
 
+// Paste is the event used when text is pasted into the terminal.
+// Only terminals that support bracketed paste report pasted text as a single
+// event, other terminals report it as a sequence of keyboard events.
+// Implements terminalapi.Event.
+type Paste struct {
+	// Text is the pasted text. Line breaks are normalized to the '\n' rune.
+	Text string
+}
+
+func (*Paste) isEvent() {}
+
+// String implements fmt.Stringer.
+func (p Paste) String() string {
+	return fmt.Sprintf("Paste{Text: %q}", p.Text)
+}
+
 // Resize is the event used when the terminal was resized.
 // Implements terminalapi.Event.
 type Resize struct {
@@ -66,13 +92,28 @@ type Mouse struct {
 	Position image.Point
 	// Button identifies the pressed button if any.
 	Button mouse.Button
+	// Motion is true if the event was generated by moving the mouse rather
+	// than by pressing or releasing a button. Button is the held button when
+	// the mouse is dragged or mouse.ButtonNone when it hovers.
+	// Not all terminals report motion events.
+	Motion bool
+	// Modifiers are the modifier keys held during the event.
+	// Not all terminals report modifiers on mouse events.
+	Modifiers keyboard.Modifier
 }
 
 func (*Mouse) isEvent() {}
 
 // String implements fmt.Stringer.
 func (m Mouse) String() string {
-	return fmt.Sprintf("Mouse{Position: %v, Button: %v}", m.Position, m.Button)
+	var extra string
+	if m.Motion {
+		extra += ", Motion: true"
+	}
+	if m.Modifiers != keyboard.ModNone {
+		extra += fmt.Sprintf(", Modifiers: %v", m.Modifiers)
+	}
+	return fmt.Sprintf("Mouse{Position: %v, Button: %v%s}", m.Position, m.Button, extra)
 }
 
 // Error is an event indicating an error while processing input.
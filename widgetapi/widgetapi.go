@@ -144,12 +144,43 @@ type Options struct {
 	// if it falls onto its canvas. See the documentation next to individual
 	// MouseScope values for details.
 	WantMouse MouseScope
+
+	// WantMouseMotion allows a widget to request mouse motion events without
+	// any button held, i.e. the events reported when the mouse hovers. These
+	// are reported with the mouse.ButtonNone button and are delivered within
+	// the scope set by WantMouse. Motion events with a held button (drags)
+	// are delivered regardless of this option.
+	// A widget that needs to know when the mouse stops hovering over its
+	// canvas should use MouseScopeContainer or MouseScopeGlobal.
+	WantMouseMotion bool
+}
+
+// Invalidator is used by widgets to request a redraw of the terminal, e.g.
+// when the data displayed by the widget changed.
+type Invalidator func()
+
+// Invalidate requests a redraw of the terminal. Multiple requests that arrive
+// in a short succession are coalesced into a single redraw.
+// Does nothing if the Invalidator is nil, i.e. when the widget isn't running
+// under termdash.
+func (i Invalidator) Invalidate() {
+	if i != nil {
+		i()
+	}
 }
 
 // Meta provide additional metadata to widgets.
 type Meta struct {
 	// Focused asserts whether the widget's container is focused.
 	Focused bool
+
+	// Invalidator allows the widget to request a redraw of the terminal.
+	// Widgets can retain it and call its Invalidate method whenever their
+	// content changes outside of the Draw, Keyboard or Mouse methods, e.g.
+	// when the user provides new data. The container requests a redraw after
+	// every keyboard or mouse event on its own.
+	// Can be nil, calling Invalidate on a nil Invalidator is safe.
+	Invalidator Invalidator
 }
 
 // EventMeta provides additional metadata about events to widgets.
@@ -200,3 +231,16 @@ type Widget interface {
 	// Draw.
 	Options() Options
 }
+
+// Paster is implemented by widgets that process pasted text at once.
+// This interface is optional, widgets that don't implement it receive the
+// pasted text as a sequence of keyboard events, one for each rune, with line
+// breaks reported as keyboard.KeyEnter and tabs as keyboard.KeyTab.
+type Paster interface {
+	// Paste is called with every paste event when the widget would receive
+	// a keyboard event, i.e. the same scope as for the Keyboard method
+	// applies.
+	//
+	// The argument meta is guaranteed to be valid (i.e. non-nil).
+	Paste(p *terminalapi.Paste, meta *EventMeta) error
+}
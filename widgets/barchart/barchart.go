@@ -50,6 +50,10 @@ type BarChart struct {
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
 
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the BarChart.
 	mu sync.Mutex
 
@@ -76,6 +80,7 @@ func New(opts ...Option) (*BarChart, error) {
 func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
+	bc.invalidator = meta.Invalidator
 
 	bc.lastWidth = cvs.Area().Dx()
 	needAr, err := area.FromSize(bc.minSize())
@@ -276,6 +281,7 @@ func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	}
 	bc.values = v
 	bc.max = max
+	bc.invalidator.Invalidate()
 	return nil
 }
 
@@ -661,7 +661,12 @@ func TestBarChart(t *testing.T) {
 				return
 			}
 
-			err = bc.Draw(c, tc.meta)
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			err = bc.Draw(c, meta)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 			}
@@ -27,6 +27,7 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/mouse/gesture"
 	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/attrrange"
 	"github.com/mum4k/termdash/private/button"
@@ -76,8 +77,8 @@ type Button struct {
 	// tOptsTracker tracks the positions in a text to which the givenTOpts apply.
 	tOptsTracker *attrrange.Tracker
 
-	// mouseFSM tracks left mouse clicks.
-	mouseFSM *button.FSM
+	// gestures recognizes left mouse clicks and double clicks.
+	gestures *gesture.Recognizer
 	// state is the current state of the button.
 	state button.State
 
@@ -90,6 +91,11 @@ type Button struct {
 	// callback gets called on each button press.
 	callback CallbackFn
 
+	// invalidator is used to request a redraw when the button gets released
+	// after a keyboard press.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the widget.
 	mu sync.Mutex
 
@@ -146,9 +152,10 @@ func NewFromChunks(chunks []*TextChunk, cFn CallbackFn, opts ...Option) (*Button
 		text:         text,
 		givenTOpts:   givenTOpts,
 		tOptsTracker: tOptsTracker,
-		mouseFSM:     button.NewFSM(mouse.ButtonLeft, image.ZR),
-		callback:     cFn,
-		opts:         opt,
+		// A long press of the button is just a click.
+		gestures: gesture.New(mouse.ButtonLeft, image.ZR, gesture.LongPressDuration(0)),
+		callback: cFn,
+		opts:     opt,
 	}, nil
 }
 
@@ -177,6 +184,7 @@ var (
 func (b *Button) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.invalidator = meta.Invalidator
 
 	if b.keyTriggerTime != nil {
 		since := timeSince(*b.keyTriggerTime)
@@ -186,7 +194,7 @@ func (b *Button) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	cvsAr := cvs.Area()
-	b.mouseFSM.UpdateArea(cvsAr)
+	b.gestures.UpdateArea(cvsAr)
 
 	sw := b.shadowWidth()
 	shadowAr := image.Rect(sw, sw, cvsAr.Dx(), cvsAr.Dy())
@@ -271,10 +279,13 @@ func (b *Button) keyActivated(k *terminalapi.Keyboard, meta *widgetapi.EventMeta
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if b.opts.globalKeys[k.Key] || (b.opts.focusedKeys[k.Key] && meta.Focused) {
+	sc := k.Shortcut()
+	if b.opts.globalKeys[sc] || (b.opts.focusedKeys[sc] && meta.Focused) {
 		b.state = button.Down
 		now := time.Now().UTC()
 		b.keyTriggerTime = &now
+		// Redraw once the button should be displayed as released.
+		time.AfterFunc(b.opts.keyUpDelay+time.Millisecond, b.invalidator.Invalidate)
 		return true
 	}
 	return false
@@ -296,16 +307,28 @@ func (b *Button) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) er
 	return nil
 }
 
-// mouseActivated asserts whether the mouse event activated the button.
-func (b *Button) mouseActivated(m *terminalapi.Mouse) bool {
+// mouseActivated determines the callback activated by the mouse event.
+// Returns nil if the mouse event didn't activate the button or if the
+// activated callback is nil.
+func (b *Button) mouseActivated(m *terminalapi.Mouse) CallbackFn {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	clicked, state := b.mouseFSM.Event(m)
-	b.state = state
+	g := b.gestures.Event(m)
+	b.state = button.Up
+	if b.gestures.Pressed() {
+		b.state = button.Down
+	}
 	b.keyTriggerTime = nil
 
-	return clicked
+	switch {
+	case g == gesture.None:
+		return nil
+	case g == gesture.DoubleClick && b.opts.doubleClickCallback != nil:
+		return b.opts.doubleClickCallback
+	default:
+		return b.callback
+	}
 }
 
 // Mouse processes mouse events, acts as a button press if both the press and
@@ -313,13 +336,11 @@ func (b *Button) mouseActivated(m *terminalapi.Mouse) bool {
 //
 // Implements widgetapi.Widget.Mouse.
 func (b *Button) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
-	if b.mouseActivated(m) {
-		if b.callback != nil {
-			// Mutex must be released when calling the callback.
-			// Users might call container methods from the callback like the
-			// Container.Update, see #205.
-			return b.callback()
-		}
+	if fn := b.mouseActivated(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return fn()
 	}
 	return nil
 }
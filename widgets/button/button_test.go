@@ -165,6 +165,18 @@ func TestButton(t *testing.T) {
 			meta:       &widgetapi.Meta{Focused: false},
 			wantNewErr: true,
 		},
+		{
+			desc:     "New fails when duplicate Shortcut and GlobalShortcut are specified",
+			callback: &callbackTracker{},
+			opts: []Option{
+				Shortcut(keyboard.Shortcut{Key: 'a', Modifiers: keyboard.ModCtrl}),
+				GlobalShortcut(keyboard.Shortcut{Key: 'a', Modifiers: keyboard.ModCtrl}),
+			},
+			canvas:     image.Rect(0, 0, 1, 1),
+			text:       "hello",
+			meta:       &widgetapi.Meta{Focused: false},
+			wantNewErr: true,
+		},
 		{
 			desc: "NewFromChunks fails with negative keyUpDelay",
 			textChunks: []*TextChunk{
@@ -598,6 +610,126 @@ func TestButton(t *testing.T) {
 			},
 		},
 
+		{
+			desc:     "ignores keyboard event configured with Key when pressed with a modifier",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Key('a'),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: 'a', Modifiers: keyboard.ModAlt},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(117)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorBlack),
+						cell.BgColor(cell.ColorNumber(117))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{
+				called: false,
+				count:  0,
+			},
+		},
+		{
+			desc:     "draws button in down state due to a keyboard event matching a shortcut",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				Shortcut(keyboard.Shortcut{Key: 'a', Modifiers: keyboard.ModAlt}),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: 'a', Modifiers: keyboard.ModAlt},
+					meta: &widgetapi.EventMeta{Focused: true},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 'x', cell.BgColor(cell.ColorNumber(117)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{2, 2},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorBlack),
+						cell.BgColor(cell.ColorNumber(117))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{
+				called: true,
+				count:  1,
+			},
+		},
+		{
+			desc:     "ignores keyboard event without the modifiers of the shortcut",
+			callback: &callbackTracker{},
+			text:     "hello",
+			opts: []Option{
+				GlobalShortcuts(
+					keyboard.Shortcut{Key: keyboard.KeyArrowUp, Modifiers: keyboard.ModShift},
+					keyboard.Shortcut{Key: keyboard.KeyArrowDown, Modifiers: keyboard.ModShift | keyboard.ModCtrl},
+				),
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			meta:   &widgetapi.Meta{Focused: false},
+			events: []*event{
+				{
+					ev:   &terminalapi.Keyboard{Key: keyboard.KeyArrowDown, Modifiers: keyboard.ModShift},
+					meta: &widgetapi.EventMeta{},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				// Shadow.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 8, 4), 's', cell.BgColor(cell.ColorNumber(240)))
+
+				// Button.
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 7, 3), 'x', cell.BgColor(cell.ColorNumber(117)))
+
+				// Text.
+				testdraw.MustText(cvs, "hello", image.Point{1, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorBlack),
+						cell.BgColor(cell.ColorNumber(117))),
+				)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{
+				called: false,
+				count:  0,
+			},
+		},
 		{
 			desc:     "draws button in down state due to a keyboard event when multiple keys are specified",
 			callback: &callbackTracker{},
@@ -1609,6 +1741,112 @@ func TestButton(t *testing.T) {
 	}
 }
 
+func TestButtonDoubleClick(t *testing.T) {
+	click := []*terminalapi.Mouse{
+		{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+		{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+	}
+
+	tests := []struct {
+		desc string
+		// setDoubleClick when true sets the DoubleClickCallback option.
+		setDoubleClick  bool
+		clicks          int
+		wantCount       int
+		wantDoubleCount int
+	}{
+		{
+			desc:      "double click calls the callback twice without DoubleClickCallback",
+			clicks:    2,
+			wantCount: 2,
+		},
+		{
+			desc:            "single click doesn't call the DoubleClickCallback",
+			setDoubleClick:  true,
+			clicks:          1,
+			wantCount:       1,
+			wantDoubleCount: 0,
+		},
+		{
+			desc:            "double click calls the DoubleClickCallback",
+			setDoubleClick:  true,
+			clicks:          2,
+			wantCount:       1,
+			wantDoubleCount: 1,
+		},
+		{
+			desc:            "triple click calls the callback for the third click",
+			setDoubleClick:  true,
+			clicks:          3,
+			wantCount:       2,
+			wantDoubleCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &callbackTracker{}
+			dct := &callbackTracker{}
+			var opts []Option
+			if tc.setDoubleClick {
+				opts = append(opts, DoubleClickCallback(dct.callback))
+			}
+			b, err := New("hello", ct.callback, opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			// Draw once which initializes the mouse state machine with the current canvas area.
+			c, err := canvas.New(image.Rect(0, 0, 8, 4))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := b.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i := 0; i < tc.clicks; i++ {
+				for _, m := range click {
+					if err := b.Mouse(m, &widgetapi.EventMeta{}); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				}
+			}
+
+			if ct.count != tc.wantCount {
+				t.Errorf("callback called %d times, want %d", ct.count, tc.wantCount)
+			}
+			if dct.count != tc.wantDoubleCount {
+				t.Errorf("DoubleClickCallback called %d times, want %d", dct.count, tc.wantDoubleCount)
+			}
+		})
+	}
+}
+
+func TestRequestsRedrawOnKeyUp(t *testing.T) {
+	b, err := New("hello", nil, Key(keyboard.KeyEnter), KeyUpDelay(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	requests := make(chan struct{}, 1)
+	c := testcanvas.MustNew(image.Rect(0, 0, 8, 4))
+	if err := b.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests <- struct{}{} },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if err := b.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}, &widgetapi.EventMeta{Focused: true}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+
+	select {
+	case <-requests:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Button didn't request a redraw after the KeyUpDelay")
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
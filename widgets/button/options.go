@@ -51,9 +51,10 @@ type options struct {
 	disableShadow         bool
 	height                int
 	width                 int
-	focusedKeys           map[keyboard.Key]bool
-	globalKeys            map[keyboard.Key]bool
+	focusedKeys           map[keyboard.Shortcut]bool
+	globalKeys            map[keyboard.Shortcut]bool
 	keyUpDelay            time.Duration
+	doubleClickCallback   CallbackFn
 }
 
 // validate validates the provided options.
@@ -73,7 +74,7 @@ func (o *options) validate() error {
 
 	for k := range o.globalKeys {
 		if o.focusedKeys[k] {
-			return fmt.Errorf("key %q cannot be configured as both a focused key (options Key or Keys) and a global key (options GlobalKey or GlobalKeys)", k)
+			return fmt.Errorf("key %q cannot be configured as both a focused key (options Key, Keys, Shortcut or Shortcuts) and a global key (options GlobalKey, GlobalKeys, GlobalShortcut or GlobalShortcuts)", k)
 		}
 	}
 	return nil
@@ -95,8 +96,8 @@ func newOptions(text string) *options {
 		height:                DefaultHeight,
 		width:                 widthFor(text),
 		keyUpDelay:            DefaultKeyUpDelay,
-		focusedKeys:           map[keyboard.Key]bool{},
-		globalKeys:            map[keyboard.Key]bool{},
+		focusedKeys:           map[keyboard.Shortcut]bool{},
+		globalKeys:            map[keyboard.Shortcut]bool{},
 	}
 }
 
@@ -171,59 +172,98 @@ func WidthFor(text string) Option {
 }
 
 // Key configures the keyboard key that presses the button.
-// The widget responds to this key only if its container is focused.
+// The widget responds to this key only if its container is focused and only
+// if the key is pressed without any modifier keys, use Shortcut to configure
+// a key with modifiers.
 //
-// Clears all keys set by Key() or Keys() previously.
+// Clears all keys set by Key(), Keys(), Shortcut() or Shortcuts() previously.
 func Key(k keyboard.Key) Option {
-	return option(func(opts *options) {
-		opts.focusedKeys = map[keyboard.Key]bool{}
-		opts.focusedKeys[k] = true
-	})
+	return Shortcut(keyboard.Shortcut{Key: k})
 }
 
 // GlobalKey is like Key, but makes the widget respond to the key even if its
 // container isn't focused.
 //
-// Clears all keys set by GlobalKey() or GlobalKeys() previously.
+// Clears all keys set by GlobalKey(), GlobalKeys(), GlobalShortcut() or
+// GlobalShortcuts() previously.
 func GlobalKey(k keyboard.Key) Option {
-	return option(func(opts *options) {
-		opts.globalKeys = map[keyboard.Key]bool{}
-		opts.globalKeys[k] = true
-	})
+	return GlobalShortcut(keyboard.Shortcut{Key: k})
 }
 
 // Keys is like Key, but allows to configure multiple keys.
 //
-// Clears all keys set by Key() or Keys() previously.
+// Clears all keys set by Key(), Keys(), Shortcut() or Shortcuts() previously.
 func Keys(keys ...keyboard.Key) Option {
+	return Shortcuts(shortcuts(keys)...)
+}
+
+// GlobalKeys is like GlobalKey, but allows to configure multiple keys.
+//
+// Clears all keys set by GlobalKey(), GlobalKeys(), GlobalShortcut() or
+// GlobalShortcuts() previously.
+func GlobalKeys(keys ...keyboard.Key) Option {
+	return GlobalShortcuts(shortcuts(keys)...)
+}
+
+// Shortcut is like Key, but the key must be pressed together with the
+// specified modifier keys.
+//
+// Clears all keys set by Key(), Keys(), Shortcut() or Shortcuts() previously.
+func Shortcut(s keyboard.Shortcut) Option {
+	return Shortcuts(s)
+}
+
+// GlobalShortcut is like GlobalKey, but the key must be pressed together with
+// the specified modifier keys.
+//
+// Clears all keys set by GlobalKey(), GlobalKeys(), GlobalShortcut() or
+// GlobalShortcuts() previously.
+func GlobalShortcut(s keyboard.Shortcut) Option {
+	return GlobalShortcuts(s)
+}
+
+// Shortcuts is like Shortcut, but allows to configure multiple shortcuts.
+//
+// Clears all keys set by Key(), Keys(), Shortcut() or Shortcuts() previously.
+func Shortcuts(shortcuts ...keyboard.Shortcut) Option {
 	return option(func(opts *options) {
-		opts.focusedKeys = map[keyboard.Key]bool{}
-		for _, k := range keys {
-			opts.focusedKeys[k] = true
+		opts.focusedKeys = map[keyboard.Shortcut]bool{}
+		for _, s := range shortcuts {
+			opts.focusedKeys[s] = true
 		}
 	})
 }
 
-// GlobalKeys is like GlobalKey, but allows to configure multiple keys.
+// GlobalShortcuts is like GlobalShortcut, but allows to configure multiple
+// shortcuts.
 //
-// Clears all keys set by GlobalKey() or GlobalKeys() previously.
-func GlobalKeys(keys ...keyboard.Key) Option {
+// Clears all keys set by GlobalKey(), GlobalKeys(), GlobalShortcut() or
+// GlobalShortcuts() previously.
+func GlobalShortcuts(shortcuts ...keyboard.Shortcut) Option {
 	return option(func(opts *options) {
-		opts.globalKeys = map[keyboard.Key]bool{}
-		for _, k := range keys {
-			opts.globalKeys[k] = true
+		opts.globalKeys = map[keyboard.Shortcut]bool{}
+		for _, s := range shortcuts {
+			opts.globalKeys[s] = true
 		}
 	})
 }
 
+// shortcuts returns shortcuts for the keys without any modifiers.
+func shortcuts(keys []keyboard.Key) []keyboard.Shortcut {
+	var res []keyboard.Shortcut
+	for _, k := range keys {
+		res = append(res, keyboard.Shortcut{Key: k})
+	}
+	return res
+}
+
 // DefaultKeyUpDelay is the default value for the KeyUpDelay option.
 const DefaultKeyUpDelay = 250 * time.Millisecond
 
 // KeyUpDelay is the amount of time the button will remain "pressed down" after
 // triggered by the configured key. Termbox doesn't emit events for key
 // releases so the button simulates it by timing it.
-// This only works if the manual termdash redraw or the periodic redraw
-// interval are reasonably close to this delay.
+// The button requests a redraw once the delay elapses.
 // The duration cannot be negative.
 // Defaults to DefaultKeyUpDelay.
 func KeyUpDelay(d time.Duration) Option {
@@ -232,6 +272,18 @@ func KeyUpDelay(d time.Duration) Option {
 	})
 }
 
+// DoubleClickCallback sets a function that is called when the button is
+// double clicked with the mouse. When set, the second click of a double click
+// calls this function instead of the callback function provided to New.
+// The first click of a double click still calls the callback provided to New.
+// The same requirements apply to this function as to the callback provided to
+// New.
+func DoubleClickCallback(fn CallbackFn) Option {
+	return option(func(opts *options) {
+		opts.doubleClickCallback = fn
+	})
+}
+
 // DisableShadow when provided the button will not have a shadow area and will
 // have no animation when pressed.
 func DisableShadow() Option {
@@ -70,6 +70,10 @@ type Donut struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the Donut.
 	mu sync.Mutex
 
@@ -114,6 +118,7 @@ func (d *Donut) Absolute(done, total int, opts ...Option) error {
 	d.pt = progressTypeAbsolute
 	d.current = done
 	d.total = total
+	d.invalidator.Invalidate()
 	return nil
 }
 
@@ -138,6 +143,7 @@ func (d *Donut) Percent(p int, opts ...Option) error {
 	d.pt = progressTypePercent
 	d.current = p
 	d.total = 100
+	d.invalidator.Invalidate()
 	return nil
 }
 
@@ -205,6 +211,7 @@ func (d *Donut) drawLabel(cvs *canvas.Canvas, labelAr image.Rectangle) error {
 func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	d.invalidator = meta.Invalidator
 
 	startA, endA := startEndAngles(d.current, d.total, d.opts.startAngle, d.opts.direction)
 	if startA == endA {
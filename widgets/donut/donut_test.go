@@ -847,7 +847,12 @@ func TestDonut(t *testing.T) {
 				}
 			}
 
-			err = d.Draw(c, tc.meta)
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			err = d.Draw(c, meta)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 			}
@@ -70,6 +70,10 @@ type Gauge struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the Gauge.
 	mu sync.Mutex
 
@@ -112,6 +116,7 @@ func (g *Gauge) Absolute(done, total int, opts ...Option) error {
 	g.pt = progressTypeAbsolute
 	g.current = done
 	g.total = total
+	g.invalidator.Invalidate()
 	return nil
 }
 
@@ -133,6 +138,7 @@ func (g *Gauge) Percent(p int, opts ...Option) error {
 	g.pt = progressTypePercent
 	g.current = p
 	g.total = 100
+	g.invalidator.Invalidate()
 	return nil
 }
 
@@ -276,6 +282,7 @@ func (g *Gauge) drawThreshold(cvs *canvas.Canvas) error {
 func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	g.invalidator = meta.Invalidator
 
 	needAr, err := area.FromSize(g.minSize())
 	if err != nil {
@@ -1030,7 +1030,12 @@ func TestGauge(t *testing.T) {
 
 			}
 
-			err = g.Draw(c, tc.meta)
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			err = g.Draw(c, meta)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 			}
@@ -1054,6 +1059,34 @@ func TestGauge(t *testing.T) {
 	}
 }
 
+func TestRequestsRedrawOnUpdate(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	if err := g.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := g.Percent(10); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	if err := g.Absolute(1, 2); err != nil {
+		t.Fatalf("Absolute => unexpected error: %v", err)
+	}
+	if err := g.Percent(101); err == nil {
+		t.Fatalf("Percent => got nil error, want an error for an invalid percentage")
+	}
+	if got, want := requests, 2; got != want {
+		t.Errorf("Gauge requested %d redraws, want %d", got, want)
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	g, err := New()
 	if err != nil {
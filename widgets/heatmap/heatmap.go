@@ -17,11 +17,18 @@ package heatmap
 
 import (
 	"errors"
+	"fmt"
 	"image"
+	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/heatmap/internal/axes"
@@ -30,12 +37,21 @@ import (
 // HeatMap draws heat map charts.
 //
 // Heatmap consists of several cells. Each cell represents a value.
-// The larger the value, the darker the color of the cell (from white to black).
+// By default, the larger the value, the darker the color of the cell (from
+// white to black). The colors are configurable with the Scale option and
+// can be explained by a legend displayed under the heat map.
 //
 // The two dimensions of the values (cells) array are determined by the length of
 // the xLabels and yLabels arrays respectively.
 //
-// HeatMap does not support mouse based zoom.
+// When the values don't fit the canvas, the HeatMap displays the last columns
+// of values, i.e. the most recent ones when the data is streamed into it with
+// AddColumn.
+// Values that are NaN aren't drawn.
+//
+// HeatMap does not support mouse based zoom, but reports the cells the mouse
+// hovers over or clicks on if the OnCellHover or OnCellClick options are
+// provided.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type HeatMap struct {
@@ -46,13 +62,29 @@ type HeatMap struct {
 	xLabels []string
 	// yLabels are the labels on the Y axis in an increasing order.
 	yLabels []string
+	// times are the timestamps of the columns added with AddColumn, zero
+	// for the columns provided to Values.
+	times []time.Time
 
 	// minValue and maxValue are the Min and Max values in the values,
 	// which will be used to calculate the color of each cell.
+	// Only positive values are considered with the LogScale option.
 	minValue, maxValue float64
 
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
+	// lastHeight is the height of the canvas as of the last time when Draw was called.
+	lastHeight int
+	// cellsAr is the area of the canvas available to the cells as of the last
+	// time when Draw was called.
+	cellsAr image.Rectangle
+	// hovered is the column and the row of the cell the mouse hovers over
+	// or image.Point{-1, -1} if it doesn't hover over any cell.
+	hovered image.Point
+
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
 
 	// opts are the provided options.
 	opts *options
@@ -63,7 +95,23 @@ type HeatMap struct {
 
 // New returns a new HeatMap widget.
 func New(opts ...Option) (*HeatMap, error) {
-	return nil, errors.New("not implemented")
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &HeatMap{
+		hovered: image.Point{-1, -1},
+		opts:    opt,
+	}, nil
+}
+
+// numberLabels returns labels "0", "1", "2"... for n values.
+func numberLabels(n int) []string {
+	var res []string
+	for i := 0; i < n; i++ {
+		res = append(res, strconv.Itoa(i))
+	}
+	return res
 }
 
 // Values sets the values to be displayed by the HeatMap.
@@ -76,17 +124,146 @@ func New(opts ...Option) (*HeatMap, error) {
 // Each call to Values overwrites any previously provided values.
 // Provided options override values set when New() was called.
 func (hp *HeatMap) Values(xLabels []string, yLabels []string, values [][]float64, opts ...Option) error {
-	return errors.New("not implemented")
+	columns := 0
+	for i, row := range values {
+		if i == 0 {
+			columns = len(row)
+		}
+		if len(row) != columns {
+			return fmt.Errorf("all rows of values must have the same length, row 0 has %d values, row %d has %d", columns, i, len(row))
+		}
+	}
+	if len(xLabels) == 0 {
+		xLabels = numberLabels(columns)
+	}
+	if len(yLabels) == 0 {
+		yLabels = numberLabels(len(values))
+	}
+	if len(xLabels) != columns {
+		return fmt.Errorf("got %d X labels, must be equal to the number of values in a row %d", len(xLabels), columns)
+	}
+	if len(yLabels) != len(values) {
+		return fmt.Errorf("got %d Y labels, must be equal to the number of rows of values %d", len(yLabels), len(values))
+	}
+
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+	for _, opt := range opts {
+		opt.set(hp.opts)
+	}
+	if err := hp.opts.validate(); err != nil {
+		return err
+	}
+
+	hp.xLabels = append([]string{}, xLabels...)
+	hp.yLabels = append([]string{}, yLabels...)
+	hp.times = make([]time.Time, columns)
+	hp.values = nil
+	for _, row := range values {
+		hp.values = append(hp.values, append([]float64{}, row...))
+	}
+	hp.minValue, hp.maxValue = minMax(hp.values, hp.opts.logScale)
+	hp.invalidator.Invalidate()
+	return nil
+}
+
+// AddColumn appends a column of values to the right side of the HeatMap, e.g.
+// the number of requests in each latency bucket observed in the time bucket
+// that starts at t. The X label of the column is the timestamp formatted
+// according to the TimeFormat option.
+//
+// The column must have one value for each row. If the HeatMap doesn't have
+// any rows yet, the column determines the number of rows which get the
+// labels "0", "1", "2"... Call Values with the Y labels and empty rows to
+// label the rows before adding the first column.
+//
+// Once the HeatMap was drawn, the oldest columns that no longer fit the
+// canvas are dropped, see ValueCapacity.
+// Provided options override values set when New() was called.
+func (hp *HeatMap) AddColumn(t time.Time, column []float64, opts ...Option) error {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+	for _, opt := range opts {
+		opt.set(hp.opts)
+	}
+	if err := hp.opts.validate(); err != nil {
+		return err
+	}
+
+	if len(hp.values) == 0 {
+		if len(column) == 0 {
+			return errors.New("the column must have at least one value")
+		}
+		hp.values = make([][]float64, len(column))
+		hp.yLabels = numberLabels(len(column))
+	}
+	if len(column) != len(hp.values) {
+		return fmt.Errorf("got %d values in the column, must be equal to the number of rows %d", len(column), len(hp.values))
+	}
+
+	// The X labels are nil after they were cleared, keep it that way.
+	if len(hp.xLabels) == hp.columns() {
+		hp.xLabels = append(hp.xLabels, t.Format(hp.opts.timeFormat))
+	}
+	hp.times = append(hp.times, t)
+	for i, v := range column {
+		hp.values[i] = append(hp.values[i], v)
+	}
+
+	if fit := hp.columnCapacity(); fit > 0 && hp.columns() > fit {
+		hp.dropColumns(hp.columns() - fit)
+	}
+	hp.minValue, hp.maxValue = minMax(hp.values, hp.opts.logScale)
+	hp.invalidator.Invalidate()
+	return nil
+}
+
+// dropColumns removes the n oldest columns of values.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) dropColumns(n int) {
+	for i, row := range hp.values {
+		hp.values[i] = append([]float64{}, row[n:]...)
+	}
+	if len(hp.xLabels) >= n {
+		hp.xLabels = append([]string{}, hp.xLabels[n:]...)
+	}
+	hp.times = append([]time.Time{}, hp.times[n:]...)
+}
+
+// minMax returns the smallest and the largest values ignoring NaN values and
+// also values that aren't positive if positive is true.
+// Returns zeroes if there aren't any values.
+func minMax(values [][]float64, positive bool) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, row := range values {
+		for _, v := range row {
+			if math.IsNaN(v) || (positive && v <= 0) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	if math.IsInf(min, 1) {
+		return 0, 0
+	}
+	return min, max
 }
 
 // ClearXLabels clear the X labels.
 func (hp *HeatMap) ClearXLabels() {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
 	hp.xLabels = nil
+	hp.invalidator.Invalidate()
 }
 
 // ClearYLabels clear the Y labels.
 func (hp *HeatMap) ClearYLabels() {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
 	hp.yLabels = nil
+	hp.invalidator.Invalidate()
 }
 
 // ValueCapacity returns the number of values that can fit into the canvas.
@@ -97,34 +274,224 @@ func (hp *HeatMap) ClearYLabels() {
 // no guarantee this remains the same next time Draw is called.
 // Should be used as a hint only.
 func (hp *HeatMap) ValueCapacity() int {
+	hp.mu.RLock()
+	defer hp.mu.RUnlock()
+
+	if hp.lastWidth == 0 || hp.lastHeight == 0 {
+		return 0
+	}
+	columns := hp.columnCapacity()
+	rows := hp.lastHeight - hp.xLabelsHeight() - hp.legendHeight()
+	if columns <= 0 || rows <= 0 {
+		return 0
+	}
+	return columns * rows
+}
+
+// columnCapacity returns the number of columns of values that fit the width
+// of the canvas as observed on the last call to draw. Returns zero if draw
+// wasn't called.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) columnCapacity() int {
+	if hp.lastWidth == 0 {
+		return 0
+	}
+	columns := (hp.lastWidth - axes.RequiredWidth(longest(hp.rowLabels()))) / hp.opts.cellWidth
+	if columns < 0 {
+		return 0
+	}
+	return columns
+}
+
+// longest returns the widest of the strings.
+func longest(strs []string) string {
+	var res string
+	for _, s := range strs {
+		if runewidth.StringWidth(s) > runewidth.StringWidth(res) {
+			res = s
+		}
+	}
+	return res
+}
+
+// columns returns the number of columns of values.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) columns() int {
+	if len(hp.values) == 0 {
+		return 0
+	}
+	return len(hp.values[0])
+}
+
+// rowLabels returns the labels for each row of values, these are empty if
+// the Y labels were cleared.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) rowLabels() []string {
+	if hp.yLabels != nil {
+		return hp.yLabels
+	}
+	return make([]string, len(hp.values))
+}
+
+// xLabelsHeight returns the height required for the X labels.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) xLabelsHeight() int {
+	if axes.LongestString(hp.xLabels) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// legendHeight returns the height required for the legend.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) legendHeight() int {
+	if hp.opts.showLegend {
+		return legendHeight
+	}
+	return 0
+}
+
+// mapper returns the mapper of values onto the color scale.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) mapper() *mapper {
+	return &mapper{
+		cs:  hp.opts.colorScale,
+		log: hp.opts.logScale,
+		min: hp.minValue,
+		max: hp.maxValue,
+	}
+}
+
+// firstColumn returns the index of the first column of values that is drawn,
+// so that the last columns fit the width available to the cells.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) firstColumn(width int) int {
+	fit := width / hp.opts.cellWidth
+	if first := hp.columns() - fit; first > 0 {
+		return first
+	}
 	return 0
 }
 
 // axesDetails determines the details about the X and Y axes.
 func (hp *HeatMap) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, error) {
-	return nil, nil, errors.New("not implemented")
+	cvsAr := cvs.Area()
+	graphHeight := cvsAr.Dy() - hp.xLabelsHeight() - hp.legendHeight()
+	yLabels := hp.rowLabels()
+	if len(yLabels) > graphHeight {
+		yLabels = yLabels[:graphHeight]
+	}
+	yd, err := axes.NewYDetails(yLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only label the columns that fit the canvas.
+	var xLabels []string
+	if hp.xLabels != nil {
+		fit := (cvsAr.Dx() - yd.Width) / hp.opts.cellWidth
+		first := len(hp.xLabels) - fit
+		if first < 0 {
+			first = 0
+		}
+		xLabels = hp.xLabels[first:]
+	}
+	xd, err := axes.NewXDetails(cvsAr, yd.End, xLabels, hp.opts.cellWidth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return xd, yd, nil
 }
 
 // Draw draws cells, X labels and Y labels as HeatMap.
 // Implements widgetapi.Widget.Draw.
 func (hp *HeatMap) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
-	return errors.New("not implemented")
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+	hp.invalidator = meta.Invalidator
+
+	ar := cvs.Area()
+	hp.lastWidth = ar.Dx()
+	hp.lastHeight = ar.Dy()
+
+	needAr := image.Rect(0, 0, hp.minSize().X, hp.minSize().Y)
+	if !needAr.In(ar) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	xd, yd, err := hp.axesDetails(cvs)
+	if err != nil {
+		return err
+	}
+	hp.cellsAr = image.Rect(xd.Start.X, 0, xd.End.X, yd.End.Y)
+	m := hp.mapper()
+	if err := hp.drawCells(cvs, xd, yd, m); err != nil {
+		return err
+	}
+	if err := hp.drawLabels(cvs, xd, yd); err != nil {
+		return err
+	}
+	if hp.opts.showLegend && len(hp.values) > 0 {
+		start := image.Point{xd.Start.X, yd.End.Y + hp.xLabelsHeight()}
+		return hp.drawLegend(cvs, start, m)
+	}
+	return nil
 }
 
 // drawCells draws m*n cells (rectangles) representing the stored values.
 // The height of each cell is 1 and the default width is 3.
-func (hp *HeatMap) drawCells(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
-	return errors.New("not implemented")
+func (hp *HeatMap) drawCells(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails, m *mapper) error {
+	first := hp.firstColumn(xd.End.X - xd.Start.X)
+	for row := 0; row < yd.End.Y && row < len(hp.values); row++ {
+		for col := first; col < hp.columns(); col++ {
+			v := hp.values[row][col]
+			if math.IsNaN(v) {
+				continue
+			}
+			x := xd.Start.X + (col-first)*hp.opts.cellWidth
+			cellAr := image.Rect(x, row, x+hp.opts.cellWidth, row+1)
+			if err := cvs.SetAreaCells(cellAr, ' ', cell.BgColor(m.color(v))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // drawAxes draws X labels (under the cells) and Y Labels (on the left side of the cell).
 func (hp *HeatMap) drawLabels(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
-	return errors.New("not implemented")
+	for _, l := range yd.Labels {
+		if err := draw.Text(cvs, l.Text, l.Pos,
+			draw.TextCellOpts(hp.opts.yLabelCellOpts...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return fmt.Errorf("failed to draw the Y label %q: %v", l.Text, err)
+		}
+	}
+	for _, l := range xd.Labels {
+		if err := draw.Text(cvs, l.Text, l.Pos,
+			draw.TextCellOpts(hp.opts.xLabelCellOpts...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return fmt.Errorf("failed to draw the X label %q: %v", l.Text, err)
+		}
+	}
+	return nil
 }
 
 // minSize determines the minimum required size to draw HeatMap.
 func (hp *HeatMap) minSize() image.Point {
-	return image.Point{}
+	// At the very least we need:
+	// - n cells width for the Y axis and its labels.
+	// - cellWidth cells width for one column of values.
+	reqWidth := axes.RequiredWidth(longest(hp.rowLabels())) + hp.opts.cellWidth
+
+	// And for the height:
+	// - one cell height for the X labels if there are any.
+	// - one cell height for one row of values.
+	// - two cells height for the legend if it is displayed.
+	reqHeight := hp.xLabelsHeight() + 1 + hp.legendHeight()
+	return image.Point{reqWidth, reqHeight}
 }
 
 // Keyboard input isn't supported on the HeatMap widget.
@@ -132,22 +499,89 @@ func (*HeatMap) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) err
 	return errors.New("the HeatMap widget doesn't support keyboard events")
 }
 
-// Mouse input isn't supported on the HeatMap widget.
-func (*HeatMap) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
-	return errors.New("the HeatMap widget doesn't support mouse events")
+// cellAt returns the cell drawn at the point of the canvas.
+// Returns nil if there isn't any cell at the point.
+// hp.mu must be held when calling this method.
+func (hp *HeatMap) cellAt(p image.Point) *Cell {
+	if !p.In(hp.cellsAr) {
+		return nil
+	}
+	row := p.Y
+	col := hp.firstColumn(hp.cellsAr.Dx()) + (p.X-hp.cellsAr.Min.X)/hp.opts.cellWidth
+	if row >= len(hp.values) || col >= hp.columns() {
+		return nil
+	}
+
+	c := &Cell{
+		Row:    row,
+		Column: col,
+		Time:   hp.times[col],
+		Value:  hp.values[row][col],
+	}
+	if col < len(hp.xLabels) {
+		c.XLabel = hp.xLabels[col]
+	}
+	if row < len(hp.yLabels) {
+		c.YLabel = hp.yLabels[row]
+	}
+	return c
+}
+
+// mouse processes the mouse event and returns the callback that must be
+// called after hp.mu is released.
+func (hp *HeatMap) mouse(m *terminalapi.Mouse) func() error {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	c := hp.cellAt(m.Position)
+	switch {
+	case m.Button == mouse.ButtonNone:
+		hovered := image.Point{-1, -1}
+		if c != nil {
+			hovered = image.Point{c.Column, c.Row}
+		}
+		if hovered == hp.hovered {
+			return nil
+		}
+		hp.hovered = hovered
+		if fn := hp.opts.onCellHover; fn != nil {
+			return func() error { return fn(c) }
+		}
+
+	case m.Button == mouse.ButtonLeft && !m.Motion && c != nil:
+		if fn := hp.opts.onCellClick; fn != nil {
+			return func() error { return fn(c) }
+		}
+	}
+	return nil
+}
+
+// Mouse reports the cells the mouse hovers over or clicks on to the functions
+// provided via the OnCellHover and OnCellClick options.
+// Implements widgetapi.Widget.Mouse.
+func (hp *HeatMap) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	if fn := hp.mouse(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
 }
 
 // Options implements widgetapi.Widget.Options.
 func (hp *HeatMap) Options() widgetapi.Options {
 	hp.mu.Lock()
 	defer hp.mu.Unlock()
-	return widgetapi.Options{}
-}
 
-// getCellColor returns the color of the cell according to its value.
-// The larger the value, the darker the color.
-// The color range is in Xterm color, from 232 to 255.
-// Refer to https://jonasjacek.github.io/colors/.
-func (hp *HeatMap) getCellColor(value float64) cell.Color {
-	return cell.ColorDefault
+	opts := widgetapi.Options{
+		MinimumSize: hp.minSize(),
+	}
+	if hp.opts.onCellClick != nil {
+		opts.WantMouse = widgetapi.MouseScopeWidget
+	}
+	if hp.opts.onCellHover != nil {
+		// The container scope reports when the mouse leaves the canvas.
+		opts.WantMouse = widgetapi.MouseScopeContainer
+		opts.WantMouseMotion = true
+	}
+	return opts
 }
@@ -13,3 +13,866 @@
 // limitations under the License.
 
 package heatmap
+
+import (
+	"errors"
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart"
+)
+
+// mustCell draws a heat map cell with the color.
+func mustCell(c *canvas.Canvas, ar image.Rectangle, color int) {
+	testcanvas.MustSetAreaCells(c, ar, ' ', cell.BgColor(cell.ColorNumber(color)))
+}
+
+func TestHeatMap(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// update if not nil is called before the draw.
+		update        func(*HeatMap) error
+		want          func(size image.Point) *faketerm.Terminal
+		wantErr       bool
+		wantUpdateErr bool
+		wantDrawErr   bool
+	}{
+		{
+			desc: "fails on zero CellWidth",
+			opts: []Option{
+				CellWidth(0),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc: "fails on invalid color scale",
+			opts: []Option{
+				Scale(SequentialScale(cell.ColorRed)),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc: "fails on diverging scale with non-positive center and LogScale",
+			opts: []Option{
+				Scale(DivergingScale(0, cell.ColorBlue, cell.ColorRed)),
+				LogScale(),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc: "fails on diverging scale with negative center and LogScale",
+			opts: []Option{
+				Scale(DivergingScale(-1, cell.ColorBlue, cell.ColorRed)),
+				LogScale(),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc:   "Values fails when the rows have different lengths",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1},
+					{2},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "Values fails on wrong number of X labels",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values([]string{"a"}, nil, [][]float64{
+					{0, 1},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "Values fails on wrong number of Y labels",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, []string{"a", "b"}, [][]float64{
+					{0, 1},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "Values fails on invalid option",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{{0}}, CellWidth(-1))
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws nothing without values",
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws resize needed character when canvas is smaller than required",
+			canvas: image.Rect(0, 0, 4, 1),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{{0, 1}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws values with the default labels",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1},
+					{2, 3},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				testdraw.MustText(c, "1", image.Point{0, 1})
+				mustCell(c, image.Rect(2, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 0, 8, 1), 247)
+				mustCell(c, image.Rect(2, 1, 5, 2), 240)
+				mustCell(c, image.Rect(5, 1, 8, 2), 232)
+				testdraw.MustText(c, "0", image.Point{3, 2})
+				testdraw.MustText(c, "1", image.Point{6, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws custom labels with cell options and cell width",
+			opts: []Option{
+				CellWidth(2),
+				XLabelCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(
+					[]string{"a", "b", "c"},
+					[]string{"x", "yy"},
+					[][]float64{
+						{0, 1, 2},
+						{2, 1, 0},
+					},
+					YLabelCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				yOpts := draw.TextCellOpts(cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "x", image.Point{1, 0}, yOpts)
+				testdraw.MustText(c, "yy", image.Point{0, 1}, yOpts)
+				mustCell(c, image.Rect(3, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 0, 7, 1), 243)
+				mustCell(c, image.Rect(7, 0, 9, 1), 232)
+				mustCell(c, image.Rect(3, 1, 5, 2), 232)
+				mustCell(c, image.Rect(5, 1, 7, 2), 243)
+				mustCell(c, image.Rect(7, 1, 9, 2), 255)
+				xOpts := draw.TextCellOpts(cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "a", image.Point{3, 2}, xOpts)
+				testdraw.MustText(c, "b", image.Point{5, 2}, xOpts)
+				testdraw.MustText(c, "c", image.Point{7, 2}, xOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws only the last columns and the first rows that fit",
+			canvas: image.Rect(0, 0, 8, 2),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1, 2, 3},
+					{3, 2, 1, 0},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				mustCell(c, image.Rect(2, 0, 5, 1), 240)
+				mustCell(c, image.Rect(5, 0, 8, 1), 232)
+				testdraw.MustText(c, "2", image.Point{3, 1})
+				testdraw.MustText(c, "3", image.Point{6, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't draw NaN values",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, math.NaN()},
+					{math.NaN(), 3},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				testdraw.MustText(c, "1", image.Point{0, 1})
+				mustCell(c, image.Rect(2, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 1, 8, 2), 232)
+				testdraw.MustText(c, "0", image.Point{3, 2})
+				testdraw.MustText(c, "1", image.Point{6, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "equal values use the lightest color",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{5, 5},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				mustCell(c, image.Rect(2, 0, 8, 1), 255)
+				testdraw.MustText(c, "0", image.Point{3, 1})
+				testdraw.MustText(c, "1", image.Point{6, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws values with a discrete scale",
+			opts: []Option{
+				Scale(DiscreteScale(
+					[]float64{1, 3},
+					[]cell.Color{cell.ColorNumber(21), cell.ColorNumber(22), cell.ColorNumber(23)},
+				)),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1},
+					{2, 3},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				testdraw.MustText(c, "1", image.Point{0, 1})
+				mustCell(c, image.Rect(2, 0, 5, 1), 21)
+				mustCell(c, image.Rect(5, 0, 8, 1), 22)
+				mustCell(c, image.Rect(2, 1, 5, 2), 22)
+				mustCell(c, image.Rect(5, 1, 8, 2), 23)
+				testdraw.MustText(c, "0", image.Point{3, 2})
+				testdraw.MustText(c, "1", image.Point{6, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws values on a logarithmic scale",
+			opts: []Option{
+				LogScale(),
+			},
+			canvas: image.Rect(0, 0, 14, 2),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1, 10, 100},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				mustCell(c, image.Rect(2, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 0, 8, 1), 255)
+				mustCell(c, image.Rect(8, 0, 11, 1), 243)
+				mustCell(c, image.Rect(11, 0, 14, 1), 232)
+				testdraw.MustText(c, "0", image.Point{3, 1})
+				testdraw.MustText(c, "1", image.Point{6, 1})
+				testdraw.MustText(c, "2", image.Point{9, 1})
+				testdraw.MustText(c, "3", image.Point{12, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the legend",
+			opts: []Option{
+				ShowLegend(),
+			},
+			canvas: image.Rect(0, 0, 12, 5),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1},
+					{2, 3},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				testdraw.MustText(c, "1", image.Point{0, 1})
+				mustCell(c, image.Rect(2, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 0, 8, 1), 247)
+				mustCell(c, image.Rect(2, 1, 5, 2), 240)
+				mustCell(c, image.Rect(5, 1, 8, 2), 232)
+				testdraw.MustText(c, "0", image.Point{3, 2})
+				testdraw.MustText(c, "1", image.Point{6, 2})
+				for i, color := range []int{255, 252, 250, 247, 245, 242, 240, 237, 235, 232} {
+					mustCell(c, image.Rect(2+i, 3, 3+i, 4), color)
+				}
+				testdraw.MustText(c, "0", image.Point{2, 4})
+				testdraw.MustText(c, "1.5", image.Point{6, 4})
+				testdraw.MustText(c, "3", image.Point{11, 4})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the thresholds of a discrete scale in the legend",
+			opts: []Option{
+				Scale(DiscreteScale(
+					[]float64{1, 2},
+					[]cell.Color{cell.ColorNumber(21), cell.ColorNumber(22), cell.ColorNumber(23)},
+				)),
+				ShowLegend(),
+				LegendFormatter(linechart.ValueFormatterSuffix(0, "ms")),
+				LegendCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 14, 4),
+			update: func(hp *HeatMap) error {
+				return hp.Values(nil, nil, [][]float64{
+					{0, 1, 2},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				mustCell(c, image.Rect(2, 0, 5, 1), 21)
+				mustCell(c, image.Rect(5, 0, 8, 1), 22)
+				mustCell(c, image.Rect(8, 0, 11, 1), 23)
+				testdraw.MustText(c, "0", image.Point{3, 1})
+				testdraw.MustText(c, "1", image.Point{6, 1})
+				testdraw.MustText(c, "2", image.Point{9, 1})
+				mustCell(c, image.Rect(2, 2, 6, 3), 21)
+				mustCell(c, image.Rect(6, 2, 10, 3), 22)
+				mustCell(c, image.Rect(10, 2, 14, 3), 23)
+				lOpts := draw.TextCellOpts(cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "1ms", image.Point{5, 3}, lOpts)
+				testdraw.MustText(c, "2ms", image.Point{9, 3}, lOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "AddColumn fails on empty first column",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.AddColumn(time.Time{}, nil)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "AddColumn fails on wrong number of values",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				if err := hp.AddColumn(time.Time{}, []float64{0, 1}); err != nil {
+					return err
+				}
+				return hp.AddColumn(time.Time{}, []float64{0})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "AddColumn fails on invalid option",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				return hp.AddColumn(time.Time{}, []float64{0}, CellWidth(0))
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "draws columns labeled with timestamps",
+			opts: []Option{
+				TimeFormat("4"),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				if err := hp.Values(nil, []string{"a", "b"}, [][]float64{{}, {}}); err != nil {
+					return err
+				}
+				start := time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC)
+				if err := hp.AddColumn(start, []float64{0, 1}); err != nil {
+					return err
+				}
+				return hp.AddColumn(start.Add(time.Minute), []float64{2, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "a", image.Point{0, 0})
+				testdraw.MustText(c, "b", image.Point{0, 1})
+				mustCell(c, image.Rect(2, 0, 5, 1), 255)
+				mustCell(c, image.Rect(5, 0, 8, 1), 240)
+				mustCell(c, image.Rect(2, 1, 5, 2), 247)
+				mustCell(c, image.Rect(5, 1, 8, 2), 232)
+				testdraw.MustText(c, "1", image.Point{3, 2})
+				testdraw.MustText(c, "2", image.Point{6, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "cleared labels take no space",
+			canvas: image.Rect(0, 0, 10, 3),
+			update: func(hp *HeatMap) error {
+				if err := hp.Values(nil, nil, [][]float64{
+					{0, 1},
+				}); err != nil {
+					return err
+				}
+				hp.ClearXLabels()
+				hp.ClearYLabels()
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustCell(c, image.Rect(1, 0, 4, 1), 255)
+				mustCell(c, image.Rect(4, 0, 7, 1), 232)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hp, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(hp)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("tc.update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			err = hp.Draw(c, &widgetapi.Meta{})
+			if (err != nil) != tc.wantDrawErr {
+				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestAddColumn(t *testing.T) {
+	hp, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := hp.AddColumn(start, []float64{0, 1}); err != nil {
+		t.Fatalf("AddColumn => unexpected error: %v", err)
+	}
+	// Two columns fit the canvas.
+	if err := hp.Draw(testcanvas.MustNew(image.Rect(0, 0, 8, 3)), &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	for i := 1; i <= 2; i++ {
+		if err := hp.AddColumn(start.Add(time.Duration(i)*time.Minute), []float64{float64(2 * i), float64(2*i + 1)}); err != nil {
+			t.Fatalf("AddColumn => unexpected error: %v", err)
+		}
+	}
+
+	if diff := pretty.Compare([][]float64{{2, 4}, {3, 5}}, hp.values); diff != "" {
+		t.Errorf("AddColumn => unexpected values, diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"10:01:00", "10:02:00"}, hp.xLabels); diff != "" {
+		t.Errorf("AddColumn => unexpected X labels, diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"0", "1"}, hp.yLabels); diff != "" {
+		t.Errorf("AddColumn => unexpected Y labels, diff (-want, +got):\n%s", diff)
+	}
+	wantTimes := []time.Time{start.Add(time.Minute), start.Add(2 * time.Minute)}
+	if diff := pretty.Compare(wantTimes, hp.times); diff != "" {
+		t.Errorf("AddColumn => unexpected times, diff (-want, +got):\n%s", diff)
+	}
+}
+
+// cellTracker tracks calls of the OnCellHover and OnCellClick functions.
+type cellTracker struct {
+	// hovered are the cells OnCellHover was called with.
+	hovered []*Cell
+	// clicked are the cells OnCellClick was called with.
+	clicked []*Cell
+	// wantErr when set to true, makes the callbacks return an error.
+	wantErr bool
+}
+
+// onHover is the OnCellHover function.
+func (ct *cellTracker) onHover(c *Cell) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.hovered = append(ct.hovered, c)
+	return nil
+}
+
+// onClick is the OnCellClick function.
+func (ct *cellTracker) onClick(c *Cell) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.clicked = append(ct.clicked, c)
+	return nil
+}
+
+func TestMouse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	// cellA1 is the cell in the first row and the second column.
+	cellA1 := &Cell{
+		Row:    0,
+		Column: 1,
+		XLabel: "10:01:00",
+		YLabel: "a",
+		Time:   start.Add(time.Minute),
+		Value:  1,
+	}
+	// cellB0 is the cell in the second row and the first column.
+	cellB0 := &Cell{
+		Row:    1,
+		Column: 0,
+		XLabel: "10:00:00",
+		YLabel: "b",
+		Time:   start,
+		Value:  2,
+	}
+
+	tests := []struct {
+		desc        string
+		events      []*terminalapi.Mouse
+		wantErr     bool
+		wantHovered []*Cell
+		wantClicked []*Cell
+	}{
+		{
+			desc: "reports the hovered cells",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{5, 0}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{7, 0}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonNone, Motion: true},
+			},
+			wantHovered: []*Cell{cellA1, cellB0},
+		},
+		{
+			desc: "reports when the mouse leaves the cells",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{5, 0}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{0, 0}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{-1, -1}, Button: mouse.ButtonNone, Motion: true},
+			},
+			wantHovered: []*Cell{cellA1, nil},
+		},
+		{
+			desc: "ignores the empty space after the last column",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonNone, Motion: true},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+			},
+		},
+		{
+			desc: "reports the clicked cells",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 1}, Button: mouse.ButtonRelease},
+				{Position: image.Point{6, 0}, Button: mouse.ButtonLeft},
+			},
+			wantClicked: []*Cell{cellB0, cellA1},
+		},
+		{
+			desc: "ignores drags",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft, Motion: true},
+			},
+		},
+		{
+			desc: "ignores clicks on the labels",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{3, 2}, Button: mouse.ButtonLeft},
+			},
+		},
+		{
+			desc: "forwards errors from the callback",
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &cellTracker{wantErr: tc.wantErr}
+			hp, err := New(
+				OnCellHover(ct.onHover),
+				OnCellClick(ct.onClick),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := hp.Values(nil, []string{"a", "b"}, [][]float64{{}, {}}); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+			if err := hp.AddColumn(start, []float64{0, 2}); err != nil {
+				t.Fatalf("AddColumn => unexpected error: %v", err)
+			}
+			if err := hp.AddColumn(start.Add(time.Minute), []float64{1, 3}); err != nil {
+				t.Fatalf("AddColumn => unexpected error: %v", err)
+			}
+			if err := hp.Draw(testcanvas.MustNew(image.Rect(0, 0, 12, 3)), &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var gotErr error
+			for _, ev := range tc.events {
+				if err := hp.Mouse(ev, &widgetapi.EventMeta{}); err != nil {
+					gotErr = err
+				}
+			}
+			if (gotErr != nil) != tc.wantErr {
+				t.Errorf("Mouse => unexpected error: %v, wantErr: %v", gotErr, tc.wantErr)
+			}
+			if diff := pretty.Compare(tc.wantHovered, ct.hovered); diff != "" {
+				t.Errorf("OnCellHover => unexpected cells, diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantClicked, ct.clicked); diff != "" {
+				t.Errorf("OnCellClick => unexpected cells, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValueCapacity(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		values [][]float64
+		want   int
+	}{
+		{
+			desc:   "zero before the first draw",
+			values: [][]float64{{0}},
+		},
+		{
+			desc:   "cells that fit the canvas",
+			canvas: image.Rect(0, 0, 10, 3),
+			values: [][]float64{{0, 1}, {2, 3}},
+			// Two columns and two rows, the last row contains X labels.
+			want: 4,
+		},
+		{
+			desc: "depends on the cell width",
+			opts: []Option{
+				CellWidth(1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			values: [][]float64{{0, 1}, {2, 3}},
+			want:   16,
+		},
+		{
+			desc: "excludes the legend",
+			opts: []Option{
+				ShowLegend(),
+			},
+			canvas: image.Rect(0, 0, 10, 5),
+			values: [][]float64{{0, 1}, {2, 3}},
+			want:   4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hp, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := hp.Values(nil, nil, tc.values); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+			if !tc.canvas.Empty() {
+				if err := hp.Draw(testcanvas.MustNew(tc.canvas), &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if got := hp.ValueCapacity(); got != tc.want {
+				t.Errorf("ValueCapacity => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequestsRedraw(t *testing.T) {
+	hp, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	if err := hp.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := hp.Values(nil, nil, [][]float64{{0, 1}}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+	hp.ClearXLabels()
+	if got, want := requests, 2; got != want {
+		t.Errorf("HeatMap requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		xLabels []string
+		yLabels []string
+		want    widgetapi.Options
+	}{
+		{
+			desc: "minimum size for the default labels",
+			want: widgetapi.Options{
+				MinimumSize: image.Point{5, 2},
+			},
+		},
+		{
+			desc:    "minimum size for wide Y labels",
+			xLabels: []string{"", ""},
+			yLabels: []string{"long"},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{8, 1},
+			},
+		},
+		{
+			desc: "minimum size with custom cell width",
+			opts: []Option{
+				CellWidth(1),
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{3, 2},
+			},
+		},
+		{
+			desc: "wants mouse clicks",
+			opts: []Option{
+				OnCellClick(func(*Cell) error { return nil }),
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{5, 2},
+				WantMouse:   widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "wants mouse motion in the container",
+			opts: []Option{
+				OnCellHover(func(*Cell) error { return nil }),
+			},
+			want: widgetapi.Options{
+				MinimumSize:     image.Point{5, 2},
+				WantMouse:       widgetapi.MouseScopeContainer,
+				WantMouseMotion: true,
+			},
+		},
+		{
+			desc: "minimum size with the legend",
+			opts: []Option{
+				ShowLegend(),
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{5, 4},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hp, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := hp.Values(tc.xLabels, tc.yLabels, [][]float64{{0, 1}}); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+
+			got := hp.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
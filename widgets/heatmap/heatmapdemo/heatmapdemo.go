@@ -12,20 +12,115 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Binary heatmapdemo displays a heatmap widget.
+// Binary heatmapdemo displays a heatmap widget with the number of requests
+// served in each hour of the week and another one with the distribution of
+// request latencies streamed over time.
 // Exist when 'q' is pressed.
 package main
 
 import (
 	"context"
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+
 	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/container"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/terminal/tcell"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgets/heatmap"
+	"github.com/mum4k/termdash/widgets/linechart"
+	"github.com/mum4k/termdash/widgets/text"
 )
 
+// days are the labels on the Y axis.
+var days = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// hours returns the labels on the X axis.
+func hours() []string {
+	var res []string
+	for h := 0; h < 24; h++ {
+		res = append(res, fmt.Sprintf("%02d", h))
+	}
+	return res
+}
+
+// requests returns random values with more requests during the day and on
+// weekdays.
+func requests(r *rand.Rand) [][]float64 {
+	var res [][]float64
+	for d := range days {
+		var row []float64
+		for h := 0; h < 24; h++ {
+			v := 100 + 80*math.Sin(float64(h-6)*math.Pi/12) + 40*r.Float64()
+			if d >= 5 {
+				v /= 2
+			}
+			row = append(row, v)
+		}
+		res = append(res, row)
+	}
+	return res
+}
+
+// update periodically updates the values of the heat map.
+// Exits when the context expires.
+func update(ctx context.Context, hp *heatmap.HeatMap, delay time.Duration) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := hp.Values(hours(), days, requests(r)); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// latencyBuckets are the labels on the Y axis of the latency heat map.
+var latencyBuckets = []string{"<1ms", "<2ms", "<5ms", "<10ms", "<20ms", "<50ms", "<100ms", "≥100ms"}
+
+// latencies returns the random number of requests in each latency bucket.
+func latencies(r *rand.Rand) []float64 {
+	var res []float64
+	for i := range latencyBuckets {
+		// Most requests are fast, some are slow.
+		peak := 1000 * math.Exp(-math.Pow(float64(i)-2, 2)/2)
+		res = append(res, math.Round(peak*(0.5+r.Float64())))
+	}
+	return res
+}
+
+// stream periodically adds a column of latencies to the heat map.
+// Exits when the context expires.
+func stream(ctx context.Context, hp *heatmap.HeatMap, delay time.Duration) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case t := <-ticker.C:
+			if err := hp.AddColumn(t, latencies(r)); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func main() {
 	t, err := tcell.New()
 	if err != nil {
@@ -33,24 +128,93 @@ func main() {
 	}
 	defer t.Close()
 
-	hp, err := heatmap.New()
+	hp, err := heatmap.New(
+		heatmap.CellWidth(2),
+		heatmap.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		heatmap.YLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		heatmap.Scale(heatmap.SequentialScale(heatmap.GradientRGB24(
+			16,
+			color.RGBA{0, 0, 95, 255},
+			color.RGBA{255, 215, 0, 255},
+			color.RGBA{255, 0, 0, 255},
+		)...)),
+		heatmap.ShowLegend(),
+		heatmap.LegendFormatter(linechart.ValueFormatterRoundWithSuffix(" req")),
+		heatmap.LegendCellOpts(cell.FgColor(cell.ColorCyan)),
+	)
 	if err != nil {
 		panic(err)
 	}
+	if err := hp.Values(hours(), days, requests(rand.New(rand.NewSource(time.Now().Unix())))); err != nil {
+		panic(err)
+	}
 
-	// TODO: set heatmap's data
+	status, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := status.Write("Hover over or click on a cell of the latency heat map."); err != nil {
+		panic(err)
+	}
+	report := func(c *heatmap.Cell) error {
+		if c == nil {
+			return status.Write("Hover over or click on a cell of the latency heat map.", text.WriteReplace())
+		}
+		return status.Write(fmt.Sprintf("%v requests %s at %s.", c.Value, c.YLabel, c.Time.Format(time.TimeOnly)), text.WriteReplace())
+	}
+
+	lat, err := heatmap.New(
+		heatmap.CellWidth(1),
+		heatmap.TimeFormat("15:04:05"),
+		heatmap.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		heatmap.YLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		heatmap.Scale(heatmap.SequentialScale(heatmap.Gradient256(17, 21)...)),
+		heatmap.LogScale(),
+		heatmap.ShowLegend(),
+		heatmap.LegendFormatter(linechart.ValueFormatterRound),
+		heatmap.LegendCellOpts(cell.FgColor(cell.ColorCyan)),
+		heatmap.OnCellHover(report),
+		heatmap.OnCellClick(report),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := lat.Values(nil, latencyBuckets, make([][]float64, len(latencyBuckets))); err != nil {
+		panic(err)
+	}
 
 	c, err := container.New(
 		t,
 		container.Border(linestyle.Light),
 		container.BorderTitle("PRESS Q TO QUIT"),
-		container.PlaceWidget(hp),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(linestyle.Light),
+				container.BorderTitle("Requests per hour"),
+				container.PlaceWidget(hp),
+			),
+			container.Bottom(
+				container.SplitHorizontal(
+					container.Top(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Latency distribution"),
+						container.PlaceWidget(lat),
+					),
+					container.Bottom(
+						container.PlaceWidget(status),
+					),
+					container.SplitPercent(85),
+				),
+			),
+		),
 	)
 	if err != nil {
 		panic(err)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
+	go update(ctx, hp, 2*time.Second)
+	go stream(ctx, lat, time.Second)
 
 	quitter := func(k *terminalapi.Keyboard) {
 		if k.Key == 'q' || k.Key == 'Q' {
@@ -16,7 +16,7 @@
 package axes
 
 import (
-	"errors"
+	"fmt"
 	"image"
 
 	"github.com/mum4k/termdash/private/runewidth"
@@ -52,7 +52,20 @@ func RequiredWidth(ls string) int {
 // NewYDetails retrieves details about the Y axis required
 // to draw it on a canvas of the provided area.
 func NewYDetails(labels []string) (*YDetails, error) {
-	return nil, errors.New("not implemented")
+	graphHeight := len(labels)
+	labelWidth := LongestString(labels)
+	lbls, err := yLabels(graphHeight, labelWidth, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	width := labelWidth + axisWidth
+	return &YDetails{
+		Width:  width,
+		Start:  image.Point{width - axisWidth, 0},
+		End:    image.Point{width - axisWidth, graphHeight},
+		Labels: lbls,
+	}, nil
 }
 
 // LongestString returns the length of the longest string in the string array.
@@ -83,5 +96,22 @@ type XDetails struct {
 // of the provided area.
 // The yEnd is the point where the Y axis ends.
 func NewXDetails(cvsAr image.Rectangle, yEnd image.Point, labels []string, cellWidth int) (*XDetails, error) {
-	return nil, errors.New("not implemented")
+	if min := 1; cellWidth < min {
+		return nil, fmt.Errorf("invalid cellWidth %d, must be %d <= cellWidth", cellWidth, min)
+	}
+	if yEnd.X < 0 || yEnd.X >= cvsAr.Dx() {
+		return nil, fmt.Errorf("the Y axis end %v falls outside of the canvas %v", yEnd, cvsAr)
+	}
+
+	// The cells start right of the Y axis.
+	graphWidth := cvsAr.Dx() - yEnd.X - axisWidth
+	lbls, err := xLabels(yEnd, graphWidth, labels, cellWidth)
+	if err != nil {
+		return nil, err
+	}
+	return &XDetails{
+		Start:  image.Point{yEnd.X + axisWidth, yEnd.Y},
+		End:    image.Point{cvsAr.Dx(), yEnd.Y},
+		Labels: lbls,
+	}, nil
 }
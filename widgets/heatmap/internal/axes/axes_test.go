@@ -13,3 +13,166 @@
 // limitations under the License.
 
 package axes
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestRequiredWidth(t *testing.T) {
+	tests := []struct {
+		desc string
+		ls   string
+		want int
+	}{
+		{
+			desc: "empty label",
+			want: 1,
+		},
+		{
+			desc: "half-width runes",
+			ls:   "abc",
+			want: 4,
+		},
+		{
+			desc: "full-width runes",
+			ls:   "日本",
+			want: 5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := RequiredWidth(tc.ls); got != tc.want {
+				t.Errorf("RequiredWidth(%q) => %d, want %d", tc.ls, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewYDetails(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []string
+		want    *YDetails
+		wantErr bool
+	}{
+		{
+			desc: "no labels",
+			want: &YDetails{
+				Width: 1,
+				Start: image.Point{0, 0},
+				End:   image.Point{0, 0},
+			},
+		},
+		{
+			desc:   "empty labels take no space",
+			labels: []string{"", ""},
+			want: &YDetails{
+				Width: 1,
+				Start: image.Point{0, 0},
+				End:   image.Point{0, 2},
+			},
+		},
+		{
+			desc:   "labels aligned to the right",
+			labels: []string{"a", "", "ccc"},
+			want: &YDetails{
+				Width: 4,
+				Start: image.Point{3, 0},
+				End:   image.Point{3, 3},
+				Labels: []*Label{
+					{Text: "a", Pos: image.Point{2, 0}},
+					{Text: "ccc", Pos: image.Point{0, 2}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewYDetails(tc.labels)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewYDetails => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewYDetails => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewXDetails(t *testing.T) {
+	tests := []struct {
+		desc      string
+		cvsAr     image.Rectangle
+		yEnd      image.Point
+		labels    []string
+		cellWidth int
+		want      *XDetails
+		wantErr   bool
+	}{
+		{
+			desc:      "fails on zero cellWidth",
+			cvsAr:     image.Rect(0, 0, 10, 3),
+			yEnd:      image.Point{1, 2},
+			labels:    []string{"a"},
+			cellWidth: 0,
+			wantErr:   true,
+		},
+		{
+			desc:      "fails when the Y axis is outside of the canvas",
+			cvsAr:     image.Rect(0, 0, 10, 3),
+			yEnd:      image.Point{10, 2},
+			labels:    []string{"a"},
+			cellWidth: 3,
+			wantErr:   true,
+		},
+		{
+			desc:      "labels centered under the cells",
+			cvsAr:     image.Rect(0, 0, 10, 3),
+			yEnd:      image.Point{1, 2},
+			labels:    []string{"a", "b", "c"},
+			cellWidth: 3,
+			want: &XDetails{
+				Start: image.Point{2, 2},
+				End:   image.Point{10, 2},
+				Labels: []*Label{
+					{Text: "a", Pos: image.Point{3, 2}},
+					{Text: "b", Pos: image.Point{6, 2}},
+				},
+			},
+		},
+		{
+			desc:      "labels that don't fit the graph",
+			cvsAr:     image.Rect(0, 0, 10, 3),
+			yEnd:      image.Point{1, 2},
+			labels:    []string{"aaaaaaaaa"},
+			cellWidth: 3,
+			want: &XDetails{
+				Start: image.Point{2, 2},
+				End:   image.Point{10, 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewXDetails(tc.cvsAr, tc.yEnd, tc.labels, tc.cellWidth)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewXDetails => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewXDetails => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,8 +17,12 @@ package axes
 // label.go contains code that calculates the positions of labels on the axes.
 
 import (
-	"errors"
+	"fmt"
 	"image"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/runewidth"
 )
 
 // Label is one text label on an axis.
@@ -37,20 +41,84 @@ type Label struct {
 // Labels are returned with Y coordinates in ascending order.
 // Y coordinates grow down.
 func yLabels(graphHeight, labelWidth int, labels []string) ([]*Label, error) {
-	return nil, errors.New("not implemented")
+	if len(labels) > graphHeight {
+		return nil, fmt.Errorf("cannot place %d labels on a graph with height %d", len(labels), graphHeight)
+	}
+	if min := 0; labelWidth < min {
+		return nil, fmt.Errorf("cannot place labels in label area width %d, minimum is %d", labelWidth, min)
+	}
+
+	var res []*Label
+	for row, l := range labels {
+		if l == "" {
+			continue
+		}
+		label, err := rowLabel(row, l, labelWidth)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, label)
+	}
+	return res, nil
 }
 
 // rowLabel returns one label for the specified row.
 // The row is the Y coordinate of the row, Y coordinates grow down.
 func rowLabel(row int, label string, labelWidth int) (*Label, error) {
-	return nil, errors.New("not implemented")
+	if w := runewidth.StringWidth(label); w > labelWidth {
+		return nil, fmt.Errorf("the label %q has width %d which is more than the label area width %d", label, w, labelWidth)
+	}
+
+	ar := image.Rect(0, row, labelWidth, row+1)
+	pos, err := alignfor.Text(ar, label, align.HorizontalRight, align.VerticalMiddle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to align the label: %v", err)
+	}
+	return &Label{
+		Text: label,
+		Pos:  pos,
+	}, nil
 }
 
 // xLabels returns labels that should be placed under the cells.
 // Labels are returned with X coordinates in ascending order.
 // X coordinates grow right.
 func xLabels(yEnd image.Point, graphWidth int, labels []string, cellWidth int) ([]*Label, error) {
-	return nil, errors.New("not implemented")
+	if min := 1; cellWidth < min {
+		return nil, fmt.Errorf("invalid cellWidth %d, must be %d <= cellWidth", cellWidth, min)
+	}
+
+	padded, index := paddedLabelLength(graphWidth, LongestString(labels), cellWidth)
+	if padded == 0 {
+		// Not even a single label fits.
+		return nil, nil
+	}
+
+	// The X labels start right of the Y axis.
+	startX := yEnd.X + axisWidth
+	columns := padded / cellWidth
+	var res []*Label
+	for col := index; col < len(labels); col += columns {
+		l := labels[col]
+		if l == "" {
+			continue
+		}
+
+		groupX := startX + (col-index)*cellWidth
+		if groupX+padded > startX+graphWidth {
+			break
+		}
+		ar := image.Rect(groupX, yEnd.Y, groupX+padded, yEnd.Y+1)
+		pos, err := alignfor.Text(ar, l, align.HorizontalCenter, align.VerticalMiddle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to align the label: %v", err)
+		}
+		res = append(res, &Label{
+			Text: l,
+			Pos:  pos,
+		})
+	}
+	return res, nil
 }
 
 // paddedLabelLength calculates the length of the padded X label and
@@ -59,6 +127,26 @@ func xLabels(yEnd image.Point, graphWidth int, labels []string, cellWidth int) (
 // So in order to better display, every three columns of cells will display a X label,
 // the X label belongs to the middle column of the three columns,
 // and the padded length is 3*3 (cellWidth multiplies the number of columns), which is 9.
+//
+// The padding on both sides of the label is equal when possible. Returns zero
+// length if the label doesn't fit the graph.
 func paddedLabelLength(graphWidth, longest, cellWidth int) (l, index int) {
-	return
+	if cellWidth <= 0 || longest <= 0 {
+		return 0, 0
+	}
+
+	maxColumns := graphWidth / cellWidth
+	// At least one cell of space between neighbouring labels.
+	minColumns := longest/cellWidth + 1
+	for columns := minColumns; columns <= maxColumns; columns++ {
+		if (columns*cellWidth-longest)%2 == 0 {
+			return columns * cellWidth, columns / 2
+		}
+	}
+	// Equal padding isn't possible, e.g. an odd label length with an even
+	// cellWidth.
+	if minColumns <= maxColumns {
+		return minColumns * cellWidth, minColumns / 2
+	}
+	return 0, 0
 }
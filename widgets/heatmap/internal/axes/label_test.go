@@ -13,3 +13,216 @@
 // limitations under the License.
 
 package axes
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestYLabels(t *testing.T) {
+	tests := []struct {
+		desc        string
+		graphHeight int
+		labelWidth  int
+		labels      []string
+		want        []*Label
+		wantErr     bool
+	}{
+		{
+			desc:        "fails when there are more labels than rows",
+			graphHeight: 1,
+			labelWidth:  1,
+			labels:      []string{"a", "b"},
+			wantErr:     true,
+		},
+		{
+			desc:        "fails on negative label width",
+			graphHeight: 1,
+			labelWidth:  -1,
+			labels:      []string{"a"},
+			wantErr:     true,
+		},
+		{
+			desc:        "fails when a label is wider than the label area",
+			graphHeight: 1,
+			labelWidth:  1,
+			labels:      []string{"ab"},
+			wantErr:     true,
+		},
+		{
+			desc:        "skips empty labels",
+			graphHeight: 3,
+			labelWidth:  2,
+			labels:      []string{"", "b", ""},
+			want: []*Label{
+				{Text: "b", Pos: image.Point{1, 1}},
+			},
+		},
+		{
+			desc:        "aligns labels to the right",
+			graphHeight: 2,
+			labelWidth:  4,
+			labels:      []string{"ab", "日本"},
+			want: []*Label{
+				{Text: "ab", Pos: image.Point{2, 0}},
+				{Text: "日本", Pos: image.Point{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := yLabels(tc.graphHeight, tc.labelWidth, tc.labels)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("yLabels => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("yLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestXLabels(t *testing.T) {
+	tests := []struct {
+		desc       string
+		yEnd       image.Point
+		graphWidth int
+		labels     []string
+		cellWidth  int
+		want       []*Label
+		wantErr    bool
+	}{
+		{
+			desc:       "fails on zero cellWidth",
+			graphWidth: 9,
+			labels:     []string{"a"},
+			wantErr:    true,
+		},
+		{
+			desc:       "no labels",
+			graphWidth: 9,
+			cellWidth:  3,
+		},
+		{
+			desc:       "label on every column",
+			yEnd:       image.Point{0, 1},
+			graphWidth: 9,
+			labels:     []string{"a", "b", "c"},
+			cellWidth:  3,
+			want: []*Label{
+				{Text: "a", Pos: image.Point{2, 1}},
+				{Text: "b", Pos: image.Point{5, 1}},
+				{Text: "c", Pos: image.Point{8, 1}},
+			},
+		},
+		{
+			desc:       "label on every third column",
+			yEnd:       image.Point{2, 4},
+			graphWidth: 18,
+			labels:     []string{"12:00", "12:01", "12:02", "12:03", "12:04", "12:05"},
+			cellWidth:  3,
+			want: []*Label{
+				{Text: "12:01", Pos: image.Point{5, 4}},
+				{Text: "12:04", Pos: image.Point{14, 4}},
+			},
+		},
+		{
+			desc:       "skips empty labels",
+			graphWidth: 9,
+			labels:     []string{"a", "", "c"},
+			cellWidth:  3,
+			want: []*Label{
+				{Text: "a", Pos: image.Point{2, 0}},
+				{Text: "c", Pos: image.Point{8, 0}},
+			},
+		},
+		{
+			desc:       "stops at labels that don't fit",
+			graphWidth: 7,
+			labels:     []string{"a", "b", "c"},
+			cellWidth:  3,
+			want: []*Label{
+				{Text: "a", Pos: image.Point{2, 0}},
+				{Text: "b", Pos: image.Point{5, 0}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := xLabels(tc.yEnd, tc.graphWidth, tc.labels, tc.cellWidth)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("xLabels => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("xLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPaddedLabelLength(t *testing.T) {
+	tests := []struct {
+		desc       string
+		graphWidth int
+		longest    int
+		cellWidth  int
+		wantL      int
+		wantIndex  int
+	}{
+		{
+			desc:       "no labels",
+			graphWidth: 9,
+			longest:    0,
+			cellWidth:  3,
+		},
+		{
+			desc:       "label shorter than a cell",
+			graphWidth: 9,
+			longest:    1,
+			cellWidth:  3,
+			wantL:      3,
+			wantIndex:  0,
+		},
+		{
+			desc:       "label spans three cells",
+			graphWidth: 18,
+			longest:    5,
+			cellWidth:  3,
+			wantL:      9,
+			wantIndex:  1,
+		},
+		{
+			desc:       "equal padding isn't possible",
+			graphWidth: 18,
+			longest:    3,
+			cellWidth:  2,
+			wantL:      4,
+			wantIndex:  1,
+		},
+		{
+			desc:       "label doesn't fit the graph",
+			graphWidth: 5,
+			longest:    5,
+			cellWidth:  3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotL, gotIndex := paddedLabelLength(tc.graphWidth, tc.longest, tc.cellWidth)
+			if gotL != tc.wantL || gotIndex != tc.wantIndex {
+				t.Errorf("paddedLabelLength => (%d, %d), want (%d, %d)", gotL, gotIndex, tc.wantL, tc.wantIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+// legend.go contains code that draws the legend of the color scale.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// legendHeight is the height of the legend, one row for the strip of colors
+// and one for the values.
+const legendHeight = 2
+
+// legendLabel is a value displayed in the legend.
+type legendLabel struct {
+	text string
+	// x is the position of the label relative to the start of the strip.
+	x int
+}
+
+// defaultLegendFormat formats the values in the legend unless the user
+// provided the LegendFormatter option.
+func defaultLegendFormat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// format formats the value for the legend.
+func (hp *HeatMap) format(v float64) string {
+	if hp.opts.legendFormat != nil {
+		return hp.opts.legendFormat(v)
+	}
+	return defaultLegendFormat(v)
+}
+
+// segments returns the x coordinates where each of the n segments of a strip
+// of the width begins. The last coordinate is the end of the strip.
+func segments(n, width int) []int {
+	var res []int
+	for i := 0; i <= n; i++ {
+		res = append(res, i*width/n)
+	}
+	return res
+}
+
+// stripColors returns the colors of each cell of the strip of the width.
+func stripColors(m *mapper, width int) []cell.Color {
+	cs := m.cs
+	var res []cell.Color
+	if cs.kind == scaleDiscrete {
+		bounds := segments(len(cs.colors), width)
+		for i, c := range cs.colors {
+			for x := bounds[i]; x < bounds[i+1]; x++ {
+				res = append(res, c)
+			}
+		}
+		return res
+	}
+
+	for x := 0; x < width; x++ {
+		var pos float64
+		if width > 1 {
+			pos = float64(x) / float64(width-1)
+		}
+		res = append(res, cs.colorAt(pos))
+	}
+	return res
+}
+
+// legendLabels returns the values displayed under a strip of the width.
+// Labels that don't fit or would overlap the labels placed before them are
+// skipped.
+func (hp *HeatMap) legendLabels(m *mapper, width int) []*legendLabel {
+	// candidate is a value with the x coordinate of its anchor. The label is
+	// centered on the anchor unless it is aligned to the left or to the right
+	// of it.
+	type candidate struct {
+		value float64
+		x     int
+		left  bool
+		right bool
+	}
+
+	var cands []candidate
+	if m.cs.kind == scaleDiscrete {
+		// The thresholds are centered on the boundaries of the buckets.
+		bounds := segments(len(m.cs.colors), width)
+		for i, t := range m.cs.thresholds {
+			cands = append(cands, candidate{value: t, x: bounds[i+1]})
+		}
+	} else {
+		// The smallest and the largest value at the edges and the value in
+		// the middle if it fits.
+		cands = append(cands,
+			candidate{value: m.value(0), x: 0, left: true},
+			candidate{value: m.value(1), x: width, right: true},
+			candidate{value: m.value(0.5), x: width / 2},
+		)
+	}
+
+	var res []*legendLabel
+	taken := make([]bool, width)
+	for _, c := range cands {
+		text := hp.format(c.value)
+		w := runewidth.StringWidth(text)
+		if w == 0 || w > width {
+			continue
+		}
+		var x int
+		switch {
+		case c.left:
+			x = c.x
+		case c.right:
+			x = c.x - w
+		default:
+			x = c.x - w/2
+		}
+		if x < 0 {
+			x = 0
+		}
+		if x+w > width {
+			x = width - w
+		}
+
+		// Keep one empty cell between the labels.
+		free := true
+		for i := x - 1; i <= x+w; i++ {
+			if i >= 0 && i < width && taken[i] {
+				free = false
+				break
+			}
+		}
+		if !free {
+			continue
+		}
+		for i := x; i < x+w; i++ {
+			taken[i] = true
+		}
+		res = append(res, &legendLabel{text: text, x: x})
+	}
+	return res
+}
+
+// drawLegend draws the legend of the color scale starting at the point.
+func (hp *HeatMap) drawLegend(cvs *canvas.Canvas, start image.Point, m *mapper) error {
+	width := cvs.Area().Dx() - start.X
+	if width <= 0 {
+		return nil
+	}
+	for i, c := range stripColors(m, width) {
+		p := image.Point{start.X + i, start.Y}
+		if _, err := cvs.SetCell(p, ' ', cell.BgColor(c)); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range hp.legendLabels(m, width) {
+		p := image.Point{start.X + l.x, start.Y + 1}
+		if err := draw.Text(cvs, l.text, p, draw.TextCellOpts(hp.opts.legendCellOpts...)); err != nil {
+			return fmt.Errorf("failed to draw the legend value %q: %v", l.text, err)
+		}
+	}
+	return nil
+}
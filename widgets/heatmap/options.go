@@ -15,8 +15,11 @@
 package heatmap
 
 import (
-	"errors"
+	"fmt"
+	"time"
+
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/widgets/linechart"
 )
 
 // options.go contains configurable options for HeatMap.
@@ -33,17 +36,33 @@ type options struct {
 	cellWidth      int
 	xLabelCellOpts []cell.Option
 	yLabelCellOpts []cell.Option
+	colorScale     *ColorScale
+	logScale       bool
+	showLegend     bool
+	legendFormat   linechart.ValueFormatter
+	legendCellOpts []cell.Option
+	timeFormat     string
+	onCellHover    CellFn
+	onCellClick    CellFn
 }
 
 // validate validates the provided options.
 func (o *options) validate() error {
-	return errors.New("not implemented")
+	if min := 1; o.cellWidth < min {
+		return fmt.Errorf("invalid CellWidth %d, must be %d <= width", o.cellWidth, min)
+	}
+	if err := o.colorScale.validate(o.logScale); err != nil {
+		return fmt.Errorf("invalid Scale: %v", err)
+	}
+	return nil
 }
 
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
-		cellWidth: 3,
+		cellWidth:  3,
+		colorScale: DefaultColorScale,
+		timeFormat: DefaultTimeFormat,
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -80,3 +99,101 @@ func YLabelCellOpts(co ...cell.Option) Option {
 		opts.yLabelCellOpts = co
 	})
 }
+
+// Scale sets the color scale that maps the values to the colors of the cells.
+// Defaults to DefaultColorScale.
+func Scale(cs *ColorScale) Option {
+	return option(func(opts *options) {
+		opts.colorScale = cs
+	})
+}
+
+// LogScale maps the values onto the color scale logarithmically, which is
+// useful when the values span several orders of magnitude, e.g. latencies.
+// Values that aren't positive get the first color of the scale. Doesn't affect
+// the thresholds of a DiscreteScale. The center of a DivergingScale must be
+// positive.
+func LogScale() Option {
+	return option(func(opts *options) {
+		opts.logScale = true
+	})
+}
+
+// ShowLegend displays a legend under the heat map. The legend is a strip
+// that shows the colors of the scale with the values they represent.
+func ShowLegend() Option {
+	return option(func(opts *options) {
+		opts.showLegend = true
+	})
+}
+
+// LegendFormatter sets the formatter of the values displayed in the legend.
+// By default the values are rounded to two decimal places.
+func LegendFormatter(vf linechart.ValueFormatter) Option {
+	return option(func(opts *options) {
+		opts.legendFormat = vf
+	})
+}
+
+// LegendCellOpts set the cell options for the values displayed in the legend.
+func LegendCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.legendCellOpts = co
+	})
+}
+
+// DefaultTimeFormat is the default value for the TimeFormat option.
+const DefaultTimeFormat = "15:04:05"
+
+// TimeFormat sets the layout used to format the timestamps of the columns
+// added with AddColumn into the X labels. See time.Time.Format for the
+// syntax of the layout.
+// Defaults to DefaultTimeFormat.
+func TimeFormat(layout string) Option {
+	return option(func(opts *options) {
+		opts.timeFormat = layout
+	})
+}
+
+// Cell describes a cell of the heat map, e.g. the number of requests in a
+// latency bucket observed in a time bucket.
+type Cell struct {
+	// Row and Column are the indexes of the value in the rows and columns of
+	// values currently stored in the heat map.
+	Row, Column int
+	// XLabel and YLabel are the labels of the column and the row. These are
+	// empty if the labels were cleared.
+	XLabel, YLabel string
+	// Time is the timestamp of the column if it was added with AddColumn,
+	// otherwise the zero time.
+	Time time.Time
+	// Value is the value of the cell.
+	Value float64
+}
+
+// CellFn is a function called with a cell of the heat map.
+//
+// The function must be light-weight and thread-safe as the mouse events that
+// trigger it are processed in a separate goroutine.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type CellFn func(c *Cell) error
+
+// OnCellHover sets a function that is called when the mouse starts hovering
+// over a cell. The function is called with nil when the mouse leaves the
+// cells.
+func OnCellHover(fn CellFn) Option {
+	return option(func(opts *options) {
+		opts.onCellHover = fn
+	})
+}
+
+// OnCellClick sets a function that is called when the user clicks on a cell
+// with the left mouse button.
+func OnCellClick(fn CellFn) Option {
+	return option(func(opts *options) {
+		opts.onCellClick = fn
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+// scale.go contains the color scales that map values to the colors of cells.
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// scaleKind identifies the kind of a color scale.
+type scaleKind int
+
+const (
+	scaleSequential scaleKind = iota
+	scaleDiverging
+	scaleDiscrete
+)
+
+// ColorScale maps the values in the heat map to the colors of the cells.
+// Use one of the SequentialScale, DivergingScale or DiscreteScale functions to
+// create a color scale.
+type ColorScale struct {
+	kind scaleKind
+	// colors are the colors of the scale, from the smallest to the largest
+	// values.
+	colors []cell.Color
+	// center is the value in the middle of a diverging scale.
+	center float64
+	// thresholds are the boundaries of the buckets of a discrete scale.
+	thresholds []float64
+}
+
+// SequentialScale returns a color scale that spreads the colors evenly between
+// the smallest and the largest value. The first color is used for the smallest
+// value and the last one for the largest value.
+// At least two colors must be provided.
+func SequentialScale(colors ...cell.Color) *ColorScale {
+	return &ColorScale{
+		kind:   scaleSequential,
+		colors: colors,
+	}
+}
+
+// DivergingScale returns a color scale that maps the center value to the
+// middle color and spreads the first half of the colors between the smallest
+// value and the center and the second half between the center and the largest
+// value. Useful when values deviate in both directions from a reference value.
+// At least two colors must be provided.
+func DivergingScale(center float64, colors ...cell.Color) *ColorScale {
+	return &ColorScale{
+		kind:   scaleDiverging,
+		colors: colors,
+		center: center,
+	}
+}
+
+// DiscreteScale returns a color scale with explicit buckets. Values smaller
+// than thresholds[0] use colors[0], values in the range
+// thresholds[i-1] <= value < thresholds[i] use colors[i] and values larger or
+// equal to the last threshold use the last color.
+// The thresholds must be increasing and there must be exactly one more color
+// than there are thresholds.
+func DiscreteScale(thresholds []float64, colors []cell.Color) *ColorScale {
+	return &ColorScale{
+		kind:       scaleDiscrete,
+		colors:     colors,
+		thresholds: thresholds,
+	}
+}
+
+// DefaultColorScale is the default value for the Scale option. The larger the
+// value, the darker the color of the cell, from white to black.
+// The colors are the Xterm colors 255 to 232.
+// Refer to https://jonasjacek.github.io/colors/.
+var DefaultColorScale = SequentialScale(Gradient256(255, 232)...)
+
+// validate validates the color scale. The log argument indicates that the
+// values are mapped onto the scale logarithmically.
+func (cs *ColorScale) validate(log bool) error {
+	if cs == nil {
+		return errors.New("the color scale cannot be nil")
+	}
+	switch cs.kind {
+	case scaleSequential, scaleDiverging:
+		if min := 2; len(cs.colors) < min {
+			return fmt.Errorf("got %d colors, the color scale needs at least %d", len(cs.colors), min)
+		}
+		if math.IsNaN(cs.center) || math.IsInf(cs.center, 0) {
+			return fmt.Errorf("invalid center %v of the diverging color scale", cs.center)
+		}
+		if log && cs.kind == scaleDiverging && cs.center <= 0 {
+			return fmt.Errorf("the center %v of the diverging color scale must be positive on a logarithmic scale", cs.center)
+		}
+
+	case scaleDiscrete:
+		if got, want := len(cs.colors), len(cs.thresholds)+1; got != want {
+			return fmt.Errorf("got %d colors for %d thresholds, the discrete color scale needs %d colors", got, len(cs.thresholds), want)
+		}
+		for i, t := range cs.thresholds {
+			if math.IsNaN(t) {
+				return fmt.Errorf("threshold[%d] cannot be NaN", i)
+			}
+			if i > 0 && t <= cs.thresholds[i-1] {
+				return fmt.Errorf("threshold[%d] %v must be larger than the previous threshold %v", i, t, cs.thresholds[i-1])
+			}
+		}
+	}
+	return nil
+}
+
+// colorAt returns the color at the position on the scale in the range 0-1.
+func (cs *ColorScale) colorAt(pos float64) cell.Color {
+	pos = math.Max(0, math.Min(1, pos))
+	return cs.colors[int(math.Round(pos*float64(len(cs.colors)-1)))]
+}
+
+// bucket returns the index of the bucket of a discrete scale the value falls
+// into.
+func (cs *ColorScale) bucket(value float64) int {
+	for i, t := range cs.thresholds {
+		if value < t {
+			return i
+		}
+	}
+	return len(cs.thresholds)
+}
+
+// Gradient256 returns the colors from the 256 color palette with the numbers
+// from `from` to `to` inclusive, in this order. The numbers are decreasing if
+// `from` is larger than `to`.
+// Refer to https://jonasjacek.github.io/colors/.
+func Gradient256(from, to int) []cell.Color {
+	step := 1
+	if from > to {
+		step = -1
+	}
+	var res []cell.Color
+	for n := from; n != to+step; n += step {
+		res = append(res, cell.ColorNumber(n))
+	}
+	return res
+}
+
+// GradientRGB24 returns n colors that are evenly interpolated between the
+// provided color stops. The colors are created with cell.ColorRGB24, so they
+// are approximated on terminals that don't support 24 bit colors.
+// Returns nil unless n and the number of stops are at least two.
+func GradientRGB24(n int, stops ...color.Color) []cell.Color {
+	if n < 2 || len(stops) < 2 {
+		return nil
+	}
+	var res []cell.Color
+	for i := 0; i < n; i++ {
+		// The position between the stops, the integer part is the index of
+		// the stop on the left and the fraction the distance from it.
+		pos := float64(i) * float64(len(stops)-1) / float64(n-1)
+		left := int(pos)
+		if left == len(stops)-1 {
+			left--
+		}
+		frac := pos - float64(left)
+
+		r1, g1, b1 := rgb8(stops[left])
+		r2, g2, b2 := rgb8(stops[left+1])
+		res = append(res, cell.ColorRGB24(
+			interpolate(r1, r2, frac),
+			interpolate(g1, g2, frac),
+			interpolate(b1, b2, frac),
+		))
+	}
+	return res
+}
+
+// rgb8 returns the 8 bit red, green and blue components of the color.
+func rgb8(c color.Color) (r, g, b int) {
+	r32, g32, b32, _ := c.RGBA()
+	return int(r32 >> 8), int(g32 >> 8), int(b32 >> 8)
+}
+
+// interpolate returns the value at the fraction between from and to.
+func interpolate(from, to int, frac float64) int {
+	return from + int(math.Round(float64(to-from)*frac))
+}
+
+// mapper maps values onto the color scale given the range of the values.
+type mapper struct {
+	cs *ColorScale
+	// log indicates that the values are mapped on a logarithmic scale.
+	log bool
+	// min and max are the smallest and the largest value.
+	// On a logarithmic scale these are the smallest and the largest positive
+	// value.
+	min, max float64
+}
+
+// transform returns the value on the linear or logarithmic scale.
+func (m *mapper) transform(v float64) float64 {
+	if !m.log {
+		return v
+	}
+	return math.Log10(v)
+}
+
+// untransform is the inverse of transform.
+func (m *mapper) untransform(v float64) float64 {
+	if !m.log {
+		return v
+	}
+	return math.Pow(10, v)
+}
+
+// position returns the position of the value on a continuous color scale in
+// the range 0-1.
+func (m *mapper) position(v float64) float64 {
+	if m.log && v <= 0 {
+		return 0
+	}
+	min, max, v := m.transform(m.min), m.transform(m.max), m.transform(v)
+	switch m.cs.kind {
+	case scaleDiverging:
+		center := m.transform(m.cs.center)
+		switch {
+		case v >= center && max > center:
+			return math.Min(1, 0.5+0.5*(v-center)/(max-center))
+		case v < center && center > min:
+			return math.Max(0, 0.5-0.5*(center-v)/(center-min))
+		default:
+			return 0.5
+		}
+
+	default:
+		if max == min {
+			return 0
+		}
+		return (v - min) / (max - min)
+	}
+}
+
+// value is the inverse of position.
+func (m *mapper) value(pos float64) float64 {
+	if m.log && m.max <= 0 {
+		// There are no positive values on the logarithmic scale.
+		return 0
+	}
+	min, max := m.transform(m.min), m.transform(m.max)
+	switch m.cs.kind {
+	case scaleDiverging:
+		center := m.transform(m.cs.center)
+		if pos >= 0.5 {
+			return m.untransform(center + (pos-0.5)*2*(max-center))
+		}
+		return m.untransform(center - (0.5-pos)*2*(center-min))
+
+	default:
+		return m.untransform(min + pos*(max-min))
+	}
+}
+
+// color returns the color of the cell with the value.
+func (m *mapper) color(v float64) cell.Color {
+	if m.cs.kind == scaleDiscrete {
+		return m.cs.colors[m.cs.bucket(v)]
+	}
+	return m.cs.colorAt(m.position(v))
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heatmap
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+)
+
+func TestGradient256(t *testing.T) {
+	tests := []struct {
+		desc     string
+		from, to int
+		want     []cell.Color
+	}{
+		{
+			desc: "increasing numbers",
+			from: 232,
+			to:   234,
+			want: []cell.Color{
+				cell.ColorNumber(232),
+				cell.ColorNumber(233),
+				cell.ColorNumber(234),
+			},
+		},
+		{
+			desc: "decreasing numbers",
+			from: 255,
+			to:   253,
+			want: []cell.Color{
+				cell.ColorNumber(255),
+				cell.ColorNumber(254),
+				cell.ColorNumber(253),
+			},
+		},
+		{
+			desc: "single color",
+			from: 1,
+			to:   1,
+			want: []cell.Color{
+				cell.ColorNumber(1),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := Gradient256(tc.from, tc.to)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Gradient256 => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGradientRGB24(t *testing.T) {
+	black := color.RGBA{0, 0, 0, 255}
+	red := color.RGBA{255, 0, 0, 255}
+	white := color.RGBA{255, 255, 255, 255}
+
+	tests := []struct {
+		desc  string
+		n     int
+		stops []color.Color
+		want  []cell.Color
+	}{
+		{
+			desc:  "nil when n is too small",
+			n:     1,
+			stops: []color.Color{black, white},
+		},
+		{
+			desc:  "nil with a single stop",
+			n:     3,
+			stops: []color.Color{black},
+		},
+		{
+			desc:  "interpolates between two stops",
+			n:     3,
+			stops: []color.Color{black, white},
+			want: []cell.Color{
+				cell.ColorRGB24(0, 0, 0),
+				cell.ColorRGB24(128, 128, 128),
+				cell.ColorRGB24(255, 255, 255),
+			},
+		},
+		{
+			desc:  "interpolates between multiple stops",
+			n:     5,
+			stops: []color.Color{black, red, white},
+			want: []cell.Color{
+				cell.ColorRGB24(0, 0, 0),
+				cell.ColorRGB24(128, 0, 0),
+				cell.ColorRGB24(255, 0, 0),
+				cell.ColorRGB24(255, 128, 128),
+				cell.ColorRGB24(255, 255, 255),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := GradientRGB24(tc.n, tc.stops...)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("GradientRGB24 => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColorScaleValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cs      *ColorScale
+		log     bool
+		wantErr bool
+	}{
+		{
+			desc:    "fails on nil scale",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on sequential scale with a single color",
+			cs:      SequentialScale(cell.ColorRed),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on diverging scale with NaN center",
+			cs:      DivergingScale(math.NaN(), cell.ColorBlue, cell.ColorRed),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on diverging scale with zero center on a logarithmic scale",
+			cs:      DivergingScale(0, cell.ColorBlue, cell.ColorRed),
+			log:     true,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on diverging scale with negative center on a logarithmic scale",
+			cs:      DivergingScale(-1, cell.ColorBlue, cell.ColorRed),
+			log:     true,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on discrete scale with too few colors",
+			cs:      DiscreteScale([]float64{1, 2}, []cell.Color{cell.ColorBlue, cell.ColorRed}),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on discrete scale with thresholds that aren't increasing",
+			cs:      DiscreteScale([]float64{2, 2}, []cell.Color{cell.ColorBlue, cell.ColorGreen, cell.ColorRed}),
+			wantErr: true,
+		},
+		{
+			desc: "valid sequential scale",
+			cs:   SequentialScale(cell.ColorBlue, cell.ColorRed),
+		},
+		{
+			desc: "valid diverging scale",
+			cs:   DivergingScale(0, cell.ColorBlue, cell.ColorWhite, cell.ColorRed),
+		},
+		{
+			desc: "valid diverging scale with positive center on a logarithmic scale",
+			cs:   DivergingScale(10, cell.ColorBlue, cell.ColorWhite, cell.ColorRed),
+			log:  true,
+		},
+		{
+			desc: "valid sequential scale on a logarithmic scale",
+			cs:   SequentialScale(cell.ColorBlue, cell.ColorRed),
+			log:  true,
+		},
+		{
+			desc: "valid discrete scale without thresholds",
+			cs:   DiscreteScale(nil, []cell.Color{cell.ColorBlue}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.cs.validate(tc.log)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validate => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMapper(t *testing.T) {
+	blue, white, red := cell.ColorBlue, cell.ColorWhite, cell.ColorRed
+
+	tests := []struct {
+		desc     string
+		m        *mapper
+		values   []float64
+		want     []cell.Color
+		wantLow  float64 // The value at the start of the scale.
+		wantMid  float64 // The value in the middle of the scale.
+		wantHigh float64 // The value at the end of the scale.
+	}{
+		{
+			desc: "sequential scale",
+			m: &mapper{
+				cs:  SequentialScale(blue, white, red),
+				min: 0,
+				max: 10,
+			},
+			values:   []float64{0, 2, 3, 7, 8, 10},
+			want:     []cell.Color{blue, blue, white, white, red, red},
+			wantLow:  0,
+			wantMid:  5,
+			wantHigh: 10,
+		},
+		{
+			desc: "sequential scale with equal values",
+			m: &mapper{
+				cs:  SequentialScale(blue, red),
+				min: 5,
+				max: 5,
+			},
+			values:   []float64{5},
+			want:     []cell.Color{blue},
+			wantLow:  5,
+			wantMid:  5,
+			wantHigh: 5,
+		},
+		{
+			desc: "logarithmic sequential scale",
+			m: &mapper{
+				cs:  SequentialScale(blue, white, red),
+				log: true,
+				min: 1,
+				max: 10000,
+			},
+			values:   []float64{-1, 0, 1, 100, 10000},
+			want:     []cell.Color{blue, blue, blue, white, red},
+			wantLow:  1,
+			wantMid:  100,
+			wantHigh: 10000,
+		},
+		{
+			desc: "diverging scale spreads the halves around the center",
+			m: &mapper{
+				cs:  DivergingScale(2, blue, white, red),
+				min: 0,
+				max: 10,
+			},
+			values:   []float64{0, 0.9, 2, 5, 6, 10},
+			want:     []cell.Color{blue, blue, white, white, red, red},
+			wantLow:  0,
+			wantMid:  2,
+			wantHigh: 10,
+		},
+		{
+			desc: "discrete scale",
+			m: &mapper{
+				cs:  DiscreteScale([]float64{1, 5}, []cell.Color{blue, white, red}),
+				min: 0,
+				max: 10,
+			},
+			values:   []float64{0, 1, 4.9, 5, 10},
+			want:     []cell.Color{blue, white, white, red, red},
+			wantLow:  0,
+			wantMid:  5,
+			wantHigh: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []cell.Color
+			for _, v := range tc.values {
+				got = append(got, tc.m.color(v))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("color => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			for _, pv := range []struct {
+				pos  float64
+				want float64
+			}{
+				{0, tc.wantLow},
+				{0.5, tc.wantMid},
+				{1, tc.wantHigh},
+			} {
+				if got := tc.m.value(pv.pos); math.Abs(got-pv.want) > 1e-9 {
+					t.Errorf("value(%v) => %v, want %v", pv.pos, got, pv.want)
+				}
+			}
+		})
+	}
+}
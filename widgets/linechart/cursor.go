@@ -0,0 +1,415 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// cursor.go contains code that moves the cursor and displays the values of
+// the series at the cursor.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// Cursor is the position of the cursor and the values of the series at the
+// cursor, see the EnableCursor option.
+type Cursor struct {
+	// X is the position of the cursor on the X axis. This is the index of the
+	// values for series provided by calling Series, the number of seconds
+	// elapsed since the Unix epoch for time series and the X coordinate for
+	// XY series.
+	X float64
+	// Time is the time at the cursor. Only set for time series.
+	Time time.Time
+	// Label is the X label displayed in the tooltip.
+	Label string
+	// Values are the values of the series at the cursor keyed by the labels
+	// of the series. Series toggled off in the legend and series that don't
+	// have a value at the cursor are omitted.
+	Values map[string]float64
+}
+
+// cursorTimeLayout is the layout of the X label in the tooltip for time
+// series, see time.Time.Format.
+const cursorTimeLayout = "2006-01-02 15:04:05"
+
+// cursor returns the current position of the cursor and the values of the
+// displayed series at the cursor.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) cursor() *Cursor {
+	c := &Cursor{
+		X:      float64(lc.cursorX),
+		Values: map[string]float64{},
+	}
+	switch lc.axisKind() {
+	case seriesKindTime:
+		c.Time = axes.ValueToTime(lc.cursorX).In(lc.opts.xTimeLocation)
+		c.Label = c.Time.Format(cursorTimeLayout)
+	case seriesKindXY:
+		c.X = lc.cursorXY()
+		c.Label = lc.continuous.Format(lc.cursorX)
+	default:
+		c.Label = strconv.Itoa(lc.cursorX)
+		if l, ok := lc.xLabels[lc.cursorX]; ok {
+			c.Label = l
+		}
+	}
+
+	for name, sv := range lc.series {
+		if lc.hidden[name] {
+			continue
+		}
+		if v, ok := lc.valueAt(sv, lc.cursorX); ok {
+			c.Values[name] = v
+		}
+	}
+	return c
+}
+
+// cursorXY returns the X coordinate of the point of an XY series at the
+// cursor. The mapping of X coordinates onto the X axis loses precision, so
+// the coordinate is only computed from the cursor if no point is at it.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) cursorXY() float64 {
+	for _, sv := range lc.series {
+		if i, ok := sv.indexAt(lc.cursorX, lc.continuous); ok {
+			return sv.xs[i]
+		}
+	}
+	return lc.continuous.FromValue(lc.cursorX)
+}
+
+// indexAt returns the index of the first value at the position x on the X
+// axis. Returns false if the series doesn't have a value there.
+func (sv *seriesValues) indexAt(x int, cx *axes.ContinuousX) (int, bool) {
+	n := len(sv.values)
+	i := sort.Search(n, func(i int) bool {
+		return sv.xValue(i, cx) >= x
+	})
+	return i, i < n && sv.xValue(i, cx) == x
+}
+
+// valueAt returns the value of the series at the position x on the X axis.
+// Between two values, the value is read off the drawn line or step. Returns
+// false if the series doesn't have a value at the position.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) valueAt(sv *seriesValues, x int) (float64, bool) {
+	i, ok := sv.indexAt(x, lc.continuous)
+	if ok {
+		v := sv.values[i]
+		return v, !math.IsNaN(v)
+	}
+	if i == 0 || i == len(sv.values) || sv.drawMode == DrawModeMarkers {
+		return 0, false
+	}
+
+	prev, next := sv.values[i-1], sv.values[i]
+	if math.IsNaN(prev) || math.IsNaN(next) {
+		return 0, false
+	}
+	if sv.drawMode == DrawModeSteps {
+		return prev, true
+	}
+	return interpolate(sv.xValue(i-1, lc.continuous), prev, sv.xValue(i, lc.continuous), next, x), true
+}
+
+// cursorStops returns the positions on the X axis between min and max the
+// cursor can stop at in an increasing order. These are the positions of the
+// values of the displayed series.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) cursorStops(min, max int) []int {
+	seen := map[int]bool{}
+	var res []int
+	for name, sv := range lc.series {
+		if lc.hidden[name] {
+			continue
+		}
+		for i, v := range sv.values {
+			if math.IsNaN(v) {
+				continue
+			}
+			x := sv.xValue(i, lc.continuous)
+			if x < min || x > max || seen[x] {
+				continue
+			}
+			seen[x] = true
+			res = append(res, x)
+		}
+	}
+	sort.Ints(res)
+	return res
+}
+
+// moveCursor moves the cursor to the position x on the X axis. Returns the
+// callback that must be called after lc.mu is released.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) moveCursor(x int) func() error {
+	if lc.cursorSet && lc.cursorX == x {
+		return nil
+	}
+	lc.cursorSet = true
+	lc.cursorX = x
+	lc.invalidator.Invalidate()
+
+	if fn := lc.opts.onCursor; fn != nil {
+		c := lc.cursor()
+		return func() error { return fn(c) }
+	}
+	return nil
+}
+
+// hideCursor hides the cursor. Returns the callback that must be called after
+// lc.mu is released.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) hideCursor() func() error {
+	if !lc.cursorSet {
+		return nil
+	}
+	lc.cursorSet = false
+	lc.invalidator.Invalidate()
+
+	if fn := lc.opts.onCursor; fn != nil {
+		return func() error { return fn(nil) }
+	}
+	return nil
+}
+
+// cursorKeyboard moves the cursor to the previous or the next value or hides
+// it. Returns the callback that must be called after lc.mu is released.
+// lc.mu must be held and the line chart must be drawn when calling this
+// method.
+func (lc *LineChart) cursorKeyboard(k *terminalapi.Keyboard) func() error {
+	xd := lc.zoom.Zoom()
+	stops := lc.cursorStops(int(xd.Scale.Min.Value), int(xd.Scale.Max.Value))
+	switch k.Shortcut() {
+	case lc.opts.cursorKeyLeft:
+		for i := len(stops) - 1; i >= 0; i-- {
+			if !lc.cursorSet || stops[i] < lc.cursorX {
+				return lc.moveCursor(stops[i])
+			}
+		}
+	case lc.opts.cursorKeyRight:
+		for _, x := range stops {
+			if !lc.cursorSet || x > lc.cursorX {
+				return lc.moveCursor(x)
+			}
+		}
+	case lc.opts.cursorKeyHide:
+		return lc.hideCursor()
+	}
+	return nil
+}
+
+// cursorMouse moves the cursor to the value nearest to the mouse when the
+// mouse hovers over or clicks on the graph. Returns the callback that must be
+// called after lc.mu is released.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) cursorMouse(m *terminalapi.Mouse) (func() error, error) {
+	if !lc.opts.cursor || lc.zoom == nil || !m.Position.In(lc.cvsGraphAr) {
+		return nil, nil
+	}
+	hover := m.Motion && m.Button == mouse.ButtonNone
+	click := !m.Motion && m.Button == mouse.ButtonLeft
+	if !hover && !click {
+		return nil, nil
+	}
+
+	xd := lc.zoom.Zoom()
+	target, err := xd.Scale.PixelToValue((m.Position.X - lc.cvsGraphAr.Min.X) * braille.ColMult)
+	if err != nil {
+		return nil, err
+	}
+
+	stops := lc.cursorStops(int(xd.Scale.Min.Value), int(xd.Scale.Max.Value))
+	if len(stops) == 0 {
+		return nil, nil
+	}
+	nearest := stops[0]
+	for _, x := range stops[1:] {
+		if math.Abs(float64(x)-target) < math.Abs(float64(nearest)-target) {
+			nearest = x
+		}
+	}
+	return lc.moveCursor(nearest), nil
+}
+
+// drawCursor draws the cursor as a vertical line across the graph if it is
+// within the displayed range of the X axis.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) drawCursor(bc *braille.Canvas, xd *axes.XDetails) error {
+	if !lc.cursorVisible(xd) {
+		return nil
+	}
+	x, err := xd.Scale.ValueToPixel(lc.cursorX)
+	if err != nil {
+		return fmt.Errorf("on scale %v, xd.Scale.ValueToPixel(%v) => %v", xd.Scale, lc.cursorX, err)
+	}
+	start := image.Point{x, 0}
+	end := image.Point{x, bc.Area().Max.Y - 1}
+	if err := draw.BrailleLine(bc, start, end, draw.BrailleLineCellOpts(lc.opts.cursorCellOpts...)); err != nil {
+		return fmt.Errorf("draw.BrailleLine => %v", err)
+	}
+	return nil
+}
+
+// cursorVisible asserts whether the cursor is displayed on the X axis.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) cursorVisible(xd *axes.XDetails) bool {
+	if !lc.opts.cursor || !lc.cursorSet {
+		return false
+	}
+	return float64(lc.cursorX) >= xd.Scale.Min.Value && float64(lc.cursorX) <= xd.Scale.Max.Value
+}
+
+// tooltipLine is one line of text in the tooltip.
+type tooltipLine struct {
+	// text is the text of the line.
+	text string
+	// swatch indicates that the text is preceded by a swatch in the color of
+	// the series.
+	swatch bool
+	// cellOpts are the cell options of the series.
+	cellOpts []cell.Option
+}
+
+// width returns the number of cells the line needs.
+func (tl *tooltipLine) width() int {
+	if tl.swatch {
+		return 2 + runewidth.StringWidth(tl.text) // The swatch and a space.
+	}
+	return runewidth.StringWidth(tl.text)
+}
+
+// tooltipLines returns the lines of the tooltip, the X label followed by the
+// values of the series in the order in which the series are drawn.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) tooltipLines() []*tooltipLine {
+	c := lc.cursor()
+	var names []string
+	for name := range c.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := []*tooltipLine{{text: c.Label}}
+	for _, name := range names {
+		res = append(res, &tooltipLine{
+			text:     fmt.Sprintf("%s: %s", name, lc.formatValue(c.Values[name])),
+			swatch:   true,
+			cellOpts: lc.series[name].seriesCellOpts,
+		})
+	}
+	return res
+}
+
+// tooltipPadding is the number of cells between the border of the tooltip and
+// its text on each side.
+const tooltipPadding = 1
+
+// tooltipAr returns the area of the tooltip of the provided size. The tooltip
+// is placed at the top of the graph next to the cell of the cursor, on the
+// right side if it fits there, otherwise on the left side. The tooltip never
+// leaves the canvas area.
+func tooltipAr(cvsAr, graphAr image.Rectangle, cursorCell int, size image.Point) image.Rectangle {
+	if size.X > cvsAr.Dx() {
+		size.X = cvsAr.Dx()
+	}
+	if size.Y > cvsAr.Dy() {
+		size.Y = cvsAr.Dy()
+	}
+
+	x := cursorCell + 1
+	if x+size.X > cvsAr.Max.X {
+		x = cursorCell - size.X
+	}
+	if x < cvsAr.Min.X {
+		x = cvsAr.Max.X - size.X
+	}
+	y := graphAr.Min.Y
+	if y+size.Y > cvsAr.Max.Y {
+		y = cvsAr.Max.Y - size.Y
+	}
+	return image.Rect(x, y, x+size.X, y+size.Y)
+}
+
+// drawTooltip draws the tooltip with the values of the series at the cursor
+// if the cursor is within the displayed range of the X axis.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) drawTooltip(cvs *canvas.Canvas, xd *axes.XDetails) error {
+	if !lc.cursorVisible(xd) {
+		return nil
+	}
+	cellX, err := xd.Scale.ValueToCell(lc.cursorX)
+	if err != nil {
+		return fmt.Errorf("on scale %v, xd.Scale.ValueToCell(%v) => %v", xd.Scale, lc.cursorX, err)
+	}
+
+	lines := lc.tooltipLines()
+	var widest int
+	for _, tl := range lines {
+		if w := tl.width(); w > widest {
+			widest = w
+		}
+	}
+	size := image.Point{widest + 2*tooltipPadding + 2, len(lines) + 2} // Two cells for the border.
+	ar := tooltipAr(cvs.Area(), lc.cvsGraphAr, lc.cvsGraphAr.Min.X+cellX, size)
+	textAr := image.Rect(ar.Min.X+1+tooltipPadding, ar.Min.Y+1, ar.Max.X-1-tooltipPadding, ar.Max.Y-1)
+	if textAr.Dx() <= 0 || textAr.Dy() <= 0 {
+		// The canvas is too small for the tooltip.
+		return nil
+	}
+
+	if err := cvs.SetAreaCells(ar, ' '); err != nil {
+		return err
+	}
+	if err := draw.Border(cvs, ar, draw.BorderCellOpts(lc.opts.cursorCellOpts...)); err != nil {
+		return fmt.Errorf("failed to draw the tooltip: %v", err)
+	}
+
+	for i, tl := range lines {
+		if i >= textAr.Dy() {
+			break
+		}
+		start := image.Point{textAr.Min.X, textAr.Min.Y + i}
+		if tl.swatch {
+			if _, err := cvs.SetCell(start, legendSwatch, tl.cellOpts...); err != nil {
+				return err
+			}
+			start.X += 2
+		}
+		if start.X >= textAr.Max.X {
+			continue
+		}
+		if err := draw.Text(cvs, tl.text, start,
+			draw.TextMaxX(textAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return fmt.Errorf("failed to draw the tooltip: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,689 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille"
+	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// hover draws the line chart on a canvas of the size and moves the mouse
+// over the point without pressing any buttons.
+func hover(lc *LineChart, size image.Point, p image.Point) error {
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return err
+	}
+	if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		return err
+	}
+	return lc.Mouse(&terminalapi.Mouse{Position: p, Button: mouse.ButtonNone, Motion: true}, &widgetapi.EventMeta{})
+}
+
+// press draws the line chart on a canvas of the size and presses the keys.
+func press(lc *LineChart, size image.Point, keys ...keyboard.Key) error {
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return err
+	}
+	if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := lc.Keyboard(&terminalapi.Keyboard{Key: k}, &widgetapi.EventMeta{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cursorChart draws the axes, the labels and the series of the line chart
+// used by TestCursor onto the canvas. The graph spans 68x36 pixels.
+func cursorChart(c *canvas.Canvas) *canvas.Canvas {
+	// Y and X axis.
+	lines := []draw.HVLine{
+		{Start: image.Point{5, 0}, End: image.Point{5, 9}},
+		{Start: image.Point{5, 9}, End: image.Point{39, 9}},
+	}
+	testdraw.MustHVLines(c, lines)
+
+	// Value labels.
+	testdraw.MustText(c, "0", image.Point{4, 8})
+	testdraw.MustText(c, "45.76", image.Point{0, 4})
+	testdraw.MustText(c, "91.52", image.Point{0, 0})
+	testdraw.MustText(c, "0", image.Point{6, 10})
+	testdraw.MustText(c, "1", image.Point{22, 10})
+	testdraw.MustText(c, "2", image.Point{39, 10})
+	return c
+}
+
+func TestCursor(t *testing.T) {
+	size := image.Point{40, 11}
+	writes := func(lc *LineChart) error {
+		if err := lc.Series("first", []float64{0, 100, 0}, SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+			return err
+		}
+		return lc.Series("second", []float64{100, 0})
+	}
+	series := func(bc *braille.Canvas) {
+		testdraw.MustBrailleLine(bc, image.Point{0, 35}, image.Point{33, 0}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)))
+		testdraw.MustBrailleLine(bc, image.Point{33, 0}, image.Point{67, 35}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)))
+		testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{33, 35})
+	}
+
+	tests := []struct {
+		desc    string
+		canvas  image.Rectangle
+		opts    []Option
+		writes  func(*LineChart) error
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "fails when the shortcuts aren't unique",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				CursorShortcuts(
+					keyboard.Shortcut{Key: keyboard.KeyArrowLeft},
+					keyboard.Shortcut{Key: keyboard.KeyArrowRight},
+					keyboard.Shortcut{Key: keyboard.KeyArrowLeft},
+				),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "ignores the mouse without the EnableCursor option",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return hover(lc, size, image.Point{22, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "hovering over the graph displays the cursor and the tooltip",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+				CursorCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return hover(lc, size, image.Point{22, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testdraw.MustBrailleLine(bc, image.Point{33, 0}, image.Point{33, 35}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip.
+				ar := image.Rect(23, 0, 39, 5)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar, draw.BorderCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "1", image.Point{25, 1})
+				testcanvas.MustSetCell(c, image.Point{25, 2}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first: 100", image.Point{27, 2})
+				testcanvas.MustSetCell(c, image.Point{25, 3}, '⣿')
+				testdraw.MustText(c, "second: 0", image.Point{27, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking on the graph moves the cursor",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return click(lc, size, image.Point{21, 6})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testdraw.MustBrailleLine(bc, image.Point{33, 0}, image.Point{33, 35})
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip.
+				ar := image.Rect(23, 0, 39, 5)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar)
+				testdraw.MustText(c, "1", image.Point{25, 1})
+				testcanvas.MustSetCell(c, image.Point{25, 2}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first: 100", image.Point{27, 2})
+				testcanvas.MustSetCell(c, image.Point{25, 3}, '⣿')
+				testdraw.MustText(c, "second: 0", image.Point{27, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "tooltip is placed on the left side of the cursor near the right edge",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return hover(lc, size, image.Point{39, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testdraw.MustBrailleLine(bc, image.Point{67, 0}, image.Point{67, 35})
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip, the series "second" doesn't have a value at the
+				// cursor.
+				ar := image.Rect(25, 0, 39, 4)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar)
+				testdraw.MustText(c, "2", image.Point{27, 1})
+				testcanvas.MustSetCell(c, image.Point{27, 2}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first: 0", image.Point{29, 2})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "keys move the cursor to the next values",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return press(lc, size, keyboard.KeyArrowRight, keyboard.KeyArrowRight)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testdraw.MustBrailleLine(bc, image.Point{33, 0}, image.Point{33, 35})
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip.
+				ar := image.Rect(23, 0, 39, 5)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar)
+				testdraw.MustText(c, "1", image.Point{25, 1})
+				testcanvas.MustSetCell(c, image.Point{25, 2}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first: 100", image.Point{27, 2})
+				testcanvas.MustSetCell(c, image.Point{25, 3}, '⣿')
+				testdraw.MustText(c, "second: 0", image.Point{27, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "keys move the cursor to the previous values",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return press(lc, size, keyboard.KeyArrowLeft, keyboard.KeyArrowLeft, keyboard.KeyArrowLeft, keyboard.KeyArrowLeft)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{0, 35})
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip.
+				ar := image.Rect(7, 0, 24, 5)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar)
+				testdraw.MustText(c, "0", image.Point{9, 1})
+				testcanvas.MustSetCell(c, image.Point{9, 2}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first: 0", image.Point{11, 2})
+				testcanvas.MustSetCell(c, image.Point{9, 3}, '⣿')
+				testdraw.MustText(c, "second: 100", image.Point{11, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "configured key hides the cursor",
+			canvas: image.Rect(0, 0, size.X, size.Y),
+			opts: []Option{
+				EnableCursor(),
+				CursorShortcuts(
+					keyboard.Shortcut{Key: 'h'},
+					keyboard.Shortcut{Key: 'l'},
+					keyboard.Shortcut{Key: 'q'},
+				),
+			},
+			writes: func(lc *LineChart) error {
+				if err := writes(lc); err != nil {
+					return err
+				}
+				return press(lc, size, 'l', 'q')
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := cursorChart(testcanvas.MustNew(ft.Area()))
+
+				bc := testbraille.MustNew(image.Rect(6, 0, 40, 9))
+				series(bc)
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "tooltip is trimmed to fit the canvas",
+			canvas: image.Rect(0, 0, 12, 4),
+			opts: []Option{
+				EnableCursor(),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("series", []float64{0, 1}); err != nil {
+					return err
+				}
+				return press(lc, image.Point{12, 4}, keyboard.KeyArrowRight)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{1, 0}, End: image.Point{1, 2}},
+					{Start: image.Point{1, 2}, End: image.Point{11, 2}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{0, 1})
+				testdraw.MustText(c, "0", image.Point{2, 3})
+				testdraw.MustText(c, "1", image.Point{11, 3})
+
+				// Braille line and the cursor.
+				bc := testbraille.MustNew(image.Rect(2, 0, 12, 2))
+				testdraw.MustBrailleLine(bc, image.Point{0, 7}, image.Point{19, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{0, 7})
+				testbraille.MustCopyTo(bc, c)
+
+				// Tooltip.
+				ar := image.Rect(0, 0, 12, 4)
+				testcanvas.MustSetAreaCells(c, ar, ' ')
+				testdraw.MustBorder(c, ar)
+				testdraw.MustText(c, "0", image.Point{2, 1})
+				testcanvas.MustSetCell(c, image.Point{2, 2}, '⣿')
+				testdraw.MustText(c, "serie…", image.Point{4, 2})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			widget, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.writes != nil {
+				if err := tc.writes(widget); err != nil {
+					t.Fatalf("writes => unexpected error: %v", err)
+				}
+			}
+
+			if err := widget.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			want := faketerm.MustNew(c.Size())
+			if tc.want != nil {
+				want = tc.want(c.Size())
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOnCursor(t *testing.T) {
+	size := image.Point{40, 11}
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		desc   string
+		opts   []Option
+		writes func(*LineChart) error
+		// events are executed after the writes.
+		events func(*LineChart) error
+		want   []*Cursor
+	}{
+		{
+			desc: "reports custom X labels and omits missing values",
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("series", []float64{0, 10}, SeriesXLabels(map[int]string{1: "one"})); err != nil {
+					return err
+				}
+				return lc.Series("longer", []float64{0, math.NaN(), 2})
+			},
+			events: func(lc *LineChart) error {
+				return press(lc, size, keyboard.KeyArrowRight, keyboard.KeyArrowRight)
+			},
+			want: []*Cursor{
+				{
+					X:     0,
+					Label: "0",
+					Values: map[string]float64{
+						"series": 0,
+						"longer": 0,
+					},
+				},
+				{
+					X:     1,
+					Label: "one",
+					Values: map[string]float64{
+						"series": 10,
+					},
+				},
+			},
+		},
+		{
+			desc: "reports values of time series between their points",
+			opts: []Option{
+				XAxisTimeLocation(time.UTC),
+			},
+			writes: func(lc *LineChart) error {
+				hourly := []TimePoint{
+					{Time: start, Value: 0},
+					{Time: start.Add(time.Hour), Value: 60},
+				}
+				if err := lc.TimeSeries("hourly", hourly); err != nil {
+					return err
+				}
+				if err := lc.TimeSeries("steps", hourly, SeriesDrawMode(DrawModeSteps)); err != nil {
+					return err
+				}
+				if err := lc.TimeSeries("markers", hourly, SeriesDrawMode(DrawModeMarkers)); err != nil {
+					return err
+				}
+				return lc.TimeSeries("half", []TimePoint{
+					{Time: start.Add(30 * time.Minute), Value: 5},
+				})
+			},
+			events: func(lc *LineChart) error {
+				return press(lc, size, keyboard.KeyArrowRight, keyboard.KeyArrowRight)
+			},
+			want: []*Cursor{
+				{
+					X:     float64(start.Unix()),
+					Time:  start,
+					Label: "2024-01-02 03:04:05",
+					Values: map[string]float64{
+						"hourly":  0,
+						"steps":   0,
+						"markers": 0,
+					},
+				},
+				{
+					X:     float64(start.Add(30 * time.Minute).Unix()),
+					Time:  start.Add(30 * time.Minute),
+					Label: "2024-01-02 03:34:05",
+					Values: map[string]float64{
+						"hourly": 30,
+						"steps":  0,
+						"half":   5,
+					},
+				},
+			},
+		},
+		{
+			desc: "reports the X coordinates of XY series",
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("xy", []XYPoint{
+					{X: 0.1, Y: 1},
+					{X: 0.3, Y: 3},
+				})
+			},
+			events: func(lc *LineChart) error {
+				return press(lc, size, keyboard.KeyArrowLeft)
+			},
+			want: []*Cursor{
+				{
+					X:     0.3,
+					Label: "0.3",
+					Values: map[string]float64{
+						"xy": 3,
+					},
+				},
+			},
+		},
+		{
+			desc: "doesn't report toggled off series",
+			opts: []Option{
+				Legend(LegendPositionTopLeft),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 1}); err != nil {
+					return err
+				}
+				if err := lc.Series("second", []float64{2, 3}); err != nil {
+					return err
+				}
+				return click(lc, size, image.Point{10, 0})
+			},
+			events: func(lc *LineChart) error {
+				return hover(lc, size, image.Point{39, 8})
+			},
+			want: []*Cursor{
+				{
+					X:     1,
+					Label: "1",
+					Values: map[string]float64{
+						"second": 3,
+					},
+				},
+			},
+		},
+		{
+			desc: "reports nil when the cursor is hidden",
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", []float64{0, 1})
+			},
+			events: func(lc *LineChart) error {
+				return press(lc, size, keyboard.KeyArrowRight, keyboard.KeyArrowRight, keyboard.KeyArrowRight, keyboard.KeyEsc, keyboard.KeyEsc)
+			},
+			want: []*Cursor{
+				{
+					X:     0,
+					Label: "0",
+					Values: map[string]float64{
+						"series": 0,
+					},
+				},
+				{
+					X:     1,
+					Label: "1",
+					Values: map[string]float64{
+						"series": 1,
+					},
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []*Cursor
+			opts := append([]Option{
+				EnableCursor(),
+				OnCursor(func(c *Cursor) error {
+					got = append(got, c)
+					return nil
+				}),
+			}, tc.opts...)
+			lc, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tc.writes(lc); err != nil {
+				t.Fatalf("writes => unexpected error: %v", err)
+			}
+			if err := tc.events(lc); err != nil {
+				t.Fatalf("events => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("OnCursor => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCursorInContainer(t *testing.T) {
+	var got []*Cursor
+	lc, err := New(
+		EnableCursor(),
+		OnCursor(func(c *Cursor) error {
+			got = append(got, c)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lc.Series("series", []float64{0, 100, 0}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	ft, err := faketerm.New(image.Point{40, 11})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	c, err := container.New(ft, container.PlaceWidget(lc))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+	eds := event.NewDistributionSystem()
+	c.Subscribe(eds)
+	// Initial draw to determine the size of the graph.
+	if err := c.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	eds.Event(&terminalapi.Mouse{Position: image.Point{22, 3}, Button: mouse.ButtonNone, Motion: true})
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if got, want := eds.Processed(), 1; got != want {
+			return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("testevent.WaitFor => %v", err)
+	}
+
+	want := []*Cursor{
+		{
+			X:     1,
+			Label: "1",
+			Values: map[string]float64{
+				"series": 100,
+			},
+		},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("OnCursor => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
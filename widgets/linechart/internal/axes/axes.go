@@ -18,6 +18,7 @@ package axes
 import (
 	"fmt"
 	"image"
+	"time"
 
 	"github.com/mum4k/termdash/private/runewidth"
 )
@@ -176,6 +177,18 @@ type XProperties struct {
 	CustomLabels map[int]string
 	// LO is the desired orientation of labels under the X axis.
 	LO LabelOrientation
+	// Time indicates that the values on the X axis are times, see
+	// TimeToValue. The labels are placed at times aligned to a step selected
+	// according to the displayed range and CustomLabels are ignored.
+	Time bool
+	// TimeLocation is the location used to format the labels when Time is
+	// true. Defaults to UTC if nil.
+	TimeLocation *time.Location
+	// Continuous indicates that the values on the X axis are mapped from
+	// continuous values, see ContinuousX. The labels are placed at multiples
+	// of a step selected according to the displayed range and CustomLabels
+	// are ignored. Must not be set when Time is true.
+	Continuous *ContinuousX
 }
 
 // NewXDetails retrieves details about the X axis required to draw it on a canvas
@@ -188,6 +201,12 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 	cvsHeight := cvsAr.Dy()
 	maxHeight := cvsHeight - 1 // Reserve one row for the line chart itself.
 	reqHeight := RequiredHeight(xp.Max, xp.CustomLabels, xp.LO)
+	switch {
+	case xp.Time:
+		reqHeight = RequiredTimeHeight(xp.LO)
+	case xp.Continuous != nil:
+		reqHeight = RequiredContinuousHeight(xp.LO)
+	}
 	if maxHeight < reqHeight {
 		return nil, fmt.Errorf("the available maxHeight %d is smaller than the reported required height %d", maxHeight, reqHeight)
 	}
@@ -205,7 +224,19 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 		xp.ReqYWidth + 1,
 		cvsAr.Dy() - reqHeight - 1,
 	}
-	labels, err := xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	var labels []*Label
+	switch {
+	case xp.Time:
+		loc := xp.TimeLocation
+		if loc == nil {
+			loc = time.UTC
+		}
+		labels, err = timeLabels(scale, graphZero, loc, xp.LO)
+	case xp.Continuous != nil:
+		labels, err = continuousLabels(scale, graphZero, xp.Continuous, xp.LO)
+	default:
+		labels, err = xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -238,3 +269,12 @@ func RequiredHeight(max int, customLabels map[int]string, lo LabelOrientation) i
 	}
 	return longestLabel(labels) + axisWidth
 }
+
+// RequiredTimeHeight calculates the minimum height required in order to draw
+// a time X axis and its labels, see XProperties.Time.
+func RequiredTimeHeight(lo LabelOrientation) int {
+	if lo == LabelOrientationHorizontal {
+		return axisWidth + 1
+	}
+	return maxTimeLabelWidth() + axisWidth
+}
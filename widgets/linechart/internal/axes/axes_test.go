@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 )
@@ -457,6 +458,63 @@ func TestNewXDetails(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "time axis ignores custom labels",
+			xp: &XProperties{
+				Min:          TimeToValue(noon),
+				Max:          TimeToValue(noon.Add(time.Minute)),
+				ReqYWidth:    5,
+				CustomLabels: map[int]string{0: "start"},
+				LO:           LabelOrientationVertical,
+				Time:         true,
+			},
+			cvsAr: image.Rect(0, 0, 20, 12),
+			want: &XDetails{
+				Start: image.Point{5, 3},
+				End:   image.Point{19, 3},
+				Scale: mustNewXScale(TimeToValue(noon), TimeToValue(noon.Add(time.Minute)), 14, nonZeroDecimals),
+				Labels: []*Label{
+					timeLabel(noon, "12:00:00", image.Point{6, 4}),
+					timeLabel(noon.Add(30*time.Second), "12:00:30", image.Point{12, 4}),
+					timeLabel(noon.Add(time.Minute), "12:01:00", image.Point{19, 4}),
+				},
+				Properties: &XProperties{
+					Min:          TimeToValue(noon),
+					Max:          TimeToValue(noon.Add(time.Minute)),
+					ReqYWidth:    5,
+					CustomLabels: map[int]string{0: "start"},
+					LO:           LabelOrientationVertical,
+					Time:         true,
+				},
+			},
+		},
+		{
+			desc: "continuous axis ignores custom labels",
+			xp: &XProperties{
+				Min:          0,
+				Max:          100000,
+				ReqYWidth:    2,
+				CustomLabels: map[int]string{0: "start"},
+				Continuous:   &ContinuousX{Origin: 0, Resolution: 0.0001},
+			},
+			cvsAr: image.Rect(0, 0, 20, 5),
+			want: &XDetails{
+				Start: image.Point{2, 3},
+				End:   image.Point{19, 3},
+				Scale: mustNewXScale(0, 100000, 17, nonZeroDecimals),
+				Labels: []*Label{
+					continuousLabel(0, "0", image.Point{3, 4}),
+					continuousLabel(50000, "5", image.Point{11, 4}),
+				},
+				Properties: &XProperties{
+					Min:          0,
+					Max:          100000,
+					ReqYWidth:    2,
+					CustomLabels: map[int]string{0: "start"},
+					Continuous:   &ContinuousX{Origin: 0, Resolution: 0.0001},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,239 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+// continuous.go contains code that maps continuous values onto the X axis and
+// places their labels.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mum4k/termdash/private/canvas/braille"
+)
+
+// continuousValues is the number of values on the X axis that correspond to
+// one order of magnitude of the continuous values.
+const continuousValues = 1000000
+
+// ContinuousX maps continuous values onto the integer values of the X axis.
+// The value on the axis is round((x - Origin) / Resolution).
+type ContinuousX struct {
+	// Origin is the continuous value represented by value zero on the axis.
+	Origin float64
+	// Resolution is the difference between the continuous values represented
+	// by two adjacent values on the axis.
+	Resolution float64
+}
+
+// NewContinuousX returns a mapping for continuous values in the range
+// min <= x <= max. The range is divided into at least continuousValues / 10
+// values on the axis.
+// The mapping is stable, it only changes when the size of the range changes by
+// an order of magnitude or when the range moves by more than its order of
+// magnitude. This allows the zoom to remain on the same continuous values
+// when the values change.
+func NewContinuousX(min, max float64) *ContinuousX {
+	span := max - min
+	if span <= 0 {
+		span = math.Abs(min) / continuousValues
+	}
+	if span == 0 {
+		span = 1
+	}
+	// The smallest power of ten larger than the span.
+	magnitude := math.Pow(10, math.Floor(math.Log10(span))+1)
+	return &ContinuousX{
+		Origin:     math.Floor(min/magnitude) * magnitude,
+		Resolution: magnitude / continuousValues,
+	}
+}
+
+// String implements fmt.Stringer.
+func (cx *ContinuousX) String() string {
+	return fmt.Sprintf("ContinuousX{Origin:%v, Resolution:%v}", cx.Origin, cx.Resolution)
+}
+
+// ToValue returns the value on the axis that represents the continuous value.
+func (cx *ContinuousX) ToValue(x float64) int {
+	return int(math.Round((x - cx.Origin) / cx.Resolution))
+}
+
+// FromValue is the reverse of ToValue.
+func (cx *ContinuousX) FromValue(v int) float64 {
+	return cx.Origin + float64(v)*cx.Resolution
+}
+
+// maxContinuousLabelWidth is the width of the widest label on a continuous X
+// axis. Longer labels are formatted in the scientific notation.
+const maxContinuousLabelWidth = 10
+
+// Format formats the continuous value represented by the value on the axis
+// for a label. Omits decimal places beyond the resolution.
+func (cx *ContinuousX) Format(v int) string {
+	decimals := int(math.Max(0, math.Ceil(-math.Log10(cx.Resolution))))
+	x := cx.FromValue(v)
+	t := strconv.FormatFloat(x, 'f', decimals, 64)
+	if strings.Contains(t, ".") {
+		t = strings.TrimRight(strings.TrimRight(t, "0"), ".")
+	}
+	if len(t) > maxContinuousLabelWidth {
+		t = fmt.Sprintf("%.2e", x)
+	}
+	return t
+}
+
+// formatContinuous formats a continuous value for a label.
+func formatContinuous(x float64) string {
+	t := strconv.FormatFloat(x, 'f', -1, 64)
+	if len(t) > maxContinuousLabelWidth {
+		t = fmt.Sprintf("%.2e", x)
+	}
+	return t
+}
+
+// continuousStep is a step between two labels on a continuous X axis, the
+// step is mult * 10^exp.
+type continuousStep struct {
+	mult int
+	exp  int
+}
+
+// size returns the size of the step.
+func (cs continuousStep) size() float64 {
+	return math.Pow(10, float64(cs.exp)) * float64(cs.mult)
+}
+
+// tick returns the continuous value of the n-th multiple of the step.
+// Avoids multiplying by fractions which would introduce rounding errors into
+// the labels, e.g. 0.30000000000000004.
+func (cs continuousStep) tick(n int) float64 {
+	if cs.exp >= 0 {
+		return float64(n*cs.mult) * math.Pow(10, float64(cs.exp))
+	}
+	return float64(n*cs.mult) / math.Pow(10, float64(-cs.exp))
+}
+
+// ticks returns the range of multiples of the step between min and max.
+func (cs continuousStep) ticks(min, max float64) (int, int) {
+	size := cs.size()
+	return int(math.Ceil(min / size)), int(math.Floor(max / size))
+}
+
+// labelWidth returns the width of the widest label placed with this step
+// between min and max.
+func (cs continuousStep) labelWidth(min, max float64) int {
+	first, last := cs.ticks(min, max)
+	var widest int
+	for _, n := range []int{first, last} {
+		if w := len(formatContinuous(cs.tick(n))); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// pickContinuousStep returns the smallest step whose labels fit under the X
+// axis without overlapping. The cellSize is the difference between the
+// continuous values of two adjacent cells.
+func pickContinuousStep(cellSize, min, max float64, lo LabelOrientation) continuousStep {
+	const minSpacing = 3
+	exp := int(math.Floor(math.Log10(cellSize)))
+	for e := exp; ; e++ {
+		for _, mult := range []int{1, 2, 5} {
+			cs := continuousStep{mult: mult, exp: e}
+			labelLen := 1
+			if lo == LabelOrientationHorizontal {
+				labelLen = cs.labelWidth(min, max)
+			}
+			if cs.size()/cellSize >= float64(labelLen+minSpacing) {
+				return cs
+			}
+		}
+	}
+}
+
+// continuousLabels returns labels that should be placed under a continuous X
+// axis. The values on the scale are mapped from the continuous values by the
+// provided ContinuousX. The graphZero is the (0, 0) point of the graph area on
+// the canvas.
+// Labels are returned in an increasing value order and placed at multiples of
+// a step that is selected so that the labels fit under the axis.
+func continuousLabels(scale *XScale, graphZero image.Point, cx *ContinuousX, lo LabelOrientation) ([]*Label, error) {
+	min := cx.FromValue(int(scale.Min.Value))
+	max := cx.FromValue(int(scale.Max.Value))
+	if scale.Step.Rounded == 0 {
+		// All the values are the same.
+		text := cx.Format(int(scale.Min.Value))
+		if lo == LabelOrientationHorizontal && len(text) > scale.GraphWidth {
+			return nil, nil
+		}
+		return []*Label{
+			{
+				Value: newLabelValue(int(scale.Min.Value), text),
+				Pos:   image.Point{graphZero.X, graphZero.Y + 2},
+			},
+		}, nil
+	}
+
+	cellSize := scale.Step.Rounded * braille.ColMult * cx.Resolution
+	cs := pickContinuousStep(cellSize, min, max, lo)
+	first, last := cs.ticks(min, max)
+
+	var res []*Label
+	nextFree := 0 // The first cell not occupied by a label.
+	for n := first; n <= last; n++ {
+		x := cs.tick(n)
+		v := cx.ToValue(x)
+		if float64(v) < scale.Min.Value || float64(v) > scale.Max.Value {
+			continue
+		}
+		cellX, err := scale.ValueToCell(v)
+		if err != nil {
+			return nil, err
+		}
+
+		text := formatContinuous(x)
+		labelLen := 1
+		if lo == LabelOrientationHorizontal {
+			labelLen = len(text)
+		}
+		if cellX < nextFree {
+			continue
+		}
+		if cellX+labelLen > scale.GraphWidth {
+			break
+		}
+
+		res = append(res, &Label{
+			Value: newLabelValue(v, text),
+			Pos:   image.Point{graphZero.X + cellX, graphZero.Y + 2}, // First down is the axis, second the label.
+		})
+		nextFree = cellX + labelLen + 1
+	}
+	return res, nil
+}
+
+// RequiredContinuousHeight calculates the minimum height required in order to
+// draw a continuous X axis and its labels, see XProperties.Continuous.
+func RequiredContinuousHeight(lo LabelOrientation) int {
+	if lo == LabelOrientationHorizontal {
+		return axisWidth + 1
+	}
+	return maxContinuousLabelWidth + axisWidth
+}
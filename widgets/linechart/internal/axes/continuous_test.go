@@ -0,0 +1,255 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestNewContinuousX(t *testing.T) {
+	tests := []struct {
+		desc string
+		min  float64
+		max  float64
+		want *ContinuousX
+	}{
+		{
+			desc: "range starting at zero",
+			min:  0,
+			max:  10,
+			want: &ContinuousX{Origin: 0, Resolution: 0.0001},
+		},
+		{
+			desc: "origin is aligned to the magnitude of the range",
+			min:  250,
+			max:  260,
+			want: &ContinuousX{Origin: 200, Resolution: 0.0001},
+		},
+		{
+			desc: "negative values",
+			min:  -5,
+			max:  5,
+			want: &ContinuousX{Origin: -100, Resolution: 0.0001},
+		},
+		{
+			desc: "zero only",
+			min:  0,
+			max:  0,
+			want: &ContinuousX{Origin: 0, Resolution: 0.00001},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewContinuousX(tc.min, tc.max)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("NewContinuousX => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContinuousXValues(t *testing.T) {
+	tests := []struct {
+		desc string
+		min  float64
+		max  float64
+		x    float64
+		want int
+	}{
+		{
+			desc: "minimum maps onto a non-negative value",
+			min:  -5,
+			max:  5,
+			x:    -5,
+			want: 950000,
+		},
+		{
+			desc: "value is rounded to the resolution",
+			min:  0,
+			max:  10,
+			x:    2.00004,
+			want: 20000,
+		},
+		{
+			desc: "single value",
+			min:  123456789,
+			max:  123456789,
+			x:    123456789,
+			want: 789000,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cx := NewContinuousX(tc.min, tc.max)
+			got := cx.ToValue(tc.x)
+			if got != tc.want {
+				t.Errorf("ToValue(%v) => %d, want %d", tc.x, got, tc.want)
+			}
+
+			back := cx.FromValue(got)
+			if diff := back - tc.x; diff > cx.Resolution || diff < -cx.Resolution {
+				t.Errorf("FromValue(%d) => %v, want %v +/- %v", got, back, tc.x, cx.Resolution)
+			}
+		})
+	}
+}
+
+// continuousLabel returns a label for the value on a continuous X axis.
+func continuousLabel(v int, text string, pos image.Point) *Label {
+	return &Label{
+		Value: newLabelValue(v, text),
+		Pos:   pos,
+	}
+}
+
+func TestContinuousLabels(t *testing.T) {
+	tests := []struct {
+		desc             string
+		min              float64
+		max              float64
+		graphWidth       int
+		labelOrientation LabelOrientation
+		want             []*Label
+	}{
+		{
+			desc:       "only one value",
+			min:        3,
+			max:        3,
+			graphWidth: 10,
+			want: []*Label{
+				continuousLabel(0, "3", image.Point{0, 3}),
+			},
+		},
+		{
+			desc:       "only one value, label doesn't fit",
+			min:        123456789,
+			max:        123456789,
+			graphWidth: 8,
+		},
+		{
+			desc:       "labels integers",
+			min:        0,
+			max:        10,
+			graphWidth: 30,
+			want: []*Label{
+				continuousLabel(0, "0", image.Point{0, 3}),
+				continuousLabel(20000, "2", image.Point{6, 3}),
+				continuousLabel(40000, "4", image.Point{12, 3}),
+				continuousLabel(60000, "6", image.Point{17, 3}),
+				continuousLabel(80000, "8", image.Point{23, 3}),
+			},
+		},
+		{
+			desc:             "vertical labels fit at the end of the axis",
+			min:              0,
+			max:              10,
+			graphWidth:       30,
+			labelOrientation: LabelOrientationVertical,
+			want: []*Label{
+				continuousLabel(0, "0", image.Point{0, 3}),
+				continuousLabel(20000, "2", image.Point{6, 3}),
+				continuousLabel(40000, "4", image.Point{12, 3}),
+				continuousLabel(60000, "6", image.Point{17, 3}),
+				continuousLabel(80000, "8", image.Point{23, 3}),
+				continuousLabel(100000, "10", image.Point{29, 3}),
+			},
+		},
+		{
+			desc:       "labels negative values",
+			min:        -5,
+			max:        5,
+			graphWidth: 30,
+			want: []*Label{
+				continuousLabel(960000, "-4", image.Point{3, 3}),
+				continuousLabel(980000, "-2", image.Point{9, 3}),
+				continuousLabel(1000000, "0", image.Point{14, 3}),
+				continuousLabel(1020000, "2", image.Point{20, 3}),
+				continuousLabel(1040000, "4", image.Point{26, 3}),
+			},
+		},
+		{
+			desc:       "labels fractions without rounding errors",
+			min:        0.25,
+			max:        0.75,
+			graphWidth: 40,
+			want: []*Label{
+				continuousLabel(300000, "0.3", image.Point{4, 3}),
+				continuousLabel(400000, "0.4", image.Point{12, 3}),
+				continuousLabel(500000, "0.5", image.Point{19, 3}),
+				continuousLabel(600000, "0.6", image.Point{27, 3}),
+				continuousLabel(700000, "0.7", image.Point{35, 3}),
+			},
+		},
+		{
+			desc:       "labels large values",
+			min:        1e9,
+			max:        3e9,
+			graphWidth: 40,
+			want: []*Label{
+				continuousLabel(100000, "1000000000", image.Point{0, 3}),
+				continuousLabel(200000, "2000000000", image.Point{19, 3}),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cx := NewContinuousX(tc.min, tc.max)
+			scale, err := NewXScale(cx.ToValue(tc.min), cx.ToValue(tc.max), tc.graphWidth, nonZeroDecimals)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			got, err := continuousLabels(scale, image.Point{0, 1}, cx, tc.labelOrientation)
+			if err != nil {
+				t.Fatalf("continuousLabels => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("continuousLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequiredContinuousHeight(t *testing.T) {
+	tests := []struct {
+		desc             string
+		labelOrientation LabelOrientation
+		want             int
+	}{
+		{
+			desc: "horizontal orientation",
+			want: 2,
+		},
+		{
+			desc:             "vertical orientation fits the longest label",
+			labelOrientation: LabelOrientationVertical,
+			want:             11,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := RequiredContinuousHeight(tc.labelOrientation)
+			if got != tc.want {
+				t.Errorf("RequiredContinuousHeight => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
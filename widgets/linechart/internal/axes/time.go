@@ -0,0 +1,249 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+// time.go contains code that places time labels on the X axis.
+
+import (
+	"image"
+	"time"
+
+	"github.com/mum4k/termdash/private/canvas/braille"
+)
+
+// TimeToValue returns the value on a time X axis that represents the time.
+// The values are the number of seconds elapsed since the Unix epoch, which
+// fits the int type on 32-bit platforms. Fractions of a second are truncated.
+func TimeToValue(t time.Time) int {
+	return int(t.Unix())
+}
+
+// ValueToTime is the reverse of TimeToValue.
+func ValueToTime(v int) time.Time {
+	return time.Unix(int64(v), 0)
+}
+
+// Layouts of the time labels, see time.Time.Format.
+const (
+	secondsLayout = "15:04:05"
+	minutesLayout = "15:04"
+	daysLayout    = "Jan 2"
+	monthsLayout  = "Jan 2006"
+	yearsLayout   = "2006"
+)
+
+// timeStep is a step between two labels on a time X axis.
+type timeStep struct {
+	// approx is the duration between the labels. Approximate for steps
+	// measured in days or months since these vary in length.
+	approx time.Duration
+	// days and months are the distance between the labels in days or months.
+	// Only one of them is set and only for steps longer than a day.
+	days, months int
+	// layout is the layout of the labels.
+	layout string
+}
+
+// timeSteps are the supported steps in an increasing order.
+var timeSteps = []*timeStep{
+	{approx: time.Second, layout: secondsLayout},
+	{approx: 2 * time.Second, layout: secondsLayout},
+	{approx: 5 * time.Second, layout: secondsLayout},
+	{approx: 10 * time.Second, layout: secondsLayout},
+	{approx: 15 * time.Second, layout: secondsLayout},
+	{approx: 30 * time.Second, layout: secondsLayout},
+	{approx: time.Minute, layout: minutesLayout},
+	{approx: 2 * time.Minute, layout: minutesLayout},
+	{approx: 5 * time.Minute, layout: minutesLayout},
+	{approx: 10 * time.Minute, layout: minutesLayout},
+	{approx: 15 * time.Minute, layout: minutesLayout},
+	{approx: 30 * time.Minute, layout: minutesLayout},
+	{approx: time.Hour, layout: minutesLayout},
+	{approx: 2 * time.Hour, layout: minutesLayout},
+	{approx: 3 * time.Hour, layout: minutesLayout},
+	{approx: 6 * time.Hour, layout: minutesLayout},
+	{approx: 12 * time.Hour, layout: minutesLayout},
+	{approx: 24 * time.Hour, days: 1, layout: daysLayout},
+	{approx: 2 * 24 * time.Hour, days: 2, layout: daysLayout},
+	{approx: 7 * 24 * time.Hour, days: 7, layout: daysLayout},
+	{approx: 14 * 24 * time.Hour, days: 14, layout: daysLayout},
+	{approx: 30 * 24 * time.Hour, months: 1, layout: monthsLayout},
+	{approx: 3 * 30 * 24 * time.Hour, months: 3, layout: monthsLayout},
+	{approx: 6 * 30 * 24 * time.Hour, months: 6, layout: monthsLayout},
+	{approx: 365 * 24 * time.Hour, months: 12, layout: yearsLayout},
+}
+
+// subDay asserts whether the step is shorter than a day.
+func (ts *timeStep) subDay() bool {
+	return ts.days == 0 && ts.months == 0
+}
+
+// labelWidth returns the width of the widest label formatted for this step.
+// Labels of steps shorter than a day display the date at midnight.
+func (ts *timeStep) labelWidth() int {
+	// The longest day and month names.
+	longest := time.Date(2006, time.December, 31, 23, 59, 59, 0, time.UTC)
+	width := len(longest.Format(ts.layout))
+	if dw := len(longest.Format(daysLayout)); ts.subDay() && dw > width {
+		width = dw
+	}
+	return width
+}
+
+// format formats the time of a label.
+func (ts *timeStep) format(t time.Time) string {
+	if ts.subDay() && t.Equal(midnight(t)) {
+		return t.Format(daysLayout)
+	}
+	return t.Format(ts.layout)
+}
+
+// first returns the time of the first label at or after the time.
+func (ts *timeStep) first(t time.Time) time.Time {
+	switch {
+	case ts.months > 0:
+		y, m, _ := t.Date()
+		f := time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+		for f.Before(t) || int(f.Month()-1)%ts.months != 0 {
+			f = f.AddDate(0, 1, 0)
+		}
+		return f
+
+	case ts.days > 0:
+		f := midnight(t)
+		if f.Before(t) {
+			f = f.AddDate(0, 0, 1)
+		}
+		return f
+
+	default:
+		day := midnight(t)
+		steps := (t.Sub(day) + ts.approx - 1) / ts.approx
+		return day.Add(steps * ts.approx)
+	}
+}
+
+// next returns the time of the label that follows the label at the time.
+func (ts *timeStep) next(t time.Time) time.Time {
+	switch {
+	case ts.months > 0:
+		return t.AddDate(0, ts.months, 0)
+	case ts.days > 0:
+		return t.AddDate(0, 0, ts.days)
+	default:
+		return t.Add(ts.approx)
+	}
+}
+
+// midnight returns the start of the day of the time.
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// maxTimeLabelWidth returns the width of the widest label on a time X axis.
+func maxTimeLabelWidth() int {
+	var widest int
+	for _, ts := range timeSteps {
+		if w := ts.labelWidth(); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// pickTimeStep returns the shortest time step whose labels fit under the X
+// axis without overlapping. Returns the longest step if none fit.
+func pickTimeStep(scale *XScale, lo LabelOrientation) *timeStep {
+	const minSpacing = 3
+	cellSeconds := scale.Step.Rounded * braille.ColMult
+	for _, ts := range timeSteps {
+		labelLen := 1
+		if lo == LabelOrientationHorizontal {
+			labelLen = ts.labelWidth()
+		}
+		cells := float64(ts.approx/time.Second) / cellSeconds
+		if cells >= float64(labelLen+minSpacing) {
+			return ts
+		}
+	}
+	return timeSteps[len(timeSteps)-1]
+}
+
+// newLabelValue returns a new value for a label with the text on an X axis
+// whose values are mapped from times or continuous values.
+func newLabelValue(v int, text string) *Value {
+	return &Value{
+		Value:   float64(v),
+		Rounded: float64(v),
+		text:    text,
+	}
+}
+
+// timeLabels returns labels that should be placed under a time X axis.
+// The values on the scale are the number of seconds since the Unix epoch,
+// see TimeToValue. The graphZero is the (0, 0) point of the graph area on the
+// canvas. The labels are formatted in the provided location.
+// Labels are returned in an increasing value order and placed at times
+// aligned to the step between the labels, which is selected so that the
+// labels fit under the axis.
+func timeLabels(scale *XScale, graphZero image.Point, loc *time.Location, lo LabelOrientation) ([]*Label, error) {
+	min := ValueToTime(int(scale.Min.Value)).In(loc)
+	max := ValueToTime(int(scale.Max.Value)).In(loc)
+
+	if scale.Step.Rounded == 0 {
+		// All the values are at the same time.
+		text := min.Format(secondsLayout)
+		if lo == LabelOrientationHorizontal && len(text) > scale.GraphWidth {
+			return nil, nil
+		}
+		return []*Label{
+			{
+				Value: newLabelValue(int(scale.Min.Value), text),
+				Pos:   image.Point{graphZero.X, graphZero.Y + 2},
+			},
+		}, nil
+	}
+
+	ts := pickTimeStep(scale, lo)
+	var res []*Label
+	nextFree := 0 // The first cell not occupied by a label.
+	for t := ts.first(min); !t.After(max); t = ts.next(t) {
+		v := TimeToValue(t)
+		cellX, err := scale.ValueToCell(v)
+		if err != nil {
+			return nil, err
+		}
+
+		text := ts.format(t)
+		labelLen := 1
+		if lo == LabelOrientationHorizontal {
+			labelLen = len(text)
+		}
+		if cellX < nextFree {
+			continue
+		}
+		if cellX+labelLen > scale.GraphWidth {
+			break
+		}
+
+		res = append(res, &Label{
+			Value: newLabelValue(v, text),
+			Pos:   image.Point{graphZero.X + cellX, graphZero.Y + 2}, // First down is the axis, second the label.
+		})
+		nextFree = cellX + labelLen + 1
+	}
+	return res, nil
+}
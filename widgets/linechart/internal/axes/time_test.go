@@ -0,0 +1,195 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// noon is the time used as the start of the axis in tests.
+var noon = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// timeLabel returns a label for the time on a time X axis.
+func timeLabel(t time.Time, text string, pos image.Point) *Label {
+	return &Label{
+		Value: newLabelValue(TimeToValue(t), text),
+		Pos:   pos,
+	}
+}
+
+func TestTimeToValue(t *testing.T) {
+	want := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	v := TimeToValue(want)
+	if got := ValueToTime(v); !got.Equal(want) {
+		t.Errorf("ValueToTime(TimeToValue(%v)) => %v, want %v", want, got, want)
+	}
+	// The value must fit the int type on 32-bit platforms.
+	if v > math.MaxInt32 {
+		t.Errorf("TimeToValue(%v) => %d, want at most %d", want, v, math.MaxInt32)
+	}
+
+	truncated := want.Add(500 * time.Millisecond)
+	if got := ValueToTime(TimeToValue(truncated)); !got.Equal(want) {
+		t.Errorf("ValueToTime(TimeToValue(%v)) => %v, want %v", truncated, got, want)
+	}
+}
+
+func TestTimeLabels(t *testing.T) {
+	tests := []struct {
+		desc             string
+		min              time.Time
+		max              time.Time
+		graphWidth       int
+		loc              *time.Location
+		labelOrientation LabelOrientation
+		want             []*Label
+	}{
+		{
+			desc:       "only one time",
+			min:        noon,
+			max:        noon,
+			graphWidth: 10,
+			want: []*Label{
+				timeLabel(noon, "12:00:00", image.Point{0, 3}),
+			},
+		},
+		{
+			desc:       "only one time, label doesn't fit",
+			min:        noon,
+			max:        noon,
+			graphWidth: 7,
+		},
+		{
+			desc:       "labels seconds",
+			min:        noon,
+			max:        noon.Add(time.Minute),
+			graphWidth: 30,
+			want: []*Label{
+				timeLabel(noon, "12:00:00", image.Point{0, 3}),
+				timeLabel(noon.Add(30*time.Second), "12:00:30", image.Point{14, 3}),
+			},
+		},
+		{
+			desc:             "labels seconds, vertical labels are denser",
+			min:              noon,
+			max:              noon.Add(time.Minute),
+			graphWidth:       30,
+			labelOrientation: LabelOrientationVertical,
+			want: []*Label{
+				timeLabel(noon, "12:00:00", image.Point{0, 3}),
+				timeLabel(noon.Add(10*time.Second), "12:00:10", image.Point{5, 3}),
+				timeLabel(noon.Add(20*time.Second), "12:00:20", image.Point{10, 3}),
+				timeLabel(noon.Add(30*time.Second), "12:00:30", image.Point{14, 3}),
+				timeLabel(noon.Add(40*time.Second), "12:00:40", image.Point{19, 3}),
+				timeLabel(noon.Add(50*time.Second), "12:00:50", image.Point{24, 3}),
+				timeLabel(noon.Add(60*time.Second), "12:01:00", image.Point{29, 3}),
+			},
+		},
+		{
+			desc:       "labels minutes, displays the date at midnight",
+			min:        noon.Add(11 * time.Hour),
+			max:        noon.Add(13 * time.Hour),
+			graphWidth: 40,
+			want: []*Label{
+				timeLabel(noon.Add(11*time.Hour), "23:00", image.Point{0, 3}),
+				timeLabel(noon.Add(11*time.Hour+30*time.Minute), "23:30", image.Point{10, 3}),
+				timeLabel(noon.Add(12*time.Hour), "Jan 2", image.Point{19, 3}),
+				timeLabel(noon.Add(12*time.Hour+30*time.Minute), "00:30", image.Point{29, 3}),
+			},
+		},
+		{
+			desc:       "labels days at midnight",
+			min:        noon,
+			max:        noon.AddDate(0, 0, 10),
+			graphWidth: 40,
+			want: []*Label{
+				timeLabel(noon.Add(12*time.Hour), "Jan 2", image.Point{2, 3}),
+				timeLabel(noon.Add(12*time.Hour).AddDate(0, 0, 7), "Jan 9", image.Point{29, 3}),
+			},
+		},
+		{
+			desc:       "labels months",
+			min:        noon,
+			max:        noon.AddDate(1, 0, 0),
+			graphWidth: 40,
+			want: []*Label{
+				timeLabel(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), "Jul 2024", image.Point{19, 3}),
+			},
+		},
+		{
+			desc:       "labels times in the location",
+			min:        noon,
+			max:        noon.Add(time.Hour),
+			graphWidth: 30,
+			loc:        time.FixedZone("UTC+0:30", 30*60),
+			want: []*Label{
+				timeLabel(noon, "12:30", image.Point{0, 3}),
+				timeLabel(noon.Add(30*time.Minute), "13:00", image.Point{14, 3}),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := NewXScale(TimeToValue(tc.min), TimeToValue(tc.max), tc.graphWidth, nonZeroDecimals)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			loc := tc.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			got, err := timeLabels(scale, image.Point{0, 1}, loc, tc.labelOrientation)
+			if err != nil {
+				t.Fatalf("timeLabels => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("timeLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequiredTimeHeight(t *testing.T) {
+	tests := []struct {
+		desc             string
+		labelOrientation LabelOrientation
+		want             int
+	}{
+		{
+			desc: "horizontal orientation",
+			want: 2,
+		},
+		{
+			desc:             "vertical orientation fits the longest label",
+			labelOrientation: LabelOrientationVertical,
+			want:             9,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := RequiredTimeHeight(tc.labelOrientation)
+			if got != tc.want {
+				t.Errorf("RequiredTimeHeight => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"reflect"
 
 	"github.com/mum4k/termdash/mouse"
-	"github.com/mum4k/termdash/private/button"
+	"github.com/mum4k/termdash/mouse/gesture"
 	"github.com/mum4k/termdash/private/numbers"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
@@ -94,8 +94,9 @@ type Tracker struct {
 	// itself. I.e. an area between the axis and the borders of cvsAr.
 	graphAr image.Rectangle
 
-	// fsm is the state machine tracking the state of mouse left button.
-	fsm *button.FSM
+	// gestures tracks the state of mouse left button and recognizes double
+	// clicks.
+	gestures *gesture.Recognizer
 
 	// highlight is the currently highlighted area.
 	highlight *Range
@@ -114,7 +115,7 @@ func New(baseX *axes.XDetails, cvsAr, graphAr image.Rectangle, opts ...Option) (
 	}
 
 	t := &Tracker{
-		fsm:       button.NewFSM(mouse.ButtonLeft, graphAr),
+		gestures:  gesture.New(mouse.ButtonLeft, graphAr),
 		highlight: &Range{},
 		opts:      o,
 	}
@@ -136,7 +137,7 @@ func (t *Tracker) Update(baseX *axes.XDetails, cvsAr, graphAr image.Rectangle) e
 	ac, sc := t.axisChanged(baseX), t.sizeChanged(cvsAr, graphAr)
 	if sc {
 		t.highlight.reset()
-		t.fsm.UpdateArea(graphAr)
+		t.gestures.UpdateArea(graphAr)
 	}
 	if ac || sc {
 		if t.zoomX != nil {
@@ -202,19 +203,24 @@ func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 		}
 	}
 
-	clicked, bs := t.fsm.Event(m)
+	g := t.gestures.Event(m)
 	switch {
-	case bs == button.Down:
+	case t.gestures.Pressed():
 		cellX := m.Position.X - t.graphAr.Min.X
 		t.highlight.addX(cellX)
 
-	case clicked && bs == button.Up:
-		if t.highlight.length() >= 2 {
+	case g != gesture.None:
+		switch {
+		case t.highlight.length() >= 2:
 			zoom, err := zoomToHighlight(t.baseForZoom(), t.highlight, t.cvsAr)
 			if err != nil {
 				return err
 			}
 			t.zoomX = zoom
+
+		case g == gesture.DoubleClick:
+			// Double click resets the zoom.
+			t.zoomX = nil
 		}
 		t.highlight.reset()
 
@@ -224,6 +230,83 @@ func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 	return nil
 }
 
+// ZoomIn zooms in on the middle of the graph by the step set with the
+// ScrollStep option, the same way as scrolling up with the mouse.
+func (t *Tracker) ZoomIn() error {
+	return t.zoomMiddle(1)
+}
+
+// ZoomOut zooms out from the middle of the graph by the step set with the
+// ScrollStep option, the same way as scrolling down with the mouse.
+func (t *Tracker) ZoomOut() error {
+	return t.zoomMiddle(-1)
+}
+
+// zoomMiddle zooms in or out depending on the direction, keeping the value in
+// the middle of the graph in place.
+func (t *Tracker) zoomMiddle(direction int) error {
+	zoom, err := zoomAround(direction, t.graphAr.Dx()/2, t.cvsAr, t.baseForZoom(), t.baseX, t.opts)
+	if err != nil {
+		return err
+	}
+	t.zoomX = zoom
+	return nil
+}
+
+// PanLeft moves the zoomed X axis towards smaller values by the percentage
+// of its size set with the ScrollStep option. Doesn't move beyond the start
+// of the base X axis and does nothing if zoom isn't applied.
+func (t *Tracker) PanLeft() error {
+	return t.pan(-1)
+}
+
+// PanRight moves the zoomed X axis towards larger values by the percentage
+// of its size set with the ScrollStep option. Doesn't move beyond the end of
+// the base X axis and does nothing if zoom isn't applied.
+func (t *Tracker) PanRight() error {
+	return t.pan(1)
+}
+
+// pan moves the zoomed X axis left or right depending on the direction.
+func (t *Tracker) pan(direction int) error {
+	if t.zoomX == nil {
+		return nil
+	}
+
+	min := int(t.zoomX.Scale.Min.Value)
+	max := int(t.zoomX.Scale.Max.Value)
+	baseMin := int(t.baseX.Scale.Min.Value)
+	baseMax := int(t.baseX.Scale.Max.Value)
+	size := max - min
+	_, step := numbers.MinMaxInts([]int{
+		1,
+		size * t.opts.scrollStepPerc / 100,
+	})
+
+	newMin := min + direction*step
+	switch {
+	case newMin < baseMin:
+		newMin = baseMin
+	case newMin+size > baseMax:
+		newMin = baseMax - size
+	}
+	if newMin == min {
+		return nil
+	}
+
+	zoom, err := newZoomedFromBase(newMin, newMin+size, t.baseX, t.cvsAr)
+	if err != nil {
+		return err
+	}
+	t.zoomX = zoom
+	return nil
+}
+
+// Reset resets the zoom, the same way as double clicking with the mouse.
+func (t *Tracker) Reset() {
+	t.zoomX = nil
+}
+
 // Range represents a range of values.
 // The range includes all values x such that Start <= x < End.
 type Range struct {
@@ -501,19 +584,28 @@ func hasMinMax(min, max int, base *axes.XDetails) bool {
 // direction of the scroll. Doesn't zoom out above the base X axis view.
 // Can return nil, which indicates that we are at 0% zoom (fully unzoomed).
 func zoomToScroll(m *terminalapi.Mouse, cvsAr, graphAr image.Rectangle, curr, base *axes.XDetails, opts *options) (*axes.XDetails, error) {
-	var direction int         // Positive on zoom in, negative on zoom out.
-	var limits *axes.XDetails // Limit values for the zooming operation.
+	var direction int // Positive on zoom in, negative on zoom out.
 	switch m.Button {
 	case mouse.ButtonWheelUp:
 		direction = 1
-		limits = curr
 
 	case mouse.ButtonWheelDown:
 		direction = -1
+	}
+	return zoomAround(direction, m.Position.X-graphAr.Min.X, cvsAr, curr, base, opts)
+}
+
+// zoomAround zooms or unzooms the current X axis in or out depending on the
+// direction, which is positive on zoom in and negative on zoom out. The value
+// at the cell cellX of the graph remains at the same relative position.
+// Doesn't zoom out above the base X axis view.
+// Can return nil, which indicates that we are at 0% zoom (fully unzoomed).
+func zoomAround(direction, cellX int, cvsAr image.Rectangle, curr, base *axes.XDetails, opts *options) (*axes.XDetails, error) {
+	limits := curr // Limit values for the zooming operation.
+	if direction < 0 {
 		limits = base
 	}
 
-	cellX := m.Position.X - graphAr.Min.X
 	tgtVal, err := curr.Scale.CellLabel(cellX)
 	if err != nil {
 		return nil, fmt.Errorf("unable to determine value at the point where scrolling occurred: %v", err)
@@ -539,7 +631,7 @@ func zoomToScroll(m *terminalapi.Mouse, cvsAr, graphAr image.Rectangle, curr, ba
 	newMax := currMax - (direction * splitStep.Y)
 
 	min, max := normalize(limits.Scale.Min, limits.Scale.Max, newMin, newMax, nil)
-	if m.Button == mouse.ButtonWheelDown && hasMinMax(min, max, limits) {
+	if direction < 0 && hasMinMax(min, max, limits) {
 		// Fully unzoom.
 		return nil, nil
 	}
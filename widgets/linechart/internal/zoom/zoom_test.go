@@ -873,6 +873,76 @@ func TestTracker(t *testing.T) {
 				},
 			),
 		},
+		{
+			desc: "double click resets the zoom",
+			opts: []Option{
+				ScrollStep(30),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				for _, m := range []*terminalapi.Mouse{
+					{Position: image.Point{5, 0}, Button: mouse.ButtonWheelUp},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+				} {
+					if err := tr.Mouse(m); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "single click doesn't reset the zoom",
+			opts: []Option{
+				ScrollStep(30),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				for _, m := range []*terminalapi.Mouse{
+					{Position: image.Point{5, 0}, Button: mouse.ButtonWheelUp},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+					{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+				} {
+					if err := tr.Mouse(m); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       1,
+					Max:       4,
+					ReqYWidth: 2,
+				},
+			),
+		},
 		{
 			desc: "multiple scroll ups maximize zoom",
 			opts: []Option{
@@ -986,6 +1056,268 @@ func TestTracker(t *testing.T) {
 				},
 			),
 		},
+		{
+			desc: "ZoomIn zooms in on the middle of the graph",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				return tr.ZoomIn()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       11,
+					Max:       91,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "ZoomOut zooms out from the middle of the graph",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				return tr.ZoomOut()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       8,
+					Max:       87,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "ZoomOut doesn't zoom out above the base axis",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				if err := tr.ZoomOut(); err != nil {
+					return err
+				}
+				if err := tr.ZoomOut(); err != nil {
+					return err
+				}
+				return tr.ZoomOut()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       100,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "PanRight moves the zoomed axis right",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				return tr.PanRight()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       32,
+					Max:       92,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "PanRight stops at the end of the base axis",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				return tr.PanRight()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       20,
+					Max:       100,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "PanLeft moves the zoomed axis left",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				return tr.PanLeft()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       8,
+					Max:       68,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "PanLeft stops at the start of the base axis",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				return tr.PanLeft()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       80,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "PanLeft does nothing without zoom",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				return tr.PanLeft()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       100,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "Reset resets the zoom",
+			opts: []Option{
+				ScrollStep(20),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       100,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 30, 8),
+			graphAr: image.Rect(2, 0, 30, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.ZoomIn(); err != nil {
+					return err
+				}
+				tr.Reset()
+				return tr.PanRight()
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 30, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       100,
+					ReqYWidth: 2,
+				},
+			),
+		},
 		{
 			desc: "zoom normalized when axis changed (new values)",
 			xp: &axes.XProperties{
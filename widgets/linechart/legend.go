@@ -0,0 +1,354 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// legend.go contains code that places and draws the legend of the line chart.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/button"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// LegendPosition is the position of the legend, see the Legend option.
+type LegendPosition int
+
+// String implements fmt.Stringer()
+func (lp LegendPosition) String() string {
+	if n, ok := legendPositionNames[lp]; ok {
+		return n
+	}
+	return "LegendPositionUnknown"
+}
+
+// legendPositionNames maps LegendPosition values to human readable names.
+var legendPositionNames = map[LegendPosition]string{
+	LegendPositionTop:         "LegendPositionTop",
+	LegendPositionBottom:      "LegendPositionBottom",
+	LegendPositionRight:       "LegendPositionRight",
+	LegendPositionTopLeft:     "LegendPositionTopLeft",
+	LegendPositionTopRight:    "LegendPositionTopRight",
+	LegendPositionBottomLeft:  "LegendPositionBottomLeft",
+	LegendPositionBottomRight: "LegendPositionBottomRight",
+}
+
+const (
+	// LegendPositionTop places the legend above the line chart. The entries
+	// flow from left to right and wrap onto the next line.
+	LegendPositionTop LegendPosition = iota
+	// LegendPositionBottom places the legend below the X axis. The entries
+	// flow from left to right and wrap onto the next line.
+	LegendPositionBottom
+	// LegendPositionRight places the legend on the right side of the line
+	// chart, one entry per line.
+	LegendPositionRight
+	// LegendPositionTopLeft overlays the legend over the top left corner of
+	// the graph, one entry per line.
+	LegendPositionTopLeft
+	// LegendPositionTopRight overlays the legend over the top right corner
+	// of the graph, one entry per line.
+	LegendPositionTopRight
+	// LegendPositionBottomLeft overlays the legend over the bottom left
+	// corner of the graph, one entry per line.
+	LegendPositionBottomLeft
+	// LegendPositionBottomRight overlays the legend over the bottom right
+	// corner of the graph, one entry per line.
+	LegendPositionBottomRight
+)
+
+// overlay asserts whether the legend is drawn over the graph.
+func (lp LegendPosition) overlay() bool {
+	return lp >= LegendPositionTopLeft
+}
+
+const (
+	// legendSwatch is the rune that displays the color of the series.
+	legendSwatch = '⣿'
+	// legendEntryGap is the number of cells between two entries on one line.
+	legendEntryGap = 2
+)
+
+// legendEntry is one entry in the legend.
+type legendEntry struct {
+	// label is the label of the series.
+	label string
+	// text is the text displayed after the swatch.
+	text string
+	// cellOpts are the cell options of the series.
+	cellOpts []cell.Option
+	// hidden indicates that the series is toggled off.
+	hidden bool
+	// area is the area the entry occupies on the canvas. Empty if the entry
+	// didn't fit.
+	area image.Rectangle
+}
+
+// width returns the number of cells the entry needs.
+func (le *legendEntry) width() int {
+	return 2 + runewidth.StringWidth(le.text) // The swatch and a space.
+}
+
+// legendEntries returns the entries of the legend, one for each series in
+// the order in which the series are drawn.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) legendEntries() []*legendEntry {
+	var names []string
+	for name := range lc.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var res []*legendEntry
+	for _, name := range names {
+		sv := lc.series[name]
+		res = append(res, &legendEntry{
+			label:    name,
+			text:     lc.legendText(name, sv),
+			cellOpts: sv.seriesCellOpts,
+			hidden:   lc.hidden[name],
+		})
+	}
+	return res
+}
+
+// legendText returns the text of the legend entry for the series.
+func (lc *LineChart) legendText(label string, sv *seriesValues) string {
+	if !lc.opts.legendValues {
+		return label
+	}
+	for i := len(sv.values) - 1; i >= 0; i-- {
+		v := sv.values[i]
+		if math.IsNaN(v) {
+			continue
+		}
+		return fmt.Sprintf("%s: %s", label, lc.formatValue(v))
+	}
+	return label
+}
+
+// widestEntry returns the width of the widest entry.
+func widestEntry(entries []*legendEntry) int {
+	var widest int
+	for _, le := range entries {
+		if w := le.width(); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// flowEntries places the entries onto lines of the provided width, left to
+// right, starting at image.Point{0, 0}. Returns the number of lines needed.
+// Entries wider than the width are trimmed.
+func flowEntries(entries []*legendEntry, width int) int {
+	if len(entries) == 0 || width <= 0 {
+		return 0
+	}
+
+	lines := 1
+	x := 0
+	for _, le := range entries {
+		w := le.width()
+		if x > 0 && x+w > width {
+			lines++
+			x = 0
+		}
+		if w > width {
+			w = width
+		}
+		le.area = image.Rect(x, lines-1, x+w, lines)
+		x += w + legendEntryGap
+	}
+	return lines
+}
+
+// stackEntries places the entries one per line into the area. Entries that
+// don't fit are left without an area, wide entries are trimmed.
+func stackEntries(entries []*legendEntry, ar image.Rectangle) {
+	for i, le := range entries {
+		le.area = image.Rectangle{}
+		if i >= ar.Dy() {
+			continue
+		}
+		w := le.width()
+		if w > ar.Dx() {
+			w = ar.Dx()
+		}
+		le.area = image.Rect(ar.Min.X, ar.Min.Y+i, ar.Min.X+w, ar.Min.Y+i+1)
+	}
+}
+
+// splitLegend splits the canvas area between the line chart and the legend
+// if the legend is placed outside of the line chart. Places the entries into
+// the legend area. Returns the area for the line chart.
+// The legend only takes the space not required by the line chart, entries
+// that don't fit aren't placed.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) splitLegend(cvsAr image.Rectangle, entries []*legendEntry) image.Rectangle {
+	if !lc.opts.legend || lc.opts.legendPosition.overlay() || len(entries) == 0 {
+		return cvsAr
+	}
+
+	chartMin := lc.chartMinSize()
+	switch lc.opts.legendPosition {
+	case LegendPositionRight:
+		width := widestEntry(entries)
+		if max := cvsAr.Dx() - chartMin.X - 1; width > max { // One cell gap.
+			width = max
+		}
+		if width <= 0 {
+			return cvsAr
+		}
+		stackEntries(entries, image.Rect(cvsAr.Max.X-width, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Max.Y))
+		return image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X-width-1, cvsAr.Max.Y)
+
+	default:
+		lines := flowEntries(entries, cvsAr.Dx())
+		if max := cvsAr.Dy() - chartMin.Y; lines > max {
+			lines = max
+		}
+		if lines <= 0 {
+			return cvsAr
+		}
+
+		offset := image.Point{cvsAr.Min.X, cvsAr.Min.Y}
+		chartAr := image.Rect(cvsAr.Min.X, cvsAr.Min.Y+lines, cvsAr.Max.X, cvsAr.Max.Y)
+		if lc.opts.legendPosition == LegendPositionBottom {
+			offset = image.Point{cvsAr.Min.X, cvsAr.Max.Y - lines}
+			chartAr = image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Max.Y-lines)
+		}
+		for _, le := range entries {
+			if le.area.Min.Y >= lines {
+				le.area = image.Rectangle{}
+				continue
+			}
+			le.area = le.area.Add(offset)
+		}
+		return chartAr
+	}
+}
+
+// overlayLegend places the entries of a legend overlaid over the graph into
+// the corner of the graph area.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) overlayLegend(graphAr image.Rectangle, entries []*legendEntry) {
+	width := widestEntry(entries)
+	if width > graphAr.Dx() {
+		width = graphAr.Dx()
+	}
+	height := len(entries)
+	if height > graphAr.Dy() {
+		height = graphAr.Dy()
+	}
+
+	min := graphAr.Min
+	switch lc.opts.legendPosition {
+	case LegendPositionTopRight:
+		min.X = graphAr.Max.X - width
+	case LegendPositionBottomLeft:
+		min.Y = graphAr.Max.Y - height
+	case LegendPositionBottomRight:
+		min = image.Point{graphAr.Max.X - width, graphAr.Max.Y - height}
+	}
+	stackEntries(entries, image.Rect(min.X, min.Y, min.X+width, min.Y+height))
+}
+
+// drawLegend draws the entries that were placed onto the canvas.
+func (lc *LineChart) drawLegend(cvs *canvas.Canvas, entries []*legendEntry) error {
+	for _, le := range entries {
+		if le.area.Empty() {
+			continue
+		}
+		if lc.opts.legendPosition.overlay() {
+			// Clear the graph under the entry.
+			if err := cvs.SetAreaCells(le.area, ' '); err != nil {
+				return err
+			}
+		}
+
+		swatchOpts := le.cellOpts
+		textOpts := lc.opts.legendCellOpts
+		if le.hidden {
+			swatchOpts = []cell.Option{cell.Dim()}
+			textOpts = append(append([]cell.Option{}, textOpts...), cell.Dim())
+		}
+		if _, err := cvs.SetCell(le.area.Min, legendSwatch, swatchOpts...); err != nil {
+			return err
+		}
+
+		textStart := le.area.Min.Add(image.Point{2, 0})
+		if textStart.X >= le.area.Max.X {
+			continue
+		}
+		if err := draw.Text(cvs, le.text, textStart,
+			draw.TextMaxX(le.area.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(textOpts...),
+		); err != nil {
+			return fmt.Errorf("failed to draw the legend: %v", err)
+		}
+	}
+	return nil
+}
+
+// updateLegendFSMs updates the state machines that track clicks on the
+// entries of the legend with the areas of the entries.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) updateLegendFSMs(entries []*legendEntry) {
+	lc.legendAreas = map[string]image.Rectangle{}
+	for _, le := range entries {
+		lc.legendAreas[le.label] = le.area
+		if fsm, ok := lc.legendFSMs[le.label]; ok {
+			fsm.UpdateArea(le.area)
+			continue
+		}
+		lc.legendFSMs[le.label] = button.NewFSM(mouse.ButtonLeft, le.area)
+	}
+	for label, fsm := range lc.legendFSMs {
+		if _, ok := lc.legendAreas[label]; !ok {
+			fsm.UpdateArea(image.Rectangle{})
+		}
+	}
+}
+
+// legendMouse forwards the mouse event to the entries of the legend and
+// toggles the series whose entry was clicked.
+// Returns true if the event falls onto an entry of the legend.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) legendMouse(m *terminalapi.Mouse) bool {
+	for label, fsm := range lc.legendFSMs {
+		if clicked, _ := fsm.Event(m); clicked {
+			lc.hidden[label] = !lc.hidden[label]
+			lc.yMin, lc.yMax = lc.yMinMax()
+			lc.invalidator.Invalidate()
+		}
+	}
+
+	for _, ar := range lc.legendAreas {
+		if m.Position.In(ar) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,455 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// click draws the line chart on a canvas of the size so that the legend is
+// placed and clicks with the left mouse button at the point.
+func click(lc *LineChart, size image.Point, p image.Point) error {
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return err
+	}
+	if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		return err
+	}
+	for _, b := range []mouse.Button{mouse.ButtonLeft, mouse.ButtonRelease} {
+		if err := lc.Mouse(&terminalapi.Mouse{Position: p, Button: b}, &widgetapi.EventMeta{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestLegend(t *testing.T) {
+	tests := []struct {
+		desc    string
+		canvas  image.Rectangle
+		opts    []Option
+		writes  func(*LineChart) error
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "fails with unsupported position",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				Legend(LegendPosition(-1)),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws legend on top of the chart",
+			canvas: image.Rect(0, 0, 30, 11),
+			opts: []Option{
+				Legend(LegendPositionTop),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}, SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Legend.
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '⣿', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "first", image.Point{2, 0})
+				testcanvas.MustSetCell(c, image.Point{9, 0}, '⣿')
+				testdraw.MustText(c, "second", image.Point{11, 0})
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 1}, End: image.Point{5, 9}},
+					{Start: image.Point{5, 9}, End: image.Point{29, 9}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 8})
+				testdraw.MustText(c, "51.68", image.Point{0, 4})
+				testdraw.MustText(c, "0", image.Point{6, 10})
+				testdraw.MustText(c, "1", image.Point{28, 10})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 1, 30, 9)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{45, 0}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{45, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws legend at the bottom, entries wrap onto the next line",
+			canvas: image.Rect(0, 0, 15, 12),
+			opts: []Option{
+				Legend(LegendPositionBottom),
+				LegendCellOpts(cell.FgColor(cell.ColorGreen)),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{100, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{14, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{14, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 15, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{17, 0})
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{17, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				// Legend.
+				testcanvas.MustSetCell(c, image.Point{0, 10}, '⣿')
+				testdraw.MustText(c, "first", image.Point{2, 10}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+				testcanvas.MustSetCell(c, image.Point{0, 11}, '⣿')
+				testdraw.MustText(c, "second", image.Point{2, 11}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "omits entries that don't fit",
+			canvas: image.Rect(0, 0, 10, 5),
+			opts: []Option{
+				Legend(LegendPositionTop),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Legend.
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '⣿')
+				testdraw.MustText(c, "first", image.Point{2, 0})
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 1}, End: image.Point{5, 3}},
+					{Start: image.Point{5, 3}, End: image.Point{9, 3}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 2})
+				testdraw.MustText(c, "57.16", image.Point{0, 1})
+				testdraw.MustText(c, "0", image.Point{6, 4})
+
+				// Braille line.
+				graphAr := image.Rect(6, 1, 10, 3)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 7}, image.Point{7, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws legend on the right with the latest values",
+			canvas: image.Rect(0, 0, 30, 10),
+			opts: []Option{
+				Legend(LegendPositionRight),
+				LegendValues(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100, math.NaN()})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{16, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{11, 9})
+				testdraw.MustText(c, "2", image.Point{16, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 17, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{10, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				// Legend.
+				testcanvas.MustSetCell(c, image.Point{18, 0}, '⣿')
+				testdraw.MustText(c, "first: 100", image.Point{20, 0})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "overlays legend over the top right corner of the graph",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				Legend(LegendPositionTopRight),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				// Legend.
+				testcanvas.MustSetAreaCells(c, image.Rect(13, 0, 20, 1), ' ')
+				testcanvas.MustSetCell(c, image.Point{13, 0}, '⣿')
+				testdraw.MustText(c, "first", image.Point{15, 0})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking an entry hides the series and rescales the Y axis",
+			canvas: image.Rect(0, 0, 30, 10),
+			opts: []Option{
+				Legend(LegendPositionRight),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				if err := lc.Series("second", []float64{0, 200}); err != nil {
+					return err
+				}
+				return click(lc, image.Point{30, 10}, image.Point{23, 1})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{20, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{20, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 21, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{29, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				// Legend.
+				testcanvas.MustSetCell(c, image.Point{22, 0}, '⣿')
+				testdraw.MustText(c, "first", image.Point{24, 0})
+				testcanvas.MustSetCell(c, image.Point{22, 1}, '⣿', cell.Dim())
+				testdraw.MustText(c, "second", image.Point{24, 1}, draw.TextCellOpts(cell.Dim()))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking an entry again displays the series",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				Legend(LegendPositionTopRight),
+			},
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				if err := click(lc, image.Point{20, 10}, image.Point{15, 0}); err != nil {
+					return err
+				}
+				return click(lc, image.Point{20, 10}, image.Point{15, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				// Legend.
+				testcanvas.MustSetAreaCells(c, image.Rect(13, 0, 20, 1), ' ')
+				testcanvas.MustSetCell(c, image.Point{13, 0}, '⣿')
+				testdraw.MustText(c, "first", image.Point{15, 0})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			widget, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.writes != nil {
+				if err := tc.writes(widget); err != nil {
+					t.Fatalf("writes => unexpected error: %v", err)
+				}
+			}
+
+			if err := widget.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			want := faketerm.MustNew(c.Size())
+			if tc.want != nil {
+				want = tc.want(c.Size())
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestLegendClickDoesNotZoom(t *testing.T) {
+	lc, err := New(Legend(LegendPositionTopRight))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	for _, label := range []string{"first", "second"} {
+		if err := lc.Series(label, []float64{0, 1, 2, 3}); err != nil {
+			t.Fatalf("Series => unexpected error: %v", err)
+		}
+	}
+	// A double click on the graph resets the zoom, zoom in first.
+	if err := click(lc, image.Point{20, 10}, image.Point{10, 4}); err != nil {
+		t.Fatalf("click => unexpected error: %v", err)
+	}
+	if err := lc.Mouse(&terminalapi.Mouse{Position: image.Point{10, 4}, Button: mouse.ButtonWheelUp}, &widgetapi.EventMeta{}); err != nil {
+		t.Fatalf("Mouse => unexpected error: %v", err)
+	}
+	zoomed := lc.zoom.Zoom()
+
+	for i := 0; i < 2; i++ {
+		if err := click(lc, image.Point{20, 10}, image.Point{15, 0}); err != nil {
+			t.Fatalf("click => unexpected error: %v", err)
+		}
+	}
+	if got := lc.zoom.Zoom(); got != zoomed {
+		t.Errorf("zoom.Zoom => %v, want the zoom to remain %v", got, zoomed)
+	}
+}
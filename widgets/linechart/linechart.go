@@ -22,9 +22,12 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/private/button"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/braille"
 	"github.com/mum4k/termdash/private/draw"
@@ -35,6 +38,37 @@ import (
 	"github.com/mum4k/termdash/widgets/linechart/internal/zoom"
 )
 
+// seriesKind is the kind of a series, it determines the positions of its
+// values on the X axis.
+type seriesKind int
+
+// String implements fmt.Stringer()
+func (sk seriesKind) String() string {
+	if n, ok := seriesKindNames[sk]; ok {
+		return n
+	}
+	return "unknown series"
+}
+
+// seriesKindNames maps seriesKind values to human readable names.
+var seriesKindNames = map[seriesKind]string{
+	seriesKindPositional: "series",
+	seriesKindTime:       "time series",
+	seriesKindXY:         "XY series",
+}
+
+const (
+	// seriesKindPositional is a series set by Series, the values are evenly
+	// spaced on the X axis.
+	seriesKindPositional seriesKind = iota
+	// seriesKindTime is a series set by TimeSeries, the values are placed at
+	// their times.
+	seriesKindTime
+	// seriesKindXY is a series set by XYSeries, the values are placed at their
+	// X coordinates.
+	seriesKindXY
+)
+
 // seriesValues represent values stored in the series.
 type seriesValues struct {
 	// values are the values in the series.
@@ -44,6 +78,18 @@ type seriesValues struct {
 	// max is the largest value, zero if values is empty.
 	max float64
 
+	// kind is the kind of the series.
+	kind seriesKind
+	// times are the times of the values in a time series in an increasing
+	// order.
+	times []time.Time
+	// xs are the X coordinates of the values in an XY series in an
+	// increasing order.
+	xs []float64
+
+	// drawMode determines how the values are drawn.
+	drawMode DrawMode
+
 	seriesCellOpts []cell.Option
 	// The custom labels provided on a call to Series and a bool indicating if
 	// the labels were provided. This allows resetting them to nil.
@@ -65,6 +111,71 @@ func newSeriesValues(values []float64) *seriesValues {
 	}
 }
 
+// newTimeSeriesValues returns a new seriesValues instance for the time series.
+// The points are sorted by their time.
+func newTimeSeriesValues(points []TimePoint) *seriesValues {
+	p := make([]TimePoint, len(points))
+	copy(p, points)
+	sort.SliceStable(p, func(i, j int) bool {
+		return p[i].Time.Before(p[j].Time)
+	})
+
+	values := make([]float64, len(p))
+	times := make([]time.Time, len(p))
+	for i, tp := range p {
+		values[i] = tp.Value
+		times[i] = tp.Time
+	}
+
+	min, max := minMax(values)
+	return &seriesValues{
+		values: values,
+		min:    min,
+		max:    max,
+		kind:   seriesKindTime,
+		times:  times,
+	}
+}
+
+// newXYSeriesValues returns a new seriesValues instance for the XY series.
+// The points are sorted by their X coordinates.
+func newXYSeriesValues(points []XYPoint) *seriesValues {
+	p := make([]XYPoint, len(points))
+	copy(p, points)
+	sort.SliceStable(p, func(i, j int) bool {
+		return p[i].X < p[j].X
+	})
+
+	values := make([]float64, len(p))
+	xs := make([]float64, len(p))
+	for i, xy := range p {
+		values[i] = xy.Y
+		xs[i] = xy.X
+	}
+
+	min, max := minMax(values)
+	return &seriesValues{
+		values: values,
+		min:    min,
+		max:    max,
+		kind:   seriesKindXY,
+		xs:     xs,
+	}
+}
+
+// xValue returns the position of the i-th value on the X axis.
+// The cx maps the X coordinates of XY series onto the X axis.
+func (sv *seriesValues) xValue(i int, cx *axes.ContinuousX) int {
+	switch sv.kind {
+	case seriesKindTime:
+		return axes.TimeToValue(sv.times[i])
+	case seriesKindXY:
+		return cx.ToValue(sv.xs[i])
+	default:
+		return i
+	}
+}
+
 // LineChart draws line charts.
 //
 // Each line chart has an identifying label and a set of values that are
@@ -72,19 +183,35 @@ func newSeriesValues(values []float64) *seriesValues {
 //
 // The size of the two axes is determined from the values.
 // The X axis will have a number of evenly distributed data points equal to the
-// largest count of values among all the labeled line charts.
+// largest count of values among all the labeled line charts. If the values
+// were provided as time series, the X axis spans the times of all the values
+// instead and its labels display times. If the values were provided as XY
+// series, the X axis spans the X coordinates of all the values.
 // The Y axis will be sized so that it can conveniently accommodate the largest
 // value among all the labeled line charts. This determines the used scale.
 //
 // LineChart supports mouse based zoom, zooming is achieved by either
 // highlighting an area on the graph (left mouse clicking and dragging) or by
-// using the mouse scroll button.
+// using the mouse scroll button. Double clicking on the graph resets the zoom.
+// While the LineChart is focused, the zoom can also be changed and the zoomed
+// X axis panned with the keyboard. See the ZoomShortcuts and the PanShortcuts
+// options.
+//
+// LineChart can display a legend with an entry for each series, clicking on
+// an entry toggles the series off and on. See the Legend option.
+//
+// LineChart can display a cursor that inspects the values of the series at
+// the position of the cursor. See the EnableCursor option.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type LineChart struct {
 	// mu protects the LineChart widget.
 	mu sync.RWMutex
 
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// series are the series that will be plotted.
 	// Keyed by the name of the series and updated by calling Series.
 	series map[string]*seriesValues
@@ -104,6 +231,31 @@ type LineChart struct {
 
 	// zoom tracks the zooming of the X axis.
 	zoom *zoom.Tracker
+
+	// continuous maps the X coordinates of XY series onto the X axis.
+	// Nil unless the LineChart displays XY series.
+	continuous *axes.ContinuousX
+
+	// hidden are the labels of series that were toggled off by clicking on
+	// their entry in the legend.
+	hidden map[string]bool
+	// chartAr is the area of the canvas taken by the line chart, i.e. the
+	// canvas without the legend.
+	chartAr image.Rectangle
+	// legendAreas are the areas of the entries in the legend keyed by the
+	// labels of the series.
+	legendAreas map[string]image.Rectangle
+	// legendFSMs track clicks on the entries in the legend.
+	legendFSMs map[string]*button.FSM
+
+	// cvsGraphAr is the area of the graph on the canvas as of the last call
+	// to Draw.
+	cvsGraphAr image.Rectangle
+	// cursorSet indicates that the cursor was moved onto the line chart and
+	// wasn't hidden since.
+	cursorSet bool
+	// cursorX is the position of the cursor on the X axis.
+	cursorX int
 }
 
 // New returns a new line chart widget.
@@ -113,8 +265,10 @@ func New(opts ...Option) (*LineChart, error) {
 		return nil, err
 	}
 	return &LineChart{
-		series: map[string]*seriesValues{},
-		opts:   opt,
+		series:     map[string]*seriesValues{},
+		opts:       opt,
+		hidden:     map[string]bool{},
+		legendFSMs: map[string]*button.FSM{},
 	}, nil
 }
 
@@ -160,13 +314,55 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 	})
 }
 
+// DrawMode determines how the values of a series are drawn.
+type DrawMode int
+
+// String implements fmt.Stringer()
+func (dm DrawMode) String() string {
+	if n, ok := drawModeNames[dm]; ok {
+		return n
+	}
+	return "DrawModeUnknown"
+}
+
+// drawModeNames maps DrawMode values to human readable names.
+var drawModeNames = map[DrawMode]string{
+	DrawModeLines:   "DrawModeLines",
+	DrawModeMarkers: "DrawModeMarkers",
+	DrawModeSteps:   "DrawModeSteps",
+}
+
+const (
+	// DrawModeLines connects the values with lines.
+	DrawModeLines DrawMode = iota
+	// DrawModeMarkers draws each value as a single braille pixel without
+	// connecting the values. Useful for scatter plots.
+	DrawModeMarkers
+	// DrawModeSteps connects the values with step lines. Each value is
+	// connected to the next one with a horizontal line that ends at the
+	// position of the next value and a vertical line to the next value.
+	DrawModeSteps
+)
+
+// SeriesDrawMode sets how the values of this series are drawn.
+// Defaults to DrawModeLines.
+func SeriesDrawMode(dm DrawMode) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.drawMode = dm
+	})
+}
+
 // yMinMax determines the min and max values for the Y axis.
+// Series toggled off in the legend are ignored.
 func (lc *LineChart) yMinMax() (float64, float64) {
 	var (
 		minimums []float64
 		maximums []float64
 	)
-	for _, sv := range lc.series {
+	for name, sv := range lc.series {
+		if lc.hidden[name] {
+			continue
+		}
 		minimums = append(minimums, sv.min)
 		maximums = append(maximums, sv.max)
 	}
@@ -224,14 +420,181 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 		}
 		lc.xLabels = series.xLabels
 	}
+	return lc.setSeries(label, series)
+}
+
+// TimePoint is one value in a time series.
+type TimePoint struct {
+	// Time is the time of the value.
+	Time time.Time
+	// Value is the value.
+	Value float64
+}
+
+// TimeSeries sets the points that should be displayed as the line chart with
+// the provided label. The points don't need to be sorted and their times don't
+// need to be evenly spaced.
+// The X axis of a line chart with time series displays times, the spacing and
+// format of its labels is determined automatically from the displayed range
+// of times. The labels are formatted in the location provided with the
+// XAxisTimeLocation option. The times are placed on the X axis with a
+// resolution of one second.
+// The values that should not be displayed on the line chart should be
+// represented as math.NaN values.
+// A line chart cannot display time series together with series provided by
+// calling Series and the SeriesXLabels option cannot be used with time series.
+// Subsequent calls with the same label replace any previously provided points.
+func (lc *LineChart) TimeSeries(label string, points []TimePoint, opts ...SeriesOption) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for i, p := range points {
+		if p.Time.Before(time.Unix(0, 0)) {
+			return fmt.Errorf("invalid point %d at %v, times before the Unix epoch aren't supported", i, p.Time)
+		}
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	series := newTimeSeriesValues(points)
+	for _, opt := range opts {
+		opt.set(series)
+	}
+	if series.xLabelsSet {
+		return errors.New("SeriesXLabels cannot be used with time series")
+	}
+	return lc.setSeries(label, series)
+}
+
+// XYPoint is one point in an XY series.
+type XYPoint struct {
+	// X is the X coordinate of the point.
+	X float64
+	// Y is the value of the point.
+	Y float64
+}
+
+// XYSeries sets the points that should be displayed as the line chart with
+// the provided label. The points don't need to be sorted and their X
+// coordinates don't need to be evenly spaced, e.g. latency plotted against
+// the size of the request. Use the SeriesDrawMode option to display the
+// points as a scatter plot.
+// The X axis of a line chart with XY series spans the X coordinates of all
+// the points and the range provided with the XAxisCustomScale option. The
+// spacing of its labels is determined automatically from the displayed range.
+// The X coordinates must be valid finite numbers. The values that should not
+// be displayed on the line chart should be represented as math.NaN values of
+// Y.
+// A line chart cannot display XY series together with series provided by
+// calling Series or TimeSeries and the SeriesXLabels option cannot be used
+// with XY series.
+// Subsequent calls with the same label replace any previously provided points.
+func (lc *LineChart) XYSeries(label string, points []XYPoint, opts ...SeriesOption) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for i, p := range points {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) {
+			return fmt.Errorf("invalid point %d at X %v, the X coordinates must be valid finite numbers", i, p.X)
+		}
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	series := newXYSeriesValues(points)
+	for _, opt := range opts {
+		opt.set(series)
+	}
+	if series.xLabelsSet {
+		return errors.New("SeriesXLabels cannot be used with XY series")
+	}
+	return lc.setSeries(label, series)
+}
+
+// setSeries stores the series under the label and updates the axes.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) setSeries(label string, series *seriesValues) error {
+	if _, ok := drawModeNames[series.drawMode]; !ok {
+		return fmt.Errorf("unsupported SeriesDrawMode %v(%d)", series.drawMode, series.drawMode)
+	}
+	if err := lc.checkKind(label, series); err != nil {
+		return err
+	}
 
 	lc.series[label] = series
+	lc.updateContinuous()
 	yMin, yMax := lc.yMinMax()
 	lc.yMin = yMin
 	lc.yMax = yMax
+	lc.invalidator.Invalidate()
 	return nil
 }
 
+// checkKind returns an error if the series provided with the label cannot be
+// displayed together with the other series because they are of different
+// kinds.
+func (lc *LineChart) checkKind(label string, series *seriesValues) error {
+	for l, sv := range lc.series {
+		if l != label && sv.kind != series.kind {
+			return fmt.Errorf("%v %q cannot be displayed together with %v %q", series.kind, label, sv.kind, l)
+		}
+	}
+	return nil
+}
+
+// axisKind returns the kind of the series displayed on the X axis.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) axisKind() seriesKind {
+	for _, sv := range lc.series {
+		return sv.kind
+	}
+	return seriesKindPositional
+}
+
+// xyRange returns the range of X coordinates that must be displayed on the X
+// axis of XY series.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) xyRange() (float64, float64) {
+	var minimums, maximums []float64
+	for _, sv := range lc.series {
+		if n := len(sv.xs); n > 0 {
+			minimums = append(minimums, sv.xs[0])
+			maximums = append(maximums, sv.xs[n-1])
+		}
+	}
+	if lc.opts.xAxisCustomScale != nil {
+		minimums = append(minimums, lc.opts.xAxisCustomScale.min)
+		maximums = append(maximums, lc.opts.xAxisCustomScale.max)
+	}
+
+	min, _ := minMax(minimums)
+	_, max := minMax(maximums)
+	return min, max
+}
+
+// updateContinuous updates the mapping of the X coordinates of XY series onto
+// the X axis. Resets the zoom when the mapping changes, since the zoomed
+// values on the X axis would represent different X coordinates.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) updateContinuous() {
+	if lc.axisKind() != seriesKindXY {
+		lc.continuous = nil
+		return
+	}
+
+	cx := axes.NewContinuousX(lc.xyRange())
+	if lc.continuous == nil || *lc.continuous != *cx {
+		lc.zoom = nil
+		if lc.cursorSet && lc.continuous != nil {
+			// Keep the cursor on the same X coordinate.
+			lc.cursorX = cx.ToValue(lc.continuous.FromValue(lc.cursorX))
+		}
+	}
+	lc.continuous = cx
+}
+
 // xDetails returns the details for the X axis given the specified minimum and
 // maximum value to display.
 func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axes.XDetails, error) {
@@ -241,6 +604,9 @@ func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axe
 		ReqYWidth:    reqYWidth,
 		CustomLabels: lc.xLabels,
 		LO:           lc.opts.xLabelOrientation,
+		Time:         lc.axisKind() == seriesKindTime,
+		TimeLocation: lc.opts.xTimeLocation,
+		Continuous:   lc.continuous,
 	}
 	xd, err := axes.NewXDetails(cvs.Area(), xp)
 	if err != nil {
@@ -257,7 +623,7 @@ func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axe
 func (lc *LineChart) xDetailsForCap(cvs *canvas.Canvas, bc *braille.Canvas, xd *axes.XDetails, yd *axes.YDetails) (*axes.XDetails, error) {
 	lc.capacity = bc.Area().Dx()
 	values := int(xd.Scale.Max.Value) - int(xd.Scale.Min.Value) + 1
-	if !lc.opts.xAxisUnscaled || values <= lc.capacity {
+	if !lc.opts.xAxisUnscaled || lc.axisKind() != seriesKindPositional || values <= lc.capacity {
 		return xd, nil
 	}
 
@@ -273,7 +639,7 @@ func (lc *LineChart) xDetailsForCap(cvs *canvas.Canvas, bc *braille.Canvas, xd *
 
 // axesDetails determines the details about the X and Y axes.
 func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, error) {
-	reqXHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation)
+	reqXHeight := lc.requiredXHeight()
 	yp := &axes.YProperties{
 		Min:            lc.yMin,
 		Max:            lc.yMax,
@@ -286,8 +652,7 @@ func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDet
 		return nil, nil, fmt.Errorf("NewYDetails => %v", err)
 	}
 
-	const xMin = 0
-	xMax := lc.maxXValue()
+	xMin, xMax := lc.xMinMax()
 	xd, err := lc.xDetails(cvs, yd.Start.X, xMin, xMax)
 	if err != nil {
 		return nil, nil, err
@@ -300,6 +665,7 @@ func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDet
 func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
+	lc.invalidator = meta.Invalidator
 
 	needAr, err := area.FromSize(lc.minSize())
 	if err != nil {
@@ -309,16 +675,46 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return draw.ResizeNeeded(cvs)
 	}
 
-	xd, yd, err := lc.axesDetails(cvs)
+	var entries []*legendEntry
+	if lc.opts.legend {
+		entries = lc.legendEntries()
+	}
+	lc.chartAr = lc.splitLegend(cvs.Area(), entries)
+	chartCvs := cvs
+	if lc.chartAr != cvs.Area() {
+		chartCvs, err = canvas.New(lc.chartAr)
+		if err != nil {
+			return err
+		}
+	}
+
+	xd, yd, err := lc.axesDetails(chartCvs)
 	if err != nil {
 		return err
 	}
 
-	adjXD, err := lc.drawSeries(cvs, xd, yd)
+	adjXD, err := lc.drawSeries(chartCvs, xd, yd)
 	if err != nil {
 		return err
 	}
-	return lc.drawAxes(cvs, adjXD, yd)
+	if err := lc.drawAxes(chartCvs, adjXD, yd); err != nil {
+		return err
+	}
+	if chartCvs != cvs {
+		if err := chartCvs.CopyTo(cvs); err != nil {
+			return err
+		}
+	}
+
+	lc.cvsGraphAr = lc.graphAr(chartCvs, adjXD, yd).Add(lc.chartAr.Min)
+	if lc.opts.legend && lc.opts.legendPosition.overlay() {
+		lc.overlayLegend(lc.cvsGraphAr, entries)
+	}
+	lc.updateLegendFSMs(entries)
+	if err := lc.drawLegend(cvs, entries); err != nil {
+		return err
+	}
+	return lc.drawTooltip(cvs, adjXD)
 }
 
 // drawAxes draws the X,Y axes and their labels.
@@ -402,72 +798,184 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 	sort.Strings(names)
 
 	for _, name := range names {
+		if lc.hidden[name] {
+			continue
+		}
 		sv := lc.series[name]
-		// Skip over series that don't have at least two points since we can't
-		// draw a line for just one point.
-		// Skip over series that fall under the minimum value on the X axis.
-		if got := len(sv.values); got <= 1 {
+		var err error
+		switch sv.drawMode {
+		case DrawModeMarkers:
+			err = lc.drawMarkers(bc, name, sv, xdZoomed, yd)
+		case DrawModeSteps:
+			err = lc.drawSteps(bc, name, sv, xdZoomed, yd)
+		default:
+			err = lc.drawLines(bc, name, sv, xdZoomed, yd)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := lc.drawCursor(bc, xdZoomed); err != nil {
+		return nil, err
+	}
+	if highlight, hRange := lc.zoom.Highlight(); highlight {
+		if err := lc.highlightRange(bc, hRange); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bc.CopyTo(cvs); err != nil {
+		return nil, fmt.Errorf("bc.Apply => %v", err)
+	}
+	return xdZoomed, nil
+}
+
+// drawLines draws the values of the series connected with lines.
+func (lc *LineChart) drawLines(bc *braille.Canvas, name string, sv *seriesValues, xd *axes.XDetails, yd *axes.YDetails) error {
+	// Skip over series that don't have at least two points since we can't
+	// draw a line for just one point.
+	if got := len(sv.values); got <= 1 {
+		return nil
+	}
+
+	minX, maxX := int(xd.Scale.Min.Value), int(xd.Scale.Max.Value)
+	for i := 1; i < len(sv.values); i++ {
+		v := sv.values[i]
+		prev := sv.values[i-1]
+
+		// Skip the values that are missing.
+		if math.IsNaN(v) || math.IsNaN(prev) {
 			continue
 		}
 
-		var prev float64
-		for i := 1; i < len(sv.values); i++ {
-			v := sv.values[i]
-			prev = sv.values[i-1]
+		prevX, x := sv.xValue(i-1, lc.continuous), sv.xValue(i, lc.continuous)
+		if x <= minX || prevX >= maxX {
+			// Don't draw lines for values that aren't supposed to be visible.
+			// These are either values outside of the current zoom or
+			// values at the beginning of a series that falls before athe
+			// start of an unscaled X axis when the XAxisUnscaled option is
+			// provided.
+			continue
+		}
+		// Lines between the values of time and XY series can be only
+		// partially visible, cut them at the edges of the X axis.
+		if prevX < minX {
+			prev = interpolate(prevX, prev, x, v, minX)
+			prevX = minX
+		}
+		if x > maxX {
+			v = interpolate(prevX, prev, x, v, maxX)
+			x = maxX
+		}
 
-			// Skip the values that are missing.
-			if math.IsNaN(v) || math.IsNaN(prev) {
-				continue
-			}
+		start, err := pixel(xd, yd, prevX, prev)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i-1, err)
+		}
+		end, err := pixel(xd, yd, x, v)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i, err)
+		}
+		if err := draw.BrailleLine(bc, start, end, draw.BrailleLineCellOpts(sv.seriesCellOpts...)); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
+		}
+	}
+	return nil
+}
 
-			if i < int(xdZoomed.Scale.Min.Value)+1 || i > int(xdZoomed.Scale.Max.Value) {
-				// Don't draw lines for values that aren't supposed to be visible.
-				// These are either values outside of the current zoom or
-				// values at the beginning of a series that falls before athe
-				// start of an unscaled X axis when the XAxisUnscaled option is
-				// provided.
-				continue
-			}
+// drawSteps draws the values of the series connected with step lines.
+func (lc *LineChart) drawSteps(bc *braille.Canvas, name string, sv *seriesValues, xd *axes.XDetails, yd *axes.YDetails) error {
+	minX, maxX := int(xd.Scale.Min.Value), int(xd.Scale.Max.Value)
+	for i := 1; i < len(sv.values); i++ {
+		v := sv.values[i]
+		prev := sv.values[i-1]
 
-			startX, err := xdZoomed.Scale.ValueToPixel(i - 1)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i-1, xdZoomed.Scale, i-1, err)
-			}
-			endX, err := xdZoomed.Scale.ValueToPixel(i)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
-			}
+		// Skip the values that are missing.
+		if math.IsNaN(v) || math.IsNaN(prev) {
+			continue
+		}
 
-			startY, err := yd.Scale.ValueToPixel(prev)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i-1, yd.Scale, prev, err)
-			}
+		prevX, x := sv.xValue(i-1, lc.continuous), sv.xValue(i, lc.continuous)
+		if x < minX || prevX >= maxX {
+			// Don't draw steps for values that aren't supposed to be visible.
+			continue
+		}
+		// The horizontal line can be only partially visible, cut it at the
+		// edges of the X axis. The vertical line isn't visible if the next
+		// value is beyond the end of the axis.
+		if prevX < minX {
+			prevX = minX
+		}
+		vertical := x <= maxX
+		if !vertical {
+			x = maxX
+		}
 
-			endY, err := yd.Scale.ValueToPixel(v)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i, yd.Scale, v, err)
-			}
+		start, err := pixel(xd, yd, prevX, prev)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i-1, err)
+		}
+		corner, err := pixel(xd, yd, x, prev)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i, err)
+		}
+		if err := draw.BrailleLine(bc, start, corner, draw.BrailleLineCellOpts(sv.seriesCellOpts...)); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
+		}
+		if !vertical {
+			continue
+		}
 
-			if err := draw.BrailleLine(bc,
-				image.Point{startX, startY},
-				image.Point{endX, endY},
-				draw.BrailleLineCellOpts(sv.seriesCellOpts...),
-			); err != nil {
-				return nil, fmt.Errorf("draw.BrailleLine => %v", err)
-			}
+		end, err := pixel(xd, yd, x, v)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i, err)
+		}
+		if err := draw.BrailleLine(bc, corner, end, draw.BrailleLineCellOpts(sv.seriesCellOpts...)); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
 		}
 	}
+	return nil
+}
 
-	if highlight, hRange := lc.zoom.Highlight(); highlight {
-		if err := lc.highlightRange(bc, hRange); err != nil {
-			return nil, err
+// drawMarkers draws each value of the series as a single pixel.
+func (lc *LineChart) drawMarkers(bc *braille.Canvas, name string, sv *seriesValues, xd *axes.XDetails, yd *axes.YDetails) error {
+	minX, maxX := int(xd.Scale.Min.Value), int(xd.Scale.Max.Value)
+	for i, v := range sv.values {
+		// Skip the values that are missing.
+		if math.IsNaN(v) {
+			continue
+		}
+
+		x := sv.xValue(i, lc.continuous)
+		if x < minX || x > maxX {
+			// Don't draw values that aren't supposed to be visible.
+			continue
+		}
+
+		p, err := pixel(xd, yd, x, v)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d], %v", name, i, err)
+		}
+		if err := bc.SetPixel(p, sv.seriesCellOpts...); err != nil {
+			return fmt.Errorf("bc.SetPixel => %v", err)
 		}
 	}
+	return nil
+}
 
-	if err := bc.CopyTo(cvs); err != nil {
-		return nil, fmt.Errorf("bc.Apply => %v", err)
+// pixel returns the pixel on the braille canvas that represents the value v
+// at the position x on the X axis.
+func pixel(xd *axes.XDetails, yd *axes.YDetails, x int, v float64) (image.Point, error) {
+	px, err := xd.Scale.ValueToPixel(x)
+	if err != nil {
+		return image.Point{}, fmt.Errorf("on scale %v, xd.Scale.ValueToPixel(%v) => %v", xd.Scale, x, err)
 	}
-	return xdZoomed, nil
+	py, err := yd.Scale.ValueToPixel(v)
+	if err != nil {
+		return image.Point{}, fmt.Errorf("on scale %v, yd.Scale.ValueToPixel(%v) => %v", yd.Scale, v, err)
+	}
+	return image.Point{px, py}, nil
 }
 
 // highlightRange highlights the range of X columns on the braille canvas.
@@ -477,24 +985,115 @@ func (lc *LineChart) highlightRange(bc *braille.Canvas, hRange *zoom.Range) erro
 	return bc.SetAreaCellOpts(ar, cell.BgColor(lc.opts.zoomHightlightColor))
 }
 
-// Keyboard implements widgetapi.Widget.Keyboard.
+// keyboard processes the keyboard event and returns the callback that must be
+// called after lc.mu is released.
+func (lc *LineChart) keyboard(k *terminalapi.Keyboard) (func() error, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.zoom == nil {
+		// The line chart wasn't drawn yet.
+		return nil, nil
+	}
+
+	switch k.Shortcut() {
+	case lc.opts.zoomKeyIn:
+		return nil, lc.zoom.ZoomIn()
+	case lc.opts.zoomKeyOut:
+		return nil, lc.zoom.ZoomOut()
+	case lc.opts.zoomKeyReset:
+		lc.zoom.Reset()
+		return nil, nil
+	case lc.opts.panKeyLeft:
+		return nil, lc.zoom.PanLeft()
+	case lc.opts.panKeyRight:
+		return nil, lc.zoom.PanRight()
+	}
+
+	if !lc.opts.cursor {
+		return nil, nil
+	}
+	return lc.cursorKeyboard(k), nil
+}
+
+// Keyboard processes keyboard events that zoom, pan and move the cursor.
+// Implements widgetapi.Widget.Keyboard.
 func (lc *LineChart) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
-	return errors.New("the LineChart widget doesn't support keyboard events")
+	fn, err := lc.keyboard(k)
+	if err != nil {
+		return err
+	}
+	if fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
 }
 
-// Mouse implements widgetapi.Widget.Mouse.
-func (lc *LineChart) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+// mouse processes the mouse event and returns the callback that must be
+// called after lc.mu is released.
+func (lc *LineChart) mouse(m *terminalapi.Mouse) (func() error, error) {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
+	// Clicks on the legend don't zoom, but the zoom must see all the button
+	// releases to finish any gestures started on the graph.
+	if inLegend := lc.legendMouse(m); inLegend && m.Button != mouse.ButtonRelease {
+		return nil, nil
+	}
 	if lc.zoom == nil {
-		return nil
+		return nil, nil
+	}
+
+	fn, err := lc.cursorMouse(m)
+	if err != nil {
+		return nil, err
 	}
-	return lc.zoom.Mouse(m)
+
+	// The zoom tracks positions relative to the line chart, which can be
+	// offset by the legend.
+	zm := *m
+	zm.Position = m.Position.Sub(lc.chartAr.Min)
+	if err := lc.zoom.Mouse(&zm); err != nil {
+		return nil, err
+	}
+	return fn, nil
 }
 
-// minSize determines the minimum required size to draw the line chart.
+// Mouse implements widgetapi.Widget.Mouse.
+func (lc *LineChart) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	fn, err := lc.mouse(m)
+	if err != nil {
+		return err
+	}
+	if fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// minSize determines the minimum required size to draw the line chart and
+// its legend.
 func (lc *LineChart) minSize() image.Point {
+	size := lc.chartMinSize()
+	if !lc.opts.legend || len(lc.series) == 0 {
+		return size
+	}
+
+	// The legend needs at least space for one swatch.
+	switch lc.opts.legendPosition {
+	case LegendPositionTop, LegendPositionBottom:
+		size.Y++
+	case LegendPositionRight:
+		size.X += 2 // One cell gap.
+	}
+	return size
+}
+
+// chartMinSize determines the minimum required size to draw the line chart
+// without its legend.
+func (lc *LineChart) chartMinSize() image.Point {
 	// At the very least we need:
 	// - n cells width for the Y axis and its labels as reported by it.
 	// - at least 1 cell width for the graph.
@@ -503,7 +1102,7 @@ func (lc *LineChart) minSize() image.Point {
 	// And for the height:
 	// - n cells width for the X axis and its labels as reported by it.
 	// - at least 2 cell height for the graph.
-	reqHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation) + 2
+	reqHeight := lc.requiredXHeight() + 2
 	return image.Point{reqWidth, reqHeight}
 }
 
@@ -513,11 +1112,53 @@ func (lc *LineChart) Options() widgetapi.Options {
 	defer lc.mu.RUnlock()
 
 	return widgetapi.Options{
-		MinimumSize: lc.minSize(),
-		WantMouse:   widgetapi.MouseScopeGlobal,
+		MinimumSize:     lc.minSize(),
+		WantMouse:       widgetapi.MouseScopeGlobal,
+		WantMouseMotion: lc.opts.cursor,
+		WantKeyboard:    widgetapi.KeyScopeFocused,
+	}
+}
+
+// requiredXHeight returns the height required for the X axis and its labels.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) requiredXHeight() int {
+	switch lc.axisKind() {
+	case seriesKindTime:
+		return axes.RequiredTimeHeight(lc.opts.xLabelOrientation)
+	case seriesKindXY:
+		return axes.RequiredContinuousHeight(lc.opts.xLabelOrientation)
+	default:
+		return axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation)
 	}
 }
 
+// xMinMax returns the minimum and the maximum value on the X axis among all
+// the series.
+// lc.mu must be held when calling this method.
+func (lc *LineChart) xMinMax() (int, int) {
+	switch lc.axisKind() {
+	case seriesKindPositional:
+		return 0, lc.maxXValue()
+	case seriesKindXY:
+		min, max := lc.xyRange()
+		return lc.continuous.ToValue(min), lc.continuous.ToValue(max)
+	}
+
+	var minimums, maximums []int
+	for _, sv := range lc.series {
+		if n := len(sv.times); n > 0 {
+			minimums = append(minimums, sv.xValue(0, lc.continuous))
+			maximums = append(maximums, sv.xValue(n-1, lc.continuous))
+		}
+	}
+	if len(minimums) == 0 {
+		return 0, 0
+	}
+	min, _ := numbers.MinMaxInts(minimums)
+	_, max := numbers.MinMaxInts(maximums)
+	return min, max
+}
+
 // maxXValue returns the maximum value on the X axis among all the series.
 // lc.mu must be held when calling this method.
 func (lc *LineChart) maxXValue() int {
@@ -533,6 +1174,27 @@ func (lc *LineChart) maxXValue() int {
 	return maxLen - 1
 }
 
+// interpolate returns the value at position x on the line between value v1
+// at position x1 and value v2 at position x2. Requires x1 < x2.
+func interpolate(x1 int, v1 float64, x2 int, v2 float64, x int) float64 {
+	return v1 + (v2-v1)*float64(x-x1)/float64(x2-x1)
+}
+
+// valueNonZeroDecimals is the number of non-zero decimal places the values
+// displayed in the legend and in the tooltip of the cursor are rounded up to.
+const valueNonZeroDecimals = 2
+
+// formatValue formats a value of a series for display in the legend or in the
+// tooltip of the cursor. Uses the formatter provided with
+// YAxisFormattedValues if any.
+func (lc *LineChart) formatValue(v float64) string {
+	var vOpts []axes.ValueOption
+	if lc.opts.yAxisValueFormatter != nil {
+		vOpts = append(vOpts, axes.ValueFormatter(lc.opts.yAxisValueFormatter))
+	}
+	return axes.NewValue(v, valueNonZeroDecimals, vOpts...).Text()
+}
+
 // minMax is a wrapper around numbers.MinMax that controls
 // the output if the values are NaN and sets defaults if it's
 // the case.
@@ -19,9 +19,11 @@ import (
 	"image"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
@@ -33,6 +35,9 @@ import (
 	"github.com/mum4k/termdash/widgetapi"
 )
 
+// testNoon is the time used as the start of time series in tests.
+var testNoon = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
 func TestLineChartDraws(t *testing.T) {
 	tests := []struct {
 		desc         string
@@ -94,6 +99,30 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:   "fails with custom X scale where min is NaN",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				XAxisCustomScale(math.NaN(), 1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with custom X scale where max is infinite",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				XAxisCustomScale(0, math.Inf(1)),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with custom X scale where min == max",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				XAxisCustomScale(1, 1),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "series fails without name for the series",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -1481,6 +1510,488 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "time series fails without name for the series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.TimeSeries("", nil)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "time series fails with times before the Unix epoch",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.TimeSeries("series", []TimePoint{
+					{Time: time.Unix(-1, 0), Value: 1},
+				})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "time series fails with custom X labels",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.TimeSeries("series", nil, SeriesXLabels(map[int]string{0: "start"}))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "time series fails next to series without times",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 1}); err != nil {
+					return err
+				}
+				return lc.TimeSeries("second", nil)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails next to time series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				if err := lc.TimeSeries("first", nil); err != nil {
+					return err
+				}
+				return lc.Series("second", []float64{0, 1})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "fails with nil time location",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				XAxisTimeLocation(nil),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "draws time series with time labels",
+			opts: []Option{
+				XAxisTimeLocation(time.UTC),
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				return lc.TimeSeries("first", []TimePoint{
+					{Time: testNoon.Add(time.Minute), Value: 100},
+					{Time: testNoon, Value: 0},
+					{Time: testNoon.Add(15 * time.Second), Value: 100},
+				})
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "12:00:00", image.Point{6, 9})
+				testdraw.MustText(c, "12:00:30", image.Point{22, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{17, 0})
+				testdraw.MustBrailleLine(bc, image.Point{17, 0}, image.Point{67, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "zooms in on time series",
+			opts: []Option{
+				XAxisTimeLocation(time.UTC),
+				ZoomStepPercent(50),
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.TimeSeries("first", []TimePoint{
+					{Time: testNoon, Value: 0},
+					{Time: testNoon.Add(time.Minute), Value: 100},
+				}); err != nil {
+					return err
+				}
+				// Draw once so zoom tracker is initialized.
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 40, 10))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					return err
+				}
+				return lc.Mouse(&terminalapi.Mouse{
+					Position: image.Point{23, 5},
+					Button:   mouse.ButtonWheelUp,
+				}, &widgetapi.EventMeta{})
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "12:00:20", image.Point{10, 9})
+				testdraw.MustText(c, "12:00:30", image.Point{22, 9})
+
+				// Braille line cut at the edges of the zoomed X axis.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 23}, image.Point{66, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "XY series fails without name for the series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("", nil)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "XY series fails with X that is NaN",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("series", []XYPoint{
+					{X: math.NaN(), Y: 1},
+				})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "XY series fails with X that is infinite",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("series", []XYPoint{
+					{X: math.Inf(-1), Y: 1},
+				})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "XY series fails with custom X labels",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("series", nil, SeriesXLabels(map[int]string{0: "start"}))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "XY series fails next to time series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				if err := lc.TimeSeries("first", nil); err != nil {
+					return err
+				}
+				return lc.XYSeries("second", nil)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails with unsupported draw mode",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesDrawMode(DrawMode(-1)))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "draws XY series with continuous labels",
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("first", []XYPoint{
+					{X: 10, Y: 50},
+					{X: 0, Y: 0},
+					{X: 2.5, Y: 100},
+				})
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "4", image.Point{19, 9})
+				testdraw.MustText(c, "6", image.Point{26, 9})
+				testdraw.MustText(c, "8", image.Point{33, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{17, 0})
+				testdraw.MustBrailleLine(bc, image.Point{17, 0}, image.Point{67, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws XY series as markers",
+			opts: []Option{
+				XAxisCustomScale(0, 10),
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("first", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 2.5, Y: 100},
+					{X: 7.5, Y: math.NaN()},
+				}, SeriesDrawMode(DrawModeMarkers))
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "4", image.Point{19, 9})
+				testdraw.MustText(c, "6", image.Point{26, 9})
+				testdraw.MustText(c, "8", image.Point{33, 9})
+
+				// Braille markers.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testbraille.MustSetPixel(bc, image.Point{0, 31})
+				testbraille.MustSetPixel(bc, image.Point{17, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws a single marker",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{100}, SeriesDrawMode(DrawModeMarkers))
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+
+				// Braille marker.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws XY series as steps",
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				return lc.XYSeries("first", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 2.5, Y: 100},
+					{X: 10, Y: 50},
+				}, SeriesDrawMode(DrawModeSteps))
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "4", image.Point{19, 9})
+				testdraw.MustText(c, "6", image.Point{26, 9})
+				testdraw.MustText(c, "8", image.Point{33, 9})
+
+				// Braille step lines.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{17, 31})
+				testdraw.MustBrailleLine(bc, image.Point{17, 31}, image.Point{17, 0})
+				testdraw.MustBrailleLine(bc, image.Point{17, 0}, image.Point{67, 0})
+				testdraw.MustBrailleLine(bc, image.Point{67, 0}, image.Point{67, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "zooms in on XY series",
+			opts: []Option{
+				ZoomStepPercent(50),
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.XYSeries("first", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 10, Y: 100},
+				}); err != nil {
+					return err
+				}
+				// Draw once so zoom tracker is initialized.
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 40, 10))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					return err
+				}
+				return lc.Mouse(&terminalapi.Mouse{
+					Position: image.Point{23, 5},
+					Button:   mouse.ButtonWheelUp,
+				}, &widgetapi.EventMeta{})
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "3", image.Point{10, 9})
+				testdraw.MustText(c, "4", image.Point{16, 9})
+				testdraw.MustText(c, "5", image.Point{23, 9})
+				testdraw.MustText(c, "6", image.Point{30, 9})
+				testdraw.MustText(c, "7", image.Point{36, 9})
+
+				// Braille line cut at the edges of the zoomed X axis.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 24}, image.Point{67, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "resets zoom when the range of XY series changes",
+			opts: []Option{
+				ZoomStepPercent(50),
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.XYSeries("first", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 10, Y: 100},
+				}); err != nil {
+					return err
+				}
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 40, 10))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					return err
+				}
+				if err := lc.Mouse(&terminalapi.Mouse{
+					Position: image.Point{23, 5},
+					Button:   mouse.ButtonWheelUp,
+				}, &widgetapi.EventMeta{}); err != nil {
+					return err
+				}
+				return lc.XYSeries("first", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 100, Y: 100},
+				})
+			},
+			wantCapacity: 68,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{39, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "20", image.Point{12, 9})
+				testdraw.MustText(c, "40", image.Point{19, 9})
+				testdraw.MustText(c, "60", image.Point{26, 9})
+				testdraw.MustText(c, "80", image.Point{33, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 40, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{67, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "regression for #174, protects against external data mutation",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -1862,7 +2373,12 @@ func TestLineChartDraws(t *testing.T) {
 			}
 
 			{
-				err := widget.Draw(c, tc.meta)
+				meta := tc.meta
+				if meta == nil {
+					// The infrastructure guarantees a non-nil meta.
+					meta = &widgetapi.Meta{}
+				}
+				err := widget.Draw(c, meta)
 				if (err != nil) != tc.wantDrawErr {
 					t.Fatalf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 				}
@@ -1897,12 +2413,143 @@ func TestLineChartDraws(t *testing.T) {
 }
 
 func TestKeyboard(t *testing.T) {
-	lc, err := New()
-	if err != nil {
-		t.Fatalf("New => unexpected error: %v", err)
+	size := image.Point{30, 8}
+	values := make([]float64, 101)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	wheelUp := func(lc *LineChart) error {
+		return lc.Mouse(&terminalapi.Mouse{Position: image.Point{17, 3}, Button: mouse.ButtonWheelUp}, &widgetapi.EventMeta{})
 	}
-	if err := lc.Keyboard(&terminalapi.Keyboard{}, &widgetapi.EventMeta{}); err == nil {
-		t.Errorf("Keyboard => got nil err, wanted one")
+
+	tests := []struct {
+		desc string
+		opts []Option
+		// events if not nil, are executed after the line chart is drawn.
+		events     func(*LineChart) error
+		keys       []keyboard.Key
+		wantMin    int
+		wantMax    int
+		wantNewErr bool
+	}{
+		{
+			desc: "fails when the shortcuts aren't unique",
+			opts: []Option{
+				ZoomShortcuts(
+					keyboard.Shortcut{Key: '+'},
+					keyboard.Shortcut{Key: '-'},
+					keyboard.Shortcut{Key: '0'},
+				),
+				PanShortcuts(
+					keyboard.Shortcut{Key: '-'},
+					keyboard.Shortcut{Key: '+'},
+				),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:    "ignores other keys",
+			keys:    []keyboard.Key{'x', keyboard.KeyArrowLeft},
+			wantMin: 0,
+			wantMax: 100,
+		},
+		{
+			desc:    "zooms in on the middle of the graph",
+			keys:    []keyboard.Key{DefaultZoomKeyIn},
+			wantMin: 4,
+			wantMax: 94,
+		},
+		{
+			desc:    "zooms out",
+			keys:    []keyboard.Key{DefaultZoomKeyIn, DefaultZoomKeyIn, DefaultZoomKeyOut},
+			wantMin: 0,
+			wantMax: 94,
+		},
+		{
+			desc:    "pans the zoomed X axis left",
+			keys:    []keyboard.Key{DefaultZoomKeyIn, DefaultZoomKeyIn, DefaultPanKeyLeft},
+			wantMin: 0,
+			wantMax: 80,
+		},
+		{
+			desc:    "pans the zoomed X axis right",
+			keys:    []keyboard.Key{DefaultZoomKeyIn, DefaultZoomKeyIn, DefaultPanKeyRight},
+			wantMin: 16,
+			wantMax: 96,
+		},
+		{
+			desc:    "resets the zoom",
+			keys:    []keyboard.Key{DefaultZoomKeyIn, DefaultZoomKeyReset},
+			wantMin: 0,
+			wantMax: 100,
+		},
+		{
+			desc: "uses the configured keys",
+			opts: []Option{
+				ZoomShortcuts(
+					keyboard.Shortcut{Key: 'i'},
+					keyboard.Shortcut{Key: 'o'},
+					keyboard.Shortcut{Key: 'r'},
+				),
+				PanShortcuts(
+					keyboard.Shortcut{Key: 'h'},
+					keyboard.Shortcut{Key: 'l'},
+				),
+			},
+			keys:    []keyboard.Key{DefaultZoomKeyIn, 'i', 'l'},
+			wantMin: 10,
+			wantMax: 100,
+		},
+		{
+			desc:    "keys pan the zoom from the mouse",
+			events:  wheelUp,
+			keys:    []keyboard.Key{DefaultPanKeyRight},
+			wantMin: 10,
+			wantMax: 100,
+		},
+		{
+			desc:    "keys reset the zoom from the mouse",
+			events:  wheelUp,
+			keys:    []keyboard.Key{DefaultZoomKeyReset},
+			wantMin: 0,
+			wantMax: 100,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := lc.Series("series", values); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			// Keys are ignored before the line chart is drawn.
+			if err := lc.Keyboard(&terminalapi.Keyboard{Key: DefaultZoomKeyIn}, &widgetapi.EventMeta{}); err != nil {
+				t.Fatalf("Keyboard => unexpected error: %v", err)
+			}
+			if err := press(lc, size); err != nil {
+				t.Fatalf("press => unexpected error: %v", err)
+			}
+			if tc.events != nil {
+				if err := tc.events(lc); err != nil {
+					t.Fatalf("events => unexpected error: %v", err)
+				}
+			}
+			if err := press(lc, size, tc.keys...); err != nil {
+				t.Fatalf("press => unexpected error: %v", err)
+			}
+
+			xd := lc.zoom.Zoom()
+			if gotMin, gotMax := int(xd.Scale.Min.Value), int(xd.Scale.Max.Value); gotMin != tc.wantMin || gotMax != tc.wantMax {
+				t.Errorf("zoom.Zoom => min:%d, max:%d, want min:%d, max:%d", gotMin, gotMax, tc.wantMin, tc.wantMax)
+			}
+		})
 	}
 }
 
@@ -1927,8 +2574,9 @@ func TestOptions(t *testing.T) {
 		{
 			desc: "reserves space for axis without series",
 			want: widgetapi.Options{
-				MinimumSize: image.Point{3, 4},
-				WantMouse:   widgetapi.MouseScopeGlobal,
+				MinimumSize:  image.Point{3, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
 			},
 		},
 		{
@@ -1937,8 +2585,9 @@ func TestOptions(t *testing.T) {
 				return lc.Series("series", []float64{0, 100})
 			},
 			want: widgetapi.Options{
-				MinimumSize: image.Point{5, 4},
-				WantMouse:   widgetapi.MouseScopeGlobal,
+				MinimumSize:  image.Point{5, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
 			},
 		},
 		{
@@ -1947,8 +2596,9 @@ func TestOptions(t *testing.T) {
 				return lc.Series("series", []float64{-100, 100})
 			},
 			want: widgetapi.Options{
-				MinimumSize: image.Point{6, 4},
-				WantMouse:   widgetapi.MouseScopeGlobal,
+				MinimumSize:  image.Point{6, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
 			},
 		},
 		{
@@ -1960,8 +2610,85 @@ func TestOptions(t *testing.T) {
 				return lc.Series("series", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 			},
 			want: widgetapi.Options{
-				MinimumSize: image.Point{4, 5},
-				WantMouse:   widgetapi.MouseScopeGlobal,
+				MinimumSize:  image.Point{4, 5},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "reserves space for vertical time labels",
+			opts: []Option{
+				XLabelsVertical(),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.TimeSeries("series", []TimePoint{
+					{Time: testNoon, Value: 0},
+					{Time: testNoon.Add(time.Hour), Value: 1},
+				})
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 11},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "reserves space for vertical continuous labels",
+			opts: []Option{
+				XLabelsVertical(),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.XYSeries("series", []XYPoint{
+					{X: 0, Y: 0},
+					{X: 1, Y: 1},
+				})
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 13},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "reserves space for the legend on top",
+			opts: []Option{
+				Legend(LegendPositionTop),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.Series("series", []float64{0, 1})
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 5},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "reserves space for the legend on the right",
+			opts: []Option{
+				Legend(LegendPositionRight),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.Series("series", []float64{0, 1})
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{5, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "overlaid legend doesn't need any space",
+			opts: []Option{
+				Legend(LegendPositionBottomLeft),
+			},
+			addSeries: func(lc *LineChart) error {
+				return lc.Series("series", []float64{0, 1})
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 4},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
 			},
 		},
 		{
@@ -1973,8 +2700,21 @@ func TestOptions(t *testing.T) {
 				return lc.Series("series", []float64{0, 100}, SeriesXLabels(map[int]string{0: "text"}))
 			},
 			want: widgetapi.Options{
-				MinimumSize: image.Point{5, 7},
-				WantMouse:   widgetapi.MouseScopeGlobal,
+				MinimumSize:  image.Point{5, 7},
+				WantMouse:    widgetapi.MouseScopeGlobal,
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+		},
+		{
+			desc: "wants mouse motion with the cursor",
+			opts: []Option{
+				EnableCursor(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:     image.Point{3, 4},
+				WantMouse:       widgetapi.MouseScopeGlobal,
+				WantMouseMotion: true,
+				WantKeyboard:    widgetapi.KeyScopeFocused,
 			},
 		},
 	}
@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/mum4k/termdash"
@@ -73,6 +74,61 @@ func playLineChart(ctx context.Context, lc *linechart.LineChart, delay time.Dura
 	}
 }
 
+// playTimeChart continuously adds points with the current time to the
+// LineChart, once every delay. Only the points from the last window are
+// displayed. Exits when the context expires.
+func playTimeChart(ctx context.Context, lc *linechart.LineChart, delay, window time.Duration) {
+	inputs := sineInputs()
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	var points []linechart.TimePoint
+	for i := 0; ; {
+		select {
+		case now := <-ticker.C:
+			i = (i + 1) % len(inputs)
+			points = append(points, linechart.TimePoint{Time: now, Value: inputs[i]})
+			for len(points) > 0 && now.Sub(points[0].Time) > window {
+				points = points[1:]
+			}
+			if err := lc.TimeSeries("time", points, linechart.SeriesCellOpts(cell.FgColor(cell.ColorNumber(33)))); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// playXYChart continuously adds random points that simulate the latency of
+// requests of various sizes to the LineChart, once every delay. Only the last
+// n points are displayed. Exits when the context expires.
+func playXYChart(ctx context.Context, lc *linechart.LineChart, delay time.Duration, n int) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	var points []linechart.XYPoint
+	for {
+		select {
+		case <-ticker.C:
+			size := rand.Float64() * 1000
+			latency := 20 + size/10 + rand.NormFloat64()*10
+			points = append(points, linechart.XYPoint{X: size, Y: latency})
+			if len(points) > n {
+				points = points[1:]
+			}
+			if err := lc.XYSeries("latency", points,
+				linechart.SeriesCellOpts(cell.FgColor(cell.ColorNumber(33))),
+				linechart.SeriesDrawMode(linechart.DrawModeMarkers),
+			); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func main() {
 	t, err := tcell.New()
 	if err != nil {
@@ -86,16 +142,60 @@ func main() {
 		linechart.AxesCellOpts(cell.FgColor(cell.ColorRed)),
 		linechart.YLabelCellOpts(cell.FgColor(cell.ColorGreen)),
 		linechart.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		linechart.Legend(linechart.LegendPositionTopRight),
+		linechart.LegendValues(),
 	)
 	if err != nil {
 		panic(err)
 	}
 	go playLineChart(ctx, lc, redrawInterval/3)
+
+	tlc, err := linechart.New(
+		linechart.AxesCellOpts(cell.FgColor(cell.ColorRed)),
+		linechart.YLabelCellOpts(cell.FgColor(cell.ColorGreen)),
+		linechart.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		linechart.EnableCursor(),
+		linechart.CursorCellOpts(cell.FgColor(cell.ColorYellow)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	go playTimeChart(ctx, tlc, redrawInterval/3, 2*time.Minute)
+
+	xylc, err := linechart.New(
+		linechart.AxesCellOpts(cell.FgColor(cell.ColorRed)),
+		linechart.YLabelCellOpts(cell.FgColor(cell.ColorGreen)),
+		linechart.XLabelCellOpts(cell.FgColor(cell.ColorCyan)),
+		linechart.XAxisCustomScale(0, 1000),
+	)
+	if err != nil {
+		panic(err)
+	}
+	go playXYChart(ctx, xylc, redrawInterval/3, 300)
+
 	c, err := container.New(
 		t,
 		container.Border(linestyle.Light),
 		container.BorderTitle("PRESS Q TO QUIT"),
-		container.PlaceWidget(lc),
+		container.SplitHorizontal(
+			container.Top(
+				container.PlaceWidget(lc),
+			),
+			container.Bottom(
+				container.SplitVertical(
+					container.Left(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Time series"),
+						container.PlaceWidget(tlc),
+					),
+					container.Right(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Latency by request size"),
+						container.PlaceWidget(xylc),
+					),
+				),
+			),
+		),
 	)
 	if err != nil {
 		panic(err)
@@ -15,10 +15,13 @@
 package linechart
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
 	"github.com/mum4k/termdash/widgets/linechart/internal/zoom"
 )
@@ -40,9 +43,26 @@ type options struct {
 	xAxisUnscaled       bool
 	yAxisMode           axes.YScaleMode
 	yAxisCustomScale    *customScale
+	xAxisCustomScale    *customScale
 	yAxisValueFormatter ValueFormatter
 	zoomHightlightColor cell.Color
 	zoomStepPercent     int
+	xTimeLocation       *time.Location
+	legend              bool
+	legendPosition      LegendPosition
+	legendValues        bool
+	legendCellOpts      []cell.Option
+	cursor              bool
+	cursorCellOpts      []cell.Option
+	onCursor            CursorFn
+	cursorKeyLeft       keyboard.Shortcut
+	cursorKeyRight      keyboard.Shortcut
+	cursorKeyHide       keyboard.Shortcut
+	zoomKeyIn           keyboard.Shortcut
+	zoomKeyOut          keyboard.Shortcut
+	zoomKeyReset        keyboard.Shortcut
+	panKeyLeft          keyboard.Shortcut
+	panKeyRight         keyboard.Shortcut
 }
 
 // validate validates the provided options.
@@ -55,9 +75,38 @@ func (o *options) validate() error {
 			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom Y scale", o.yAxisCustomScale.min, o.yAxisCustomScale.max)
 		}
 	}
+	if o.xAxisCustomScale != nil {
+		if math.IsNaN(o.xAxisCustomScale.min) || math.IsNaN(o.xAxisCustomScale.max) ||
+			math.IsInf(o.xAxisCustomScale.min, 0) || math.IsInf(o.xAxisCustomScale.max, 0) {
+			return fmt.Errorf("both the min(%v) and the max(%v) provided as custom X scale must be valid finite numbers", o.xAxisCustomScale.min, o.xAxisCustomScale.max)
+		}
+		if o.xAxisCustomScale.min >= o.xAxisCustomScale.max {
+			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom X scale", o.xAxisCustomScale.min, o.xAxisCustomScale.max)
+		}
+	}
 	if got, min, max := o.zoomStepPercent, 1, 100; got < min || got > max {
 		return fmt.Errorf("invalid ZoomStepPercent %d, must be in range %d <= value <= %d", got, min, max)
 	}
+	if _, ok := legendPositionNames[o.legendPosition]; !ok {
+		return fmt.Errorf("unsupported Legend position %v(%d)", o.legendPosition, o.legendPosition)
+	}
+	if o.xTimeLocation == nil {
+		return errors.New("the location provided to XAxisTimeLocation cannot be nil")
+	}
+	keys := map[keyboard.Shortcut]bool{
+		o.cursorKeyLeft:  true,
+		o.cursorKeyRight: true,
+		o.cursorKeyHide:  true,
+		o.zoomKeyIn:      true,
+		o.zoomKeyOut:     true,
+		o.zoomKeyReset:   true,
+		o.panKeyLeft:     true,
+		o.panKeyRight:    true,
+	}
+	if len(keys) != 8 {
+		return fmt.Errorf("invalid CursorShortcuts(left:%v, right:%v, hide:%v), ZoomShortcuts(in:%v, out:%v, reset:%v) and PanShortcuts(left:%v, right:%v), the keys must be unique",
+			o.cursorKeyLeft, o.cursorKeyRight, o.cursorKeyHide, o.zoomKeyIn, o.zoomKeyOut, o.zoomKeyReset, o.panKeyLeft, o.panKeyRight)
+	}
 	return nil
 }
 
@@ -66,6 +115,15 @@ func newOptions(opts ...Option) *options {
 	opt := &options{
 		zoomHightlightColor: cell.ColorNumber(235),
 		zoomStepPercent:     zoom.DefaultScrollStep,
+		xTimeLocation:       time.Local,
+		cursorKeyLeft:       keyboard.Shortcut{Key: DefaultCursorKeyLeft},
+		cursorKeyRight:      keyboard.Shortcut{Key: DefaultCursorKeyRight},
+		cursorKeyHide:       keyboard.Shortcut{Key: DefaultCursorKeyHide},
+		zoomKeyIn:           keyboard.Shortcut{Key: DefaultZoomKeyIn},
+		zoomKeyOut:          keyboard.Shortcut{Key: DefaultZoomKeyOut},
+		zoomKeyReset:        keyboard.Shortcut{Key: DefaultZoomKeyReset},
+		panKeyLeft:          keyboard.Shortcut{Key: DefaultPanKeyLeft},
+		panKeyRight:         keyboard.Shortcut{Key: DefaultPanKeyRight},
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -132,7 +190,8 @@ func YAxisAdaptive() Option {
 	})
 }
 
-// customScale is the custom scale provided via the YAxisCustomScale option.
+// customScale is the custom scale provided via the YAxisCustomScale or the
+// XAxisCustomScale option.
 type customScale struct {
 	min, max float64
 }
@@ -160,6 +219,26 @@ func YAxisCustomScale(min, max float64) Option {
 	})
 }
 
+// XAxisCustomScale when provided, the X axis of a LineChart that displays XY
+// series will span at least the specified minimum and maximum value instead of
+// determining those from the series only. Useful to visually stabilize the X
+// axis for LineChart applications that continuously feed points, see
+// XYSeries.
+// The LineChart still extends the X axis if a point is encountered that is
+// outside of the range specified here.
+// Both the minimum and the maximum must be valid finite numbers and the
+// minimum must be smaller than the maximum.
+// This option takes no effect on series provided by calling Series or
+// TimeSeries.
+func XAxisCustomScale(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.xAxisCustomScale = &customScale{
+			min: min,
+			max: max,
+		}
+	})
+}
+
 // XAxisUnscaled when provided, stops the LineChart from rescaling the X axis
 // when it can't fit all the values in the series, instead the LineCharts only
 // displays the last n values that fit into its width. This is useful to create
@@ -170,13 +249,22 @@ func YAxisCustomScale(min, max float64) Option {
 //
 // The default behavior is to rescale the X axis to display all the values.
 // This option takes no effect if all the values on the series fit into the
-// LineChart area.
+// LineChart area or on time series and XY series.
 func XAxisUnscaled() Option {
 	return option(func(opts *options) {
 		opts.xAxisUnscaled = true
 	})
 }
 
+// XAxisTimeLocation sets the location in which the labels on the X axis are
+// formatted when the LineChart displays time series, see TimeSeries.
+// Defaults to time.Local.
+func XAxisTimeLocation(loc *time.Location) Option {
+	return option(func(opts *options) {
+		opts.xTimeLocation = loc
+	})
+}
+
 // ZoomHightlightColor sets the background color of the area that is selected
 // with mouse in order to zoom the linechart.
 // Defaults to color number 235.
@@ -186,8 +274,10 @@ func ZoomHightlightColor(c cell.Color) Option {
 	})
 }
 
-// ZoomStepPercent sets the zooming step on each mouse scroll event as the
-// percentage of the size of the X axis.
+// ZoomStepPercent sets the zooming step on each mouse scroll event or press
+// of the keys configured with ZoomShortcuts as the percentage of the size of
+// the X axis. Also sets the panning step on each press of the keys configured
+// with PanShortcuts as the percentage of the size of the zoomed X axis.
 // The value must be in range 0 < value <= 100.
 // Defaults to zoom.DefaultScrollStep.
 func ZoomStepPercent(perc int) Option {
@@ -196,6 +286,45 @@ func ZoomStepPercent(perc int) Option {
 	})
 }
 
+// The default keys that zoom the X axis.
+const (
+	DefaultZoomKeyIn    = '+'
+	DefaultZoomKeyOut   = '-'
+	DefaultZoomKeyReset = '0'
+)
+
+// ZoomShortcuts configures the keyboard shortcuts that zoom in on or out from
+// the middle of the graph by the step set with ZoomStepPercent and that reset
+// the zoom. The keys work while the line chart is focused and share the zoom
+// with the mouse.
+// The provided shortcuts must be unique.
+// Defaults to DefaultZoomKeyIn, DefaultZoomKeyOut and DefaultZoomKeyReset.
+func ZoomShortcuts(in, out, reset keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.zoomKeyIn = in
+		opts.zoomKeyOut = out
+		opts.zoomKeyReset = reset
+	})
+}
+
+// The default keys that pan the zoomed X axis.
+const (
+	DefaultPanKeyLeft  = '['
+	DefaultPanKeyRight = ']'
+)
+
+// PanShortcuts configures the keyboard shortcuts that move the zoomed X axis
+// towards smaller or larger values by the step set with ZoomStepPercent. The
+// keys work while the line chart is focused and only when it is zoomed in.
+// The provided shortcuts must be unique.
+// Defaults to DefaultPanKeyLeft and DefaultPanKeyRight.
+func PanShortcuts(left, right keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.panKeyLeft = left
+		opts.panKeyRight = right
+	})
+}
+
 // YAxisFormattedValues sets a value formatter for the Y axis values.
 // If a formatter is set, it will format the values with the desired
 // ValueFormatter and will use the retuning string from the formatter
@@ -206,6 +335,101 @@ func YAxisFormattedValues(vfmt ValueFormatter) Option {
 	})
 }
 
+// Legend displays a legend with an entry for each series at the specified
+// position. Each entry displays the label of the series next to a swatch in
+// the color set by SeriesCellOpts.
+// Clicking on an entry with the left mouse button toggles the series off and
+// on. The Y axis is rescaled to fit only the displayed series, the X axis
+// keeps its range.
+// Legends placed outside of the graph only take the space not required by the
+// line chart, entries that don't fit aren't displayed.
+// The default behavior is to not display any legend.
+func Legend(pos LegendPosition) Option {
+	return option(func(opts *options) {
+		opts.legend = true
+		opts.legendPosition = pos
+	})
+}
+
+// LegendValues displays the latest value of each series next to its label
+// in the legend. Uses the formatter provided with YAxisFormattedValues if
+// any.
+// This option takes no effect unless the Legend option is provided.
+func LegendValues() Option {
+	return option(func(opts *options) {
+		opts.legendValues = true
+	})
+}
+
+// LegendCellOpts sets the cell options for the text of the entries in the
+// legend.
+func LegendCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.legendCellOpts = co
+	})
+}
+
+// EnableCursor enables inspection of the values on the line chart with a
+// cursor. The cursor is a vertical line across the graph that stops at the
+// positions of the values of the displayed series. It is moved by hovering
+// over or clicking on the graph with the mouse or with the keys configured
+// with CursorShortcuts while the line chart is focused.
+// A tooltip next to the cursor displays the X label and the values of the
+// series at the cursor. Between two values of a series, the value is read off
+// the drawn line or step. The values are formatted with the formatter
+// provided with YAxisFormattedValues if any.
+// The default behavior is to not display any cursor.
+func EnableCursor() Option {
+	return option(func(opts *options) {
+		opts.cursor = true
+	})
+}
+
+// CursorCellOpts sets the cell options for the cursor and the border of its
+// tooltip.
+func CursorCellOpts(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cursorCellOpts = co
+	})
+}
+
+// CursorFn is a function called when the cursor moves, see the OnCursor
+// option. The cursor is nil when it was hidden.
+// The callback function must be thread-safe as the mouse or keyboard events
+// that move the cursor are processed in a separate goroutine.
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type CursorFn func(c *Cursor) error
+
+// OnCursor sets a function that is called when the user moves or hides the
+// cursor, see the EnableCursor option.
+func OnCursor(fn CursorFn) Option {
+	return option(func(opts *options) {
+		opts.onCursor = fn
+	})
+}
+
+// The default keys that move and hide the cursor.
+const (
+	DefaultCursorKeyLeft  = keyboard.KeyArrowLeft
+	DefaultCursorKeyRight = keyboard.KeyArrowRight
+	DefaultCursorKeyHide  = keyboard.KeyEsc
+)
+
+// CursorShortcuts configures the keyboard shortcuts that move the cursor to
+// the previous or the next value and that hide the cursor.
+// The provided shortcuts must be unique.
+// Defaults to DefaultCursorKeyLeft, DefaultCursorKeyRight and
+// DefaultCursorKeyHide.
+func CursorShortcuts(left, right, hide keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.cursorKeyLeft = left
+		opts.cursorKeyRight = right
+		opts.cursorKeyHide = hide
+	})
+}
+
 // ValueFormatter will be used to format values onto string based
 // representation.
 // The received float64 value could be a math.NaN value.
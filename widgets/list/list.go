@@ -0,0 +1,625 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package list contains a widget that displays a list of items the user can
+// select.
+package list
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/mouse/gesture"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The markers displayed in front of the items when the MultiSelect option is
+// set.
+const (
+	selectedMarker   = "[x] "
+	unselectedMarker = "[ ] "
+)
+
+// filterPrefix is displayed in front of the typed text when the FilterOnType
+// option is set.
+const filterPrefix = "Filter: "
+
+// Item is a single item of the list.
+type Item struct {
+	// text is the text of the item.
+	text string
+	// opts are the cell options used to draw the text.
+	opts []cell.Option
+}
+
+// NewItem returns a new item that displays the provided text.
+// The text must not be empty and must not contain any control characters or
+// space characters other than ' '. The cell options are applied to the
+// displayed text.
+func NewItem(text string, opts ...cell.Option) *Item {
+	return &Item{
+		text: text,
+		opts: opts,
+	}
+}
+
+// Text returns the text of the item.
+func (i *Item) Text() string {
+	return i.text
+}
+
+// validate validates the item.
+func (i *Item) validate() error {
+	if i.text == "" {
+		return errors.New("the text of the item cannot be empty")
+	}
+	if strings.ContainsRune(i.text, '\n') {
+		return fmt.Errorf("the text %q cannot contain newline characters", i.text)
+	}
+	return wrap.ValidText(i.text)
+}
+
+// List displays a vertical list of items.
+//
+// The cursor highlights one of the items, the user moves it with the keyboard
+// and selects the item under the cursor with the select key or by clicking on
+// an item. Typing moves the cursor to the next item that starts with the typed
+// text or filters the items if the FilterOnType option is set. The items
+// scroll to keep the cursor visible, the mouse wheel scrolls the items.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type List struct {
+	// items are the items as provided to SetItems.
+	items []*Item
+	// visible are the indexes of the displayed items.
+	visible []int
+
+	// cursor is the index of the item under the cursor or -1 if there are no
+	// visible items.
+	cursor int
+	// selected are the indexes of the selected items.
+	selected map[int]bool
+
+	// offset is the position in visible of the first displayed item.
+	offset int
+	// followCursor indicates that the next draw should scroll the items so
+	// that the cursor is visible.
+	followCursor bool
+
+	// query is the text typed by the user.
+	query string
+	// lastTyped is the time when the user last typed a character.
+	lastTyped time.Time
+
+	// bodyRows is the number of items that fit the canvas during the last
+	// draw.
+	bodyRows int
+
+	// gestures recognizes mouse clicks on the items.
+	gestures *gesture.Recognizer
+	// clicked is the index of the last clicked item or -1 if no item was
+	// clicked since the items were set.
+	clicked int
+
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new empty list.
+func New(opts ...Option) (*List, error) {
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &List{
+		cursor:   -1,
+		selected: map[int]bool{},
+		gestures: gesture.New(mouse.ButtonLeft, image.ZR),
+		clicked:  -1,
+		opts:     opt,
+	}, nil
+}
+
+// now returns the current time, can be replaced in tests.
+var now = time.Now
+
+// SetItems replaces the items displayed in the list.
+// Items are identified by their text, so the cursor and the selection remain
+// on the items whose text didn't change. The OnHighlight and OnSelect
+// functions aren't called.
+// This method is thread-safe and can be called while the dashboard is running.
+func (l *List) SetItems(items []*Item) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, it := range items {
+		if it == nil {
+			return fmt.Errorf("item[%d] is nil", i)
+		}
+		if err := it.validate(); err != nil {
+			return fmt.Errorf("invalid item[%d]: %v", i, err)
+		}
+	}
+
+	var cursorText string
+	if l.cursor >= 0 {
+		cursorText = l.items[l.cursor].text
+	}
+	selectedTexts := map[string]bool{}
+	for i := range l.selected {
+		selectedTexts[l.items[i].text] = true
+	}
+
+	l.items = items
+	l.cursor = -1
+	l.selected = map[int]bool{}
+	l.clicked = -1
+	for i, it := range items {
+		if l.cursor < 0 && cursorText != "" && it.text == cursorText {
+			l.cursor = i
+		}
+		if selectedTexts[it.text] && (l.opts.multiSelect || len(l.selected) == 0) {
+			l.selected[i] = true
+		}
+	}
+	l.filter()
+	l.invalidator.Invalidate()
+	return nil
+}
+
+// Selected returns the indexes of the selected items in an ascending order.
+func (l *List) Selected() []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.selectedIndexes()
+}
+
+// selectedIndexes returns the indexes of the selected items in an ascending
+// order.
+// Caller must hold l.mu.
+func (l *List) selectedIndexes() []int {
+	res := []int{}
+	for i := range l.selected {
+		res = append(res, i)
+	}
+	sort.Ints(res)
+	return res
+}
+
+// Highlighted returns the index of the item under the cursor. Returns false if
+// there are no visible items.
+func (l *List) Highlighted() (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cursor, l.cursor >= 0
+}
+
+// matches asserts whether the item is visible with the current query.
+func (l *List) matches(it *Item) bool {
+	if !l.opts.filterOnType || l.query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(it.text), strings.ToLower(l.query))
+}
+
+// filter updates the visible items and ensures that the cursor is on one of
+// them.
+// Caller must hold l.mu.
+func (l *List) filter() {
+	l.visible = nil
+	for i, it := range l.items {
+		if l.matches(it) {
+			l.visible = append(l.visible, i)
+		}
+	}
+	if l.position() < 0 {
+		l.cursor = -1
+		if len(l.visible) > 0 {
+			l.cursor = l.visible[0]
+		}
+	}
+	l.followCursor = true
+}
+
+// position returns the position of the cursor in the visible items or -1 if
+// there are no visible items.
+// Caller must hold l.mu.
+func (l *List) position() int {
+	for pos, i := range l.visible {
+		if i == l.cursor {
+			return pos
+		}
+	}
+	return -1
+}
+
+// queryRows returns the number of rows used to display the typed text.
+// Caller must hold l.mu.
+func (l *List) queryRows() int {
+	if l.opts.filterOnType && l.query != "" {
+		return 1
+	}
+	return 0
+}
+
+// maxOffset returns the largest offset that still fills the canvas.
+// Caller must hold l.mu.
+func (l *List) maxOffset() int {
+	if max := len(l.visible) - l.bodyRows; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// scroll updates the offset so that the cursor is visible if requested and
+// the offset is within the items.
+// Caller must hold l.mu.
+func (l *List) scroll() {
+	if pos := l.position(); l.followCursor && pos >= 0 && l.bodyRows > 0 {
+		if pos < l.offset {
+			l.offset = pos
+		}
+		if pos >= l.offset+l.bodyRows {
+			l.offset = pos - l.bodyRows + 1
+		}
+	}
+	l.followCursor = false
+
+	if max := l.maxOffset(); l.offset > max {
+		l.offset = max
+	}
+	if l.offset < 0 {
+		l.offset = 0
+	}
+}
+
+// drawText draws a single line of text trimmed to the width of the canvas.
+func drawText(cvs *canvas.Canvas, text string, start image.Point, cOpts []cell.Option) error {
+	width := cvs.Area().Dx() - start.X
+	if width <= 0 {
+		return nil
+	}
+	trimmed, err := draw.TrimText(text, width, draw.OverrunModeThreeDot)
+	if err != nil {
+		return err
+	}
+	return draw.Text(cvs, trimmed, start,
+		draw.TextCellOpts(cOpts...),
+		draw.TextOverrunMode(draw.OverrunModeTrim),
+	)
+}
+
+// drawItem draws the item at the provided line of the canvas.
+func (l *List) drawItem(cvs *canvas.Canvas, index, y int) error {
+	it := l.items[index]
+	cOpts := append([]cell.Option{}, it.opts...)
+	if l.selected[index] {
+		cOpts = append(cOpts, l.opts.selectedCellOpts...)
+	}
+	if index == l.cursor {
+		cOpts = append(cOpts, l.opts.cursorCellOpts...)
+		ar := image.Rect(0, y, cvs.Area().Dx(), y+1)
+		if err := cvs.SetAreaCells(ar, ' ', l.opts.cursorCellOpts...); err != nil {
+			return err
+		}
+	}
+
+	text := it.text
+	if l.opts.multiSelect {
+		marker := unselectedMarker
+		if l.selected[index] {
+			marker = selectedMarker
+		}
+		text = marker + text
+	}
+	return drawText(cvs, text, image.Point{0, y}, cOpts)
+}
+
+// Draw draws the List widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (l *List) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.invalidator = meta.Invalidator
+
+	ar := cvs.Area()
+	l.gestures.UpdateArea(ar)
+	l.bodyRows = ar.Dy() - l.queryRows()
+	if l.bodyRows < 0 {
+		l.bodyRows = 0
+	}
+	l.scroll()
+
+	for y := 0; y < l.bodyRows; y++ {
+		pos := l.offset + y
+		if pos >= len(l.visible) {
+			break
+		}
+		if err := l.drawItem(cvs, l.visible[pos], y); err != nil {
+			return err
+		}
+	}
+
+	if l.queryRows() > 0 && ar.Dy() > 0 {
+		if err := drawText(cvs, filterPrefix+l.query, image.Point{0, ar.Dy() - 1}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chain returns a function that calls all the non-nil functions until one
+// of them returns an error. Returns nil if all the functions are nil.
+func chain(fns ...func() error) func() error {
+	var nonNil []func() error
+	for _, fn := range fns {
+		if fn != nil {
+			nonNil = append(nonNil, fn)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return func() error {
+		for _, fn := range nonNil {
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// moveCursor moves the cursor to the item at the position in the visible
+// items. Returns the callback that must be called after l.mu is released.
+// Caller must hold l.mu.
+func (l *List) moveCursor(pos int) func() error {
+	if len(l.visible) == 0 {
+		return nil
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(l.visible) {
+		pos = len(l.visible) - 1
+	}
+
+	l.followCursor = true
+	index := l.visible[pos]
+	if index == l.cursor {
+		return nil
+	}
+	l.cursor = index
+	if fn := l.opts.onHighlight; fn != nil {
+		return func() error { return fn(index) }
+	}
+	return nil
+}
+
+// selectCursor selects the item under the cursor or toggles its selection if
+// the MultiSelect option is set. Returns the callback that must be called
+// after l.mu is released.
+// Caller must hold l.mu.
+func (l *List) selectCursor() func() error {
+	if l.cursor < 0 {
+		return nil
+	}
+
+	switch {
+	case !l.opts.multiSelect:
+		l.selected = map[int]bool{l.cursor: true}
+	case l.selected[l.cursor]:
+		delete(l.selected, l.cursor)
+	default:
+		l.selected[l.cursor] = true
+	}
+
+	fn, sel := l.opts.onSelect, l.selectedIndexes()
+	if fn == nil {
+		return nil
+	}
+	return func() error { return fn(sel) }
+}
+
+// typeAhead processes a character typed by the user. Returns the callback
+// that must be called after l.mu is released.
+// Caller must hold l.mu.
+func (l *List) typeAhead(r rune) func() error {
+	if l.opts.filterOnType {
+		l.query += string(r)
+		prev := l.cursor
+		l.filter()
+		return l.highlighted(prev)
+	}
+
+	t := now()
+	if t.Sub(l.lastTyped) > l.opts.typeAheadTimeout {
+		l.query = ""
+	}
+	l.lastTyped = t
+	l.query += string(r)
+
+	if len(l.visible) == 0 {
+		return nil
+	}
+	start := l.position()
+	if len([]rune(l.query)) == 1 {
+		// Typing the same character repeatedly cycles through the items that
+		// start with it.
+		start++
+	}
+	query := strings.ToLower(l.query)
+	for n := 0; n < len(l.visible); n++ {
+		pos := (start + n) % len(l.visible)
+		if strings.HasPrefix(strings.ToLower(l.items[l.visible[pos]].text), query) {
+			return l.moveCursor(pos)
+		}
+	}
+	return nil
+}
+
+// highlighted returns the callback for the OnHighlight function if the cursor
+// moved from the previous item.
+// Caller must hold l.mu.
+func (l *List) highlighted(prev int) func() error {
+	fn, index := l.opts.onHighlight, l.cursor
+	if fn == nil || index == prev || index < 0 {
+		return nil
+	}
+	return func() error { return fn(index) }
+}
+
+// editQuery processes keys that edit the typed text when the FilterOnType
+// option is set. Returns true if the key was consumed and the callback that
+// must be called after l.mu is released.
+// Caller must hold l.mu.
+func (l *List) editQuery(k *terminalapi.Keyboard) (bool, func() error) {
+	if !l.opts.filterOnType || l.query == "" {
+		return false, nil
+	}
+
+	switch k.Key {
+	case keyboard.KeyBackspace, keyboard.KeyBackspace2:
+		rs := []rune(l.query)
+		l.query = string(rs[:len(rs)-1])
+	case keyboard.KeyEsc:
+		l.query = ""
+	default:
+		return false, nil
+	}
+	prev := l.cursor
+	l.filter()
+	return true, l.highlighted(prev)
+}
+
+// keyboard processes the keyboard event and returns the callback that must be
+// called after l.mu is released.
+func (l *List) keyboard(k *terminalapi.Keyboard) func() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if ok, fn := l.editQuery(k); ok {
+		return fn
+	}
+
+	page := l.bodyRows
+	if page < 1 {
+		page = 1
+	}
+	switch sc := k.Shortcut(); {
+	case sc == l.opts.keyUp:
+		return l.moveCursor(l.position() - 1)
+	case sc == l.opts.keyDown:
+		return l.moveCursor(l.position() + 1)
+	case sc == l.opts.keyPgUp:
+		return l.moveCursor(l.position() - page)
+	case sc == l.opts.keyPgDown:
+		return l.moveCursor(l.position() + page)
+	case sc == l.opts.keySelect:
+		return l.selectCursor()
+	case sc.Key == ' ' && sc.Modifiers == keyboard.ModNone && l.opts.multiSelect:
+		return l.selectCursor()
+	case sc.Key > 0 && unicode.IsPrint(rune(sc.Key)) && (sc.Modifiers == keyboard.ModNone || sc.Modifiers == keyboard.ModShift):
+		return l.typeAhead(rune(sc.Key))
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events, moves the cursor and selects items.
+// Implements widgetapi.Widget.Keyboard.
+func (l *List) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	if fn := l.keyboard(k); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// mouse processes the mouse event and returns the callback that must be
+// called after l.mu is released.
+func (l *List) mouse(m *terminalapi.Mouse) func() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonWheelUp:
+		if l.offset > 0 {
+			l.offset--
+		}
+		return nil
+	case mouse.ButtonWheelDown:
+		if l.offset < l.maxOffset() {
+			l.offset++
+		}
+		return nil
+	}
+
+	g := l.gestures.Event(m)
+	if g == gesture.None || m.Position.Y >= l.bodyRows {
+		return nil
+	}
+	pos := l.offset + m.Position.Y
+	if pos >= len(l.visible) {
+		return nil
+	}
+	prev := l.clicked
+	l.clicked = l.visible[pos]
+	if l.opts.multiSelect && g != gesture.Click && g != gesture.LongPress && prev == l.clicked {
+		// The first click of a multi click on the same item already toggled
+		// it.
+		return nil
+	}
+	return chain(l.moveCursor(pos), l.selectCursor())
+}
+
+// Mouse processes mouse events, selects the clicked items and scrolls the
+// items.
+// Implements widgetapi.Widget.Mouse.
+func (l *List) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	if fn := l.mouse(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (l *List) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// At least one item with at least one full-width rune.
+		MinimumSize:  image.Point{2, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,891 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks calls of the OnSelect and OnHighlight functions.
+type callbackTracker struct {
+	// selected are the arguments the OnSelect function was called with.
+	selected [][]int
+	// highlighted are the items the OnHighlight function was called with.
+	highlighted []int
+	// wantErr when set to true, makes the callbacks return an error.
+	wantErr bool
+}
+
+// onSelect is the OnSelect function.
+func (ct *callbackTracker) onSelect(selected []int) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.selected = append(ct.selected, selected)
+	return nil
+}
+
+// onHighlight is the OnHighlight function.
+func (ct *callbackTracker) onHighlight(index int) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.highlighted = append(ct.highlighted, index)
+	return nil
+}
+
+// click returns the mouse events of a left click at the point.
+func click(x, y int) []terminalapi.Event {
+	return []terminalapi.Event{
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonRelease},
+	}
+}
+
+// typed returns the keyboard events that type the text.
+func typed(text string) []terminalapi.Event {
+	var evs []terminalapi.Event
+	for _, r := range text {
+		evs = append(evs, &terminalapi.Keyboard{Key: keyboard.Key(r)})
+	}
+	return evs
+}
+
+// fruits returns the items used in the tests.
+func fruits() []*Item {
+	return []*Item{
+		NewItem("apple"),
+		NewItem("banana"),
+		NewItem("cherry"),
+		NewItem("date"),
+		NewItem("avocado"),
+	}
+}
+
+// mustItem draws an item at the line using the default options.
+func mustItem(c *canvas.Canvas, y int, text string, cursor, selected bool) {
+	var cOpts []cell.Option
+	if selected {
+		cOpts = append(cOpts, cell.Bold())
+	}
+	if cursor {
+		cOpts = append(cOpts, cell.Inverse())
+		testcanvas.MustSetAreaCells(c, image.Rect(0, y, c.Area().Dx(), y+1), ' ', cell.Inverse())
+	}
+	testdraw.MustText(c, text, image.Point{0, y}, draw.TextCellOpts(cOpts...))
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// items if not nil are set before the first draw.
+		items []*Item
+		// events are delivered after the first draw.
+		events []terminalapi.Event
+		// wantCallbackErr indicates that the callbacks should return an error
+		// and that the last event should return it.
+		wantCallbackErr bool
+		want            func(size image.Point) *faketerm.Terminal
+		wantSelected    [][]int
+		wantHighlighted []int
+		wantErr         bool
+		wantItemsErr    bool
+	}{
+		{
+			desc: "fails on zero TypeAheadTimeout",
+			opts: []Option{
+				TypeAheadTimeout(0),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc: "fails when the keys aren't unique",
+			opts: []Option{
+				SelectShortcut(keyboard.Shortcut{Key: keyboard.KeyArrowUp}),
+			},
+			canvas:  image.Rect(0, 0, 10, 3),
+			wantErr: true,
+		},
+		{
+			desc:   "SetItems fails on nil item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items: []*Item{
+				nil,
+			},
+			wantItemsErr: true,
+		},
+		{
+			desc:   "SetItems fails on empty text",
+			canvas: image.Rect(0, 0, 10, 3),
+			items: []*Item{
+				NewItem(""),
+			},
+			wantItemsErr: true,
+		},
+		{
+			desc:   "SetItems fails on text with a newline",
+			canvas: image.Rect(0, 0, 10, 3),
+			items: []*Item{
+				NewItem("a\nb"),
+			},
+			wantItemsErr: true,
+		},
+		{
+			desc:   "draws nothing without items",
+			canvas: image.Rect(0, 0, 10, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws items with the cursor on the first one",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", true, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims items that don't fit",
+			canvas: image.Rect(0, 0, 4, 2),
+			items:  fruits(),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "app…", true, false)
+				mustItem(c, 1, "ban…", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws items with custom cell options",
+			canvas: image.Rect(0, 0, 10, 2),
+			items: []*Item{
+				NewItem("red", cell.FgColor(cell.ColorRed)),
+				NewItem("blue", cell.FgColor(cell.ColorBlue)),
+			},
+			opts: []Option{
+				CursorCellOpts(cell.BgColor(cell.ColorGreen)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(c, image.Rect(0, 0, 10, 1), ' ', cell.BgColor(cell.ColorGreen))
+				testdraw.MustText(c, "red", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+					cell.BgColor(cell.ColorGreen),
+				))
+				testdraw.MustText(c, "blue", image.Point{0, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves the cursor down and scrolls",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "banana", false, false)
+				mustItem(c, 1, "cherry", false, false)
+				mustItem(c, 2, "date", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{1, 2, 3},
+		},
+		{
+			desc:   "the cursor stops at the first item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", true, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{1, 0},
+		},
+		{
+			desc:   "page down moves the cursor by the height and stops at the last item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "cherry", false, false)
+				mustItem(c, 1, "date", false, false)
+				mustItem(c, 2, "avocado", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{3, 4},
+		},
+		{
+			desc:   "custom cursor shortcuts",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				CursorShortcuts(
+					keyboard.Shortcut{Key: 'k', Modifiers: keyboard.ModCtrl},
+					keyboard.Shortcut{Key: 'j', Modifiers: keyboard.ModCtrl},
+					keyboard.Shortcut{Key: keyboard.KeyPgUp},
+					keyboard.Shortcut{Key: keyboard.KeyPgDn},
+				),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: 'j', Modifiers: keyboard.ModCtrl},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", false, false)
+				mustItem(c, 1, "banana", true, false)
+				mustItem(c, 2, "cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "selects the item under the cursor",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", false, false)
+				mustItem(c, 1, "banana", true, true)
+				mustItem(c, 2, "cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{0}, {1}},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "toggles multiple items",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				MultiSelect(),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: ' '},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: ' '},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "[x] apple", false, true)
+				mustItem(c, 1, "[x] banana", false, true)
+				mustItem(c, 2, "[ ] cherry", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{0}, {0, 1}, {0, 1, 2}, {0, 1}},
+			wantHighlighted: []int{1, 2},
+		},
+		{
+			desc:   "space doesn't select in single selection mode",
+			canvas: image.Rect(0, 0, 10, 3),
+			items: []*Item{
+				NewItem("a b"),
+				NewItem("a c"),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: ' '},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "a b", true, false)
+				mustItem(c, 1, "a c", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "selects a clicked item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: click(2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", false, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "cherry", true, true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{2}},
+			wantHighlighted: []int{2},
+		},
+		{
+			desc:   "double click toggles the item once",
+			canvas: image.Rect(0, 0, 14, 3),
+			items:  fruits(),
+			opts: []Option{
+				MultiSelect(),
+			},
+			events: append(click(2, 1), click(2, 1)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "[ ] apple", false, false)
+				mustItem(c, 1, "[x] banana", true, true)
+				mustItem(c, 2, "[ ] cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{1}},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "quick clicks on different items toggle both",
+			canvas: image.Rect(0, 0, 14, 3),
+			items:  fruits(),
+			opts: []Option{
+				MultiSelect(),
+			},
+			events: append(click(2, 0), click(2, 1)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "[x] apple", false, true)
+				mustItem(c, 1, "[x] banana", true, true)
+				mustItem(c, 2, "[ ] cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{0}, {0, 1}},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "ignores a click below the items",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits()[:2],
+			events: click(2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", true, false)
+				mustItem(c, 1, "banana", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "mouse wheel scrolls the items",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "banana", false, false)
+				mustItem(c, 1, "cherry", false, false)
+				mustItem(c, 2, "date", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "click after scrolling selects the displayed item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: append([]terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+			}, click(0, 0)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "banana", true, true)
+				mustItem(c, 1, "cherry", false, false)
+				mustItem(c, 2, "date", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{1}},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "typing jumps to the first matching item",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: typed("Ch"),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", false, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "cherry", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{2},
+		},
+		{
+			desc:   "typing the same character cycles through matching items",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "cherry", false, false)
+				mustItem(c, 1, "date", false, false)
+				mustItem(c, 2, "avocado", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{4},
+		},
+		{
+			desc:   "typing text that doesn't match keeps the cursor",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: typed("x"),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", true, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "cherry", false, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "filters the items on type",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				FilterOnType(),
+			},
+			events: typed("AN"),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "banana", true, false)
+				testdraw.MustText(c, "Filter: AN", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{1},
+		},
+		{
+			desc:   "backspace removes the last typed character",
+			canvas: image.Rect(0, 0, 10, 4),
+			items:  fruits(),
+			opts: []Option{
+				FilterOnType(),
+			},
+			events: append(typed("at"), &terminalapi.Keyboard{Key: keyboard.KeyBackspace2}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "apple", false, false)
+				mustItem(c, 1, "banana", false, false)
+				mustItem(c, 2, "date", true, false)
+				testdraw.MustText(c, "Filter: a", image.Point{0, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{3},
+		},
+		{
+			desc:   "escape clears the filter",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				FilterOnType(),
+			},
+			events: append(typed("date"), &terminalapi.Keyboard{Key: keyboard.KeyEsc}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "banana", false, false)
+				mustItem(c, 1, "cherry", false, false)
+				mustItem(c, 2, "date", true, false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantHighlighted: []int{3},
+		},
+		{
+			desc:   "filter that matches nothing",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				FilterOnType(),
+			},
+			events: append(typed("x"), &terminalapi.Keyboard{Key: keyboard.KeyEnter}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "Filter: x", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "selection uses indexes of all the items when filtered",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			opts: []Option{
+				FilterOnType(),
+			},
+			events: append(typed("v"), &terminalapi.Keyboard{Key: keyboard.KeyEnter}),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustItem(c, 0, "avocado", true, true)
+				testdraw.MustText(c, "Filter: v", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:    [][]int{{4}},
+			wantHighlighted: []int{4},
+		},
+		{
+			desc:   "forwards errors from the OnHighlight function",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			wantCallbackErr: true,
+		},
+		{
+			desc:   "forwards errors from the OnSelect function",
+			canvas: image.Rect(0, 0, 10, 3),
+			items:  fruits(),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantCallbackErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &callbackTracker{wantErr: tc.wantCallbackErr}
+			opts := append([]Option{
+				OnSelect(ct.onSelect),
+				OnHighlight(ct.onHighlight),
+			}, tc.opts...)
+
+			l, err := New(opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.items != nil {
+				err := l.SetItems(tc.items)
+				if (err != nil) != tc.wantItemsErr {
+					t.Errorf("SetItems => unexpected error: %v, wantItemsErr: %v", err, tc.wantItemsErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so the widget knows the layout.
+			if err := l.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = l.Keyboard(e, &widgetapi.EventMeta{Focused: true})
+				case *terminalapi.Mouse:
+					err = l.Mouse(e, &widgetapi.EventMeta{})
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if i == len(tc.events)-1 && tc.wantCallbackErr {
+					if err == nil {
+						t.Errorf("event %v => got nil error, want the error from the callback", ev)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("event %v => unexpected error: %v", ev, err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := l.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if diff := pretty.Compare(tc.wantSelected, ct.selected); diff != "" {
+				t.Errorf("OnSelect => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantHighlighted, ct.highlighted); diff != "" {
+				t.Errorf("OnHighlight => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTypeAheadTimeout(t *testing.T) {
+	current := time.Unix(0, 0)
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return current }
+
+	l, err := New(TypeAheadTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := l.SetItems([]*Item{
+		NewItem("bob"),
+		NewItem("carol"),
+		NewItem("charlie"),
+	}); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		key     keyboard.Key
+		advance time.Duration
+		want    int
+	}{
+		{key: 'c', want: 1},
+		{key: 'h', advance: 500 * time.Millisecond, want: 2},
+		// After the timeout the typed text starts over.
+		{key: 'b', advance: 2 * time.Second, want: 0},
+		{key: 'o', advance: 2 * time.Second, want: 0},
+	}
+	for _, s := range steps {
+		current = current.Add(s.advance)
+		if err := l.Keyboard(&terminalapi.Keyboard{Key: s.key}, &widgetapi.EventMeta{Focused: true}); err != nil {
+			t.Fatalf("Keyboard(%q) => unexpected error: %v", s.key, err)
+		}
+		if got, ok := l.Highlighted(); !ok || got != s.want {
+			t.Errorf("after typing %q Highlighted => %v, %v, want %v, true", s.key, got, ok, s.want)
+		}
+	}
+}
+
+func TestSetItems(t *testing.T) {
+	l, err := New(MultiSelect())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if _, ok := l.Highlighted(); ok {
+		t.Errorf("Highlighted => true, want false without items")
+	}
+	if err := l.SetItems(fruits()); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+
+	for _, k := range []keyboard.Key{keyboard.KeyArrowDown, ' ', keyboard.KeyArrowDown, ' '} {
+		if err := l.Keyboard(&terminalapi.Keyboard{Key: k}, &widgetapi.EventMeta{Focused: true}); err != nil {
+			t.Fatalf("Keyboard(%v) => unexpected error: %v", k, err)
+		}
+	}
+	if diff := pretty.Compare([]int{1, 2}, l.Selected()); diff != "" {
+		t.Errorf("Selected => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	// The cursor and the selection follow the text of the items.
+	if err := l.SetItems([]*Item{
+		NewItem("cherry"),
+		NewItem("fig"),
+		NewItem("banana"),
+	}); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]int{0, 2}, l.Selected()); diff != "" {
+		t.Errorf("Selected => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if got, ok := l.Highlighted(); !ok || got != 0 {
+		t.Errorf("Highlighted => %v, %v, want 0, true", got, ok)
+	}
+
+	// The cursor moves to the first item when its item is removed.
+	if err := l.SetItems([]*Item{
+		NewItem("fig"),
+		NewItem("banana"),
+	}); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+	if got, ok := l.Highlighted(); !ok || got != 0 {
+		t.Errorf("Highlighted => %v, %v, want 0, true", got, ok)
+	}
+	if diff := pretty.Compare([]int{1}, l.Selected()); diff != "" {
+		t.Errorf("Selected => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSetItemsConcurrently(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if err := l.SetItems(fruits()[:i%5+1]); err != nil {
+				t.Errorf("SetItems => unexpected error: %v", err)
+				return
+			}
+		}
+	}()
+
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	for i := 0; i < 100; i++ {
+		if err := l.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := l.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown}, &widgetapi.EventMeta{Focused: true}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+	<-done
+}
+
+func TestRequestsRedraw(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	if err := l.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := l.SetItems(fruits()); err != nil {
+		t.Fatalf("SetItems => unexpected error: %v", err)
+	}
+	if got, want := requests, 1; got != want {
+		t.Errorf("List requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := l.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{2, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
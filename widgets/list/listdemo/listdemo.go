@@ -0,0 +1,178 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary listdemo displays a menu with a single selection and a list of
+// toppings with multiple selection that filters the items on type.
+// Exits when Ctrl+Q is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/tcell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/list"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// pizzas are the items of the menu.
+var pizzas = []string{
+	"Margherita", "Marinara", "Quattro Formaggi", "Diavola", "Capricciosa",
+	"Napoletana", "Funghi", "Prosciutto", "Calzone", "Hawaii",
+}
+
+// toppings are the items of the toppings list.
+var toppings = []string{
+	"basil", "olives", "mushrooms", "onions", "peppers", "anchovies", "ham",
+	"salami", "pineapple", "artichokes", "capers", "garlic", "rocket",
+}
+
+// items returns the toppings as list items, the topping that is running out
+// is displayed in red.
+func items(runningOut int) []*list.Item {
+	var res []*list.Item
+	for i, t := range toppings {
+		if i == runningOut {
+			res = append(res, list.NewItem(t, cell.FgColor(cell.ColorRed)))
+			continue
+		}
+		res = append(res, list.NewItem(t))
+	}
+	return res
+}
+
+// update periodically replaces the items of the toppings list.
+// Exits when the context expires.
+func update(ctx context.Context, l *list.List, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	runningOut := 0
+	for {
+		select {
+		case <-ticker.C:
+			runningOut = (runningOut + 1) % len(toppings)
+			if err := l.SetItems(items(runningOut)); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := tcell.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	status, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := status.Write("Move with the arrow keys, select with Enter, Space or a click. Type to jump or filter."); err != nil {
+		panic(err)
+	}
+
+	menu, err := list.New(
+		list.OnHighlight(func(index int) error {
+			return status.Write(fmt.Sprintf("Highlighted %s.", pizzas[index]), text.WriteReplace())
+		}),
+		list.OnSelect(func(selected []int) error {
+			return status.Write(fmt.Sprintf("Ordered %s.", pizzas[selected[0]]), text.WriteReplace())
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	var menuItems []*list.Item
+	for _, p := range pizzas {
+		menuItems = append(menuItems, list.NewItem(p))
+	}
+	if err := menu.SetItems(menuItems); err != nil {
+		panic(err)
+	}
+
+	extras, err := list.New(
+		list.MultiSelect(),
+		list.FilterOnType(),
+		list.OnSelect(func(selected []int) error {
+			var names []string
+			for _, i := range selected {
+				names = append(names, toppings[i])
+			}
+			return status.Write(fmt.Sprintf("Toppings: %s.", strings.Join(names, ", ")), text.WriteReplace())
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := extras.SetItems(items(0)); err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go update(ctx, extras, 3*time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS CTRL+Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.SplitVertical(
+					container.Left(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Pizza"),
+						container.PlaceWidget(menu),
+						container.Focused(),
+					),
+					container.Right(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Toppings"),
+						container.PlaceWidget(extras),
+					),
+				),
+			),
+			container.Bottom(
+				container.PlaceWidget(status),
+			),
+			container.SplitPercent(80),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == keyboard.KeyCtrlQ {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+// options.go contains configurable options for List.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the provided options.
+type options struct {
+	multiSelect      bool
+	filterOnType     bool
+	typeAheadTimeout time.Duration
+	cursorCellOpts   []cell.Option
+	selectedCellOpts []cell.Option
+	onSelect         SelectFn
+	onHighlight      HighlightFn
+	keyUp            keyboard.Shortcut
+	keyDown          keyboard.Shortcut
+	keyPgUp          keyboard.Shortcut
+	keyPgDown        keyboard.Shortcut
+	keySelect        keyboard.Shortcut
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		typeAheadTimeout: DefaultTypeAheadTimeout,
+		cursorCellOpts:   []cell.Option{cell.Inverse()},
+		selectedCellOpts: []cell.Option{cell.Bold()},
+		keyUp:            keyboard.Shortcut{Key: DefaultCursorKeyUp},
+		keyDown:          keyboard.Shortcut{Key: DefaultCursorKeyDown},
+		keyPgUp:          keyboard.Shortcut{Key: DefaultCursorKeyPageUp},
+		keyPgDown:        keyboard.Shortcut{Key: DefaultCursorKeyPageDown},
+		keySelect:        keyboard.Shortcut{Key: DefaultSelectKey},
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min := time.Duration(0); o.typeAheadTimeout <= min {
+		return fmt.Errorf("invalid TypeAheadTimeout %v, must be %v < timeout", o.typeAheadTimeout, min)
+	}
+	keys := map[keyboard.Shortcut]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+		o.keySelect: true,
+	}
+	if len(keys) != 5 {
+		return fmt.Errorf("invalid CursorShortcuts(up:%v, down:%v, pageUp:%v, pageDown:%v) and SelectShortcut(%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown, o.keySelect)
+	}
+	return nil
+}
+
+// MultiSelect allows the user to select multiple items. Selecting an item
+// toggles its selection, the space key also toggles the selection of the item
+// under the cursor. Each item is prefixed with a marker that shows whether
+// it is selected.
+// By default only one item can be selected at a time.
+func MultiSelect() Option {
+	return option(func(opts *options) {
+		opts.multiSelect = true
+	})
+}
+
+// FilterOnType changes what happens when the user types on the keyboard.
+// Instead of moving the cursor to the next item that starts with the typed
+// text, the list only displays the items that contain the typed text. The
+// Backspace key removes the last typed character and the Esc key clears the
+// typed text.
+func FilterOnType() Option {
+	return option(func(opts *options) {
+		opts.filterOnType = true
+	})
+}
+
+// DefaultTypeAheadTimeout is the default value for the TypeAheadTimeout
+// option.
+const DefaultTypeAheadTimeout = time.Second
+
+// TypeAheadTimeout sets how long after the last key press the typed text is
+// forgotten, so that the next key press starts a new search. Doesn't apply
+// when the FilterOnType option is set.
+// Must be a positive duration. Defaults to DefaultTypeAheadTimeout.
+func TypeAheadTimeout(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.typeAheadTimeout = d
+	})
+}
+
+// CursorCellOpts sets the cell options for the item under the cursor. These
+// are applied on top of the options of the item.
+// Defaults to inverse text.
+func CursorCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cursorCellOpts = cOpts
+	})
+}
+
+// SelectedCellOpts sets the cell options for the selected items. These are
+// applied on top of the options of the item.
+// Defaults to bold text.
+func SelectedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedCellOpts = cOpts
+	})
+}
+
+// SelectFn is a function called with the indexes of all the selected items in
+// an ascending order. The indexes refer to the items provided to SetItems.
+//
+// The function must be light-weight and thread-safe as the keyboard or mouse
+// events that trigger it are processed in a separate goroutine.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type SelectFn func(selected []int) error
+
+// OnSelect sets a function that is called when the user selects an item by
+// pressing the select key or by clicking on it.
+func OnSelect(fn SelectFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// HighlightFn is a function called with the index of the item under the
+// cursor. The index refers to the items provided to SetItems.
+//
+// The same requirements apply to this function as to the SelectFn.
+type HighlightFn func(index int) error
+
+// OnHighlight sets a function that is called when the user moves the cursor
+// to another item.
+func OnHighlight(fn HighlightFn) Option {
+	return option(func(opts *options) {
+		opts.onHighlight = fn
+	})
+}
+
+// The default keys that move the cursor.
+const (
+	DefaultCursorKeyUp       = keyboard.KeyArrowUp
+	DefaultCursorKeyDown     = keyboard.KeyArrowDown
+	DefaultCursorKeyPageUp   = keyboard.KeyPgUp
+	DefaultCursorKeyPageDown = keyboard.KeyPgDn
+)
+
+// CursorShortcuts configures the keyboard shortcuts that move the cursor up
+// and down by one item or by one page.
+// The provided shortcuts must be unique.
+// Defaults to DefaultCursorKeyUp, DefaultCursorKeyDown,
+// DefaultCursorKeyPageUp and DefaultCursorKeyPageDown.
+func CursorShortcuts(up, down, pageUp, pageDown keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
+
+// DefaultSelectKey is the default key that selects the item under the cursor.
+const DefaultSelectKey = keyboard.KeyEnter
+
+// SelectShortcut configures the keyboard shortcut that selects the item under
+// the cursor.
+// Defaults to DefaultSelectKey.
+func SelectShortcut(s keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keySelect = s
+	})
+}
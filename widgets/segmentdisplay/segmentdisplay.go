@@ -60,6 +60,10 @@ type SegmentDisplay struct {
 	// All other characters are draws using the 16-segment display.
 	dotChars map[rune]bool
 
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the widget.
 	mu sync.Mutex
 
@@ -147,6 +151,7 @@ func (sd *SegmentDisplay) Write(chunks []*TextChunk, opts ...Option) error {
 		}
 		sd.buff.WriteString(text)
 	}
+	sd.invalidator.Invalidate()
 	return nil
 }
 
@@ -169,6 +174,7 @@ func (sd *SegmentDisplay) Reset() {
 	sd.mu.Lock()
 	defer sd.mu.Unlock()
 	sd.reset()
+	sd.invalidator.Invalidate()
 }
 
 // reset is the implementation of Reset.
@@ -207,6 +213,7 @@ func (sd *SegmentDisplay) preprocess(cvsAr image.Rectangle) (*segArea, error) {
 func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	sd.mu.Lock()
 	defer sd.mu.Unlock()
+	sd.invalidator = meta.Invalidator
 
 	segAr, err := sd.preprocess(cvs.Area())
 	if err != nil {
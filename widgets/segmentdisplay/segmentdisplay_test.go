@@ -942,7 +942,12 @@ func TestSegmentDisplay(t *testing.T) {
 				}
 			}
 
-			err = sd.Draw(c, tc.meta)
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			err = sd.Draw(c, meta)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 			}
@@ -42,6 +42,10 @@ type SparkLine struct {
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
 
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the SparkLine.
 	mu sync.Mutex
 
@@ -69,6 +73,7 @@ func New(opts ...Option) (*SparkLine, error) {
 func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	sl.mu.Lock()
 	defer sl.mu.Unlock()
+	sl.invalidator = meta.Invalidator
 
 	sl.lastWidth = cvs.Area().Dx()
 	needAr, err := area.FromSize(sl.minSize())
@@ -170,6 +175,7 @@ func (sl *SparkLine) Add(data []int, opts ...Option) error {
 		}
 	}
 	sl.data = append(sl.data, data...)
+	sl.invalidator.Invalidate()
 	return nil
 }
 
@@ -180,6 +186,7 @@ func (sl *SparkLine) Clear() {
 	defer sl.mu.Unlock()
 
 	sl.data = nil
+	sl.invalidator.Invalidate()
 }
 
 // Keyboard input isn't supported on the SparkLine widget.
@@ -476,7 +476,12 @@ func TestSparkLine(t *testing.T) {
 				return
 			}
 
-			err = sp.Draw(c, tc.meta)
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			err = sp.Draw(c, meta)
 			if (err != nil) != tc.wantDrawErr {
 				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
 			}
@@ -0,0 +1,260 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// column.go contains code that defines columns and cells of the table.
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// ColumnType determines the type of the values in a column, which affects
+// how the values are sorted.
+type ColumnType int
+
+// String implements fmt.Stringer()
+func (ct ColumnType) String() string {
+	if n, ok := columnTypeNames[ct]; ok {
+		return n
+	}
+	return "ColumnTypeUnknown"
+}
+
+// columnTypeNames maps ColumnType values to human readable names.
+var columnTypeNames = map[ColumnType]string{
+	ColumnTypeText:   "ColumnTypeText",
+	ColumnTypeNumber: "ColumnTypeNumber",
+}
+
+const (
+	// ColumnTypeText is a column with textual values, created with NewCell.
+	// Values are sorted lexicographically.
+	ColumnTypeText ColumnType = iota
+
+	// ColumnTypeNumber is a column with numeric values, created with
+	// NewNumberCell. Values are sorted numerically.
+	ColumnTypeNumber
+)
+
+// Width is the width of a column.
+// The zero value means that the column shares the width that remains after
+// sizing the columns with fixed and percentage widths equally with the other
+// columns that have a zero Width.
+type Width struct {
+	cells int
+	perc  int
+}
+
+// FixedWidth returns a width of the specified number of cells.
+// Must be a positive number.
+func FixedWidth(cells int) Width {
+	return Width{cells: cells}
+}
+
+// PercentWidth returns a width that is the specified percentage of the width
+// available to the table. Must be a value in the range 0 < perc <= 100.
+func PercentWidth(perc int) Width {
+	return Width{perc: perc}
+}
+
+// Column defines a single column of the table.
+type Column struct {
+	// Title is the text displayed in the header row.
+	Title string
+
+	// Type is the type of the values in the column.
+	// Defaults to ColumnTypeText.
+	Type ColumnType
+
+	// Width is the width of the column.
+	Width Width
+
+	// Align is the horizontal alignment of the values and the title within
+	// the column. Defaults to align.HorizontalLeft.
+	Align align.Horizontal
+}
+
+// validate validates the column definition.
+func (c *Column) validate() error {
+	if err := validText(c.Title); err != nil {
+		return fmt.Errorf("invalid Title: %v", err)
+	}
+	if _, ok := columnTypeNames[c.Type]; !ok {
+		return fmt.Errorf("unsupported Type %v", c.Type)
+	}
+	w := c.Width
+	switch {
+	case w.cells < 0:
+		return fmt.Errorf("invalid FixedWidth(%d), must be a positive number", w.cells)
+	case w.perc < 0 || w.perc > 100:
+		return fmt.Errorf("invalid PercentWidth(%d), must be a value in the range 0 < perc <= 100", w.perc)
+	}
+	return nil
+}
+
+// Cell is a single cell of the table.
+type Cell struct {
+	// text is the text displayed in the cell.
+	text string
+	// number is the numeric value of the cell, only set on number cells.
+	number float64
+	// isNumber indicates that this is a number cell.
+	isNumber bool
+	// opts are the cell options used to draw the text.
+	opts []cell.Option
+}
+
+// NewCell returns a new cell that displays the provided text.
+// The text must not contain any control characters or space characters
+// other than ' '. The cell options are applied to the displayed text.
+func NewCell(text string, opts ...cell.Option) *Cell {
+	return &Cell{
+		text: text,
+		opts: opts,
+	}
+}
+
+// NewNumberCell returns a new cell with a numeric value. Must be used for
+// cells in columns of ColumnTypeNumber. The cell options are applied to the
+// displayed value.
+func NewNumberCell(value float64, opts ...cell.Option) *Cell {
+	return &Cell{
+		text:     strconv.FormatFloat(value, 'f', -1, 64),
+		number:   value,
+		isNumber: true,
+		opts:     opts,
+	}
+}
+
+// NewNumberCellText is like NewNumberCell, but displays the provided text
+// instead of the formatted value, e.g. a value with units.
+func NewNumberCellText(value float64, text string, opts ...cell.Option) *Cell {
+	c := NewNumberCell(value, opts...)
+	c.text = text
+	return c
+}
+
+// Text returns the text displayed in the cell.
+func (c *Cell) Text() string {
+	return c.text
+}
+
+// less asserts whether this cell sorts before the other cell in a column of
+// the specified type.
+func (c *Cell) less(other *Cell, ct ColumnType) bool {
+	if ct == ColumnTypeNumber {
+		return c.number < other.number
+	}
+	return c.text < other.text
+}
+
+// validText validates text displayed in the table, which can be empty but
+// must be printable and fit a single line.
+func validText(text string) error {
+	if text == "" {
+		return nil
+	}
+	if strings.ContainsRune(text, '\n') {
+		return errors.New("the text cannot contain newline characters")
+	}
+	return wrap.ValidText(text)
+}
+
+// validateRow validates a row of cells against the columns.
+func validateRow(row []*Cell, columns []*Column) error {
+	if got, want := len(row), len(columns); got != want {
+		return fmt.Errorf("the row has %d cells, must have one cell for each of the %d columns", got, want)
+	}
+	for i, c := range row {
+		if c == nil {
+			return fmt.Errorf("cell[%d] is nil", i)
+		}
+		if err := validText(c.text); err != nil {
+			return fmt.Errorf("cell[%d]: %v", i, err)
+		}
+		if columns[i].Type == ColumnTypeNumber && !c.isNumber {
+			return fmt.Errorf("cell[%d] with text %q must be created with NewNumberCell, column %q has ColumnTypeNumber", i, c.text, columns[i].Title)
+		}
+	}
+	return nil
+}
+
+// colLayout is the horizontal position of a column on the canvas.
+type colLayout struct {
+	// x is the first cell of the column.
+	x int
+	// width is the number of cells the column occupies.
+	width int
+}
+
+// layoutColumns calculates the positions of the columns within the available
+// width. Columns that don't fit get a zero width.
+func layoutColumns(columns []*Column, width, spacing int) []colLayout {
+	avail := width - spacing*(len(columns)-1)
+	if avail < 0 {
+		avail = 0
+	}
+
+	widths := make([]int, len(columns))
+	var (
+		used   int
+		shared []int
+	)
+	for i, c := range columns {
+		switch {
+		case c.Width.cells > 0:
+			widths[i] = c.Width.cells
+		case c.Width.perc > 0:
+			widths[i] = avail * c.Width.perc / 100
+		default:
+			shared = append(shared, i)
+			continue
+		}
+		used += widths[i]
+	}
+	if remaining := avail - used; remaining > 0 && len(shared) > 0 {
+		each := remaining / len(shared)
+		for n, i := range shared {
+			widths[i] = each
+			if n < remaining%len(shared) {
+				widths[i]++
+			}
+		}
+	}
+
+	res := make([]colLayout, len(columns))
+	x := 0
+	for i, w := range widths {
+		if x+w > width {
+			w = width - x
+		}
+		if w < 0 {
+			w = 0
+		}
+		res[i] = colLayout{x: x, width: w}
+		x += w + spacing
+		if x > width {
+			x = width
+		}
+	}
+	return res
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestLayoutColumns(t *testing.T) {
+	tests := []struct {
+		desc    string
+		columns []*Column
+		width   int
+		spacing int
+		want    []colLayout
+	}{
+		{
+			desc: "single column takes the whole width",
+			columns: []*Column{
+				{},
+			},
+			width: 10,
+			want: []colLayout{
+				{x: 0, width: 10},
+			},
+		},
+		{
+			desc: "columns without width share the width equally",
+			columns: []*Column{
+				{}, {}, {},
+			},
+			width:   11,
+			spacing: 1,
+			want: []colLayout{
+				{x: 0, width: 3},
+				{x: 4, width: 3},
+				{x: 8, width: 3},
+			},
+		},
+		{
+			desc: "remainder goes to the first columns",
+			columns: []*Column{
+				{}, {},
+			},
+			width: 5,
+			want: []colLayout{
+				{x: 0, width: 3},
+				{x: 3, width: 2},
+			},
+		},
+		{
+			desc: "fixed and percentage widths",
+			columns: []*Column{
+				{Width: FixedWidth(2)},
+				{Width: PercentWidth(50)},
+				{},
+			},
+			width:   22,
+			spacing: 1,
+			want: []colLayout{
+				{x: 0, width: 2},
+				{x: 3, width: 10},
+				{x: 14, width: 8},
+			},
+		},
+		{
+			desc: "columns that don't fit are trimmed",
+			columns: []*Column{
+				{Width: FixedWidth(4)},
+				{Width: FixedWidth(4)},
+				{Width: FixedWidth(4)},
+			},
+			width:   7,
+			spacing: 1,
+			want: []colLayout{
+				{x: 0, width: 4},
+				{x: 5, width: 2},
+				{x: 7, width: 0},
+			},
+		},
+		{
+			desc: "columns without width get nothing when the others take the whole width",
+			columns: []*Column{
+				{Width: PercentWidth(100)},
+				{},
+			},
+			width: 5,
+			want: []colLayout{
+				{x: 0, width: 5},
+				{x: 5, width: 0},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := layoutColumns(tc.columns, tc.width, tc.spacing)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("layoutColumns => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
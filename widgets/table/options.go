@@ -0,0 +1,195 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+// options.go contains configurable options for Table.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the provided options.
+type options struct {
+	hideHeader       bool
+	headerCellOpts   []cell.Option
+	selectedCellOpts []cell.Option
+	columnSpacing    int
+	disableSorting   bool
+	onSelect         SelectFn
+	onActivate       SelectFn
+	keyUp            keyboard.Shortcut
+	keyDown          keyboard.Shortcut
+	keyPgUp          keyboard.Shortcut
+	keyPgDown        keyboard.Shortcut
+	keyActivate      keyboard.Shortcut
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		headerCellOpts:   []cell.Option{cell.Bold()},
+		selectedCellOpts: []cell.Option{cell.Inverse()},
+		columnSpacing:    DefaultColumnSpacing,
+		keyUp:            keyboard.Shortcut{Key: DefaultSelectKeyUp},
+		keyDown:          keyboard.Shortcut{Key: DefaultSelectKeyDown},
+		keyPgUp:          keyboard.Shortcut{Key: DefaultSelectKeyPageUp},
+		keyPgDown:        keyboard.Shortcut{Key: DefaultSelectKeyPageDown},
+		keyActivate:      keyboard.Shortcut{Key: DefaultActivateKey},
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min := 0; o.columnSpacing < min {
+		return fmt.Errorf("invalid ColumnSpacing %d, must be %d <= spacing", o.columnSpacing, min)
+	}
+	keys := map[keyboard.Shortcut]bool{
+		o.keyUp:       true,
+		o.keyDown:     true,
+		o.keyPgUp:     true,
+		o.keyPgDown:   true,
+		o.keyActivate: true,
+	}
+	if len(keys) != 5 {
+		return fmt.Errorf("invalid SelectShortcuts(up:%v, down:%v, pageUp:%v, pageDown:%v) and ActivateShortcut(%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown, o.keyActivate)
+	}
+	return nil
+}
+
+// HideHeader hides the header row with the column titles.
+func HideHeader() Option {
+	return option(func(opts *options) {
+		opts.hideHeader = true
+	})
+}
+
+// HeaderCellOpts sets the cell options for the header row.
+// Defaults to bold text.
+func HeaderCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.headerCellOpts = cOpts
+	})
+}
+
+// SelectedCellOpts sets the cell options for the selected row. These are
+// applied on top of the options of the individual cells.
+// Defaults to inverse text.
+func SelectedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedCellOpts = cOpts
+	})
+}
+
+// DefaultColumnSpacing is the default value for the ColumnSpacing option.
+const DefaultColumnSpacing = 1
+
+// ColumnSpacing sets the number of empty cells between adjacent columns.
+// Defaults to DefaultColumnSpacing.
+func ColumnSpacing(cells int) Option {
+	return option(func(opts *options) {
+		opts.columnSpacing = cells
+	})
+}
+
+// DisableSorting disables sorting of the rows by clicking on the column
+// titles in the header row.
+func DisableSorting() Option {
+	return option(func(opts *options) {
+		opts.disableSorting = true
+	})
+}
+
+// SelectFn is a function called with the index of a row. The index refers to
+// the position of the row in the rows provided to SetRows, regardless of how
+// the rows are currently sorted.
+//
+// The function must be light-weight and thread-safe as the keyboard or mouse
+// events that trigger it are processed in a separate goroutine.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type SelectFn func(row int) error
+
+// OnSelect sets a function that is called when the user selects a row with the
+// keyboard or the mouse.
+func OnSelect(fn SelectFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// OnActivate sets a function that is called when the user activates the
+// selected row by pressing the activate key or by double clicking on it.
+func OnActivate(fn SelectFn) Option {
+	return option(func(opts *options) {
+		opts.onActivate = fn
+	})
+}
+
+// The default keys that move the selection.
+const (
+	DefaultSelectKeyUp       = keyboard.KeyArrowUp
+	DefaultSelectKeyDown     = keyboard.KeyArrowDown
+	DefaultSelectKeyPageUp   = keyboard.KeyPgUp
+	DefaultSelectKeyPageDown = keyboard.KeyPgDn
+)
+
+// SelectShortcuts configures the keyboard shortcuts that move the selection
+// up and down by one row or by one page.
+// The provided shortcuts must be unique.
+// Defaults to DefaultSelectKeyUp, DefaultSelectKeyDown, DefaultSelectKeyPageUp
+// and DefaultSelectKeyPageDown.
+func SelectShortcuts(up, down, pageUp, pageDown keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
+
+// DefaultActivateKey is the default key that activates the selected row.
+const DefaultActivateKey = keyboard.KeyEnter
+
+// ActivateShortcut configures the keyboard shortcut that activates the
+// selected row, see OnActivate.
+// Defaults to DefaultActivateKey.
+func ActivateShortcut(s keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyActivate = s
+	})
+}
@@ -0,0 +1,594 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package table contains a widget that displays data in rows and columns.
+package table
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+	"sync"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/mouse/gesture"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The markers displayed next to the title of the column the rows are sorted
+// by.
+const (
+	sortAscRune  = '▲'
+	sortDescRune = '▼'
+)
+
+// Table displays rows of cells in columns.
+//
+// The table has an optional header row with the column titles. Clicking on a
+// title sorts the rows by the values in that column, clicking again reverses
+// the order. The user can select a row with the mouse or the keyboard, the
+// rows scroll to keep the selected row visible. The mouse wheel scrolls the
+// rows.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Table struct {
+	// columns are the column definitions.
+	columns []*Column
+	// rows are the rows in the order they were provided.
+	rows [][]*Cell
+	// order maps the displayed positions of rows to indexes in rows.
+	order []int
+
+	// selected is the index of the selected row in rows or -1 if no row is
+	// selected.
+	selected int
+	// offset is the displayed position of the first visible row.
+	offset int
+	// followSelection indicates that the next draw should scroll the rows so
+	// that the selected row is visible.
+	followSelection bool
+
+	// sortCol is the index of the column the rows are sorted by or -1 if the
+	// rows aren't sorted.
+	sortCol int
+	// sortDesc indicates that the rows are sorted in a descending order.
+	sortDesc bool
+
+	// layout are the positions of the columns from the last draw.
+	layout []colLayout
+	// bodyRows is the number of rows that fit the canvas during the last draw.
+	bodyRows int
+
+	// gestures recognizes mouse clicks on the table.
+	gestures *gesture.Recognizer
+	// clicked is the index of the last clicked row in rows or -1 if the last
+	// click wasn't on a row.
+	clicked int
+
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new table with the provided columns.
+// At least one column must be provided.
+func New(columns []*Column, opts ...Option) (*Table, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("at least one column must be provided")
+	}
+	for i, c := range columns {
+		if c == nil {
+			return nil, fmt.Errorf("column[%d] is nil", i)
+		}
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("invalid column[%d]: %v", i, err)
+		}
+	}
+
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Table{
+		columns:  columns,
+		selected: -1,
+		sortCol:  -1,
+		gestures: gesture.New(mouse.ButtonLeft, image.ZR),
+		clicked:  -1,
+		opts:     opt,
+	}, nil
+}
+
+// SetRows replaces the rows displayed in the table. Each row must have exactly
+// one cell for each column and cells in columns of ColumnTypeNumber must be
+// created with NewNumberCell.
+// Clears the selection. The rows are sorted if the table is sorted by a
+// column.
+func (t *Table) SetRows(rows [][]*Cell) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range rows {
+		if err := validateRow(r, t.columns); err != nil {
+			return fmt.Errorf("invalid row[%d]: %v", i, err)
+		}
+	}
+	// Copy to avoid external modifications, AddRow appends to the rows.
+	t.rows = make([][]*Cell, len(rows))
+	copy(t.rows, rows)
+	t.selected = -1
+	t.clicked = -1
+	t.offset = 0
+	t.sort()
+	t.invalidator.Invalidate()
+	return nil
+}
+
+// AddRow appends a row to the table. The row must follow the same rules as
+// rows provided to SetRows. The selection is preserved.
+func (t *Table) AddRow(row []*Cell) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := validateRow(row, t.columns); err != nil {
+		return err
+	}
+	t.rows = append(t.rows, row)
+	t.sort()
+	t.invalidator.Invalidate()
+	return nil
+}
+
+// Selected returns the index of the selected row as provided to SetRows or
+// AddRow. Returns false if no row is selected.
+func (t *Table) Selected() (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.selected, t.selected >= 0
+}
+
+// Select selects the row with the provided index and scrolls it into view.
+// Use a negative index to clear the selection. Doesn't call the OnSelect
+// function.
+func (t *Table) Select(row int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if row >= len(t.rows) {
+		return fmt.Errorf("cannot select row %d, the table only has %d rows", row, len(t.rows))
+	}
+	if row < 0 {
+		row = -1
+	}
+	t.selected = row
+	t.followSelection = true
+	t.invalidator.Invalidate()
+	return nil
+}
+
+// SortBy sorts the rows by the values in the column with the provided index.
+// Use a negative index to display the rows in the order they were provided.
+func (t *Table) SortBy(col int, descending bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if col >= len(t.columns) {
+		return fmt.Errorf("cannot sort by column %d, the table only has %d columns", col, len(t.columns))
+	}
+	if col < 0 {
+		col = -1
+	}
+	t.sortCol = col
+	t.sortDesc = descending
+	t.sort()
+	t.followSelection = true
+	t.invalidator.Invalidate()
+	return nil
+}
+
+// sort updates the displayed order of the rows.
+// Caller must hold t.mu.
+func (t *Table) sort() {
+	t.order = make([]int, len(t.rows))
+	for i := range t.order {
+		t.order[i] = i
+	}
+	if t.sortCol < 0 {
+		return
+	}
+
+	ct := t.columns[t.sortCol].Type
+	sort.SliceStable(t.order, func(i, j int) bool {
+		a, b := t.rows[t.order[i]][t.sortCol], t.rows[t.order[j]][t.sortCol]
+		if t.sortDesc {
+			return b.less(a, ct)
+		}
+		return a.less(b, ct)
+	})
+}
+
+// headerRows returns the number of rows the header occupies.
+func (t *Table) headerRows() int {
+	if t.opts.hideHeader {
+		return 0
+	}
+	return 1
+}
+
+// position returns the displayed position of the selected row or -1 if no
+// row is selected.
+// Caller must hold t.mu.
+func (t *Table) position() int {
+	for pos, i := range t.order {
+		if i == t.selected {
+			return pos
+		}
+	}
+	return -1
+}
+
+// maxOffset returns the largest offset that still fills the canvas.
+// Caller must hold t.mu.
+func (t *Table) maxOffset() int {
+	if max := len(t.rows) - t.bodyRows; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// scroll updates the offset so that the selected row is visible if requested
+// and the offset is within the rows.
+// Caller must hold t.mu.
+func (t *Table) scroll() {
+	if pos := t.position(); t.followSelection && pos >= 0 && t.bodyRows > 0 {
+		if pos < t.offset {
+			t.offset = pos
+		}
+		if pos >= t.offset+t.bodyRows {
+			t.offset = pos - t.bodyRows + 1
+		}
+	}
+	t.followSelection = false
+
+	if max := t.maxOffset(); t.offset > max {
+		t.offset = max
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+}
+
+// drawText draws text trimmed to and aligned within the rectangle.
+func drawText(cvs *canvas.Canvas, text string, rect image.Rectangle, h align.Horizontal, cOpts []cell.Option) error {
+	if text == "" || rect.Dx() <= 0 {
+		return nil
+	}
+	trimmed, err := draw.TrimText(text, rect.Dx(), draw.OverrunModeThreeDot)
+	if err != nil {
+		return err
+	}
+	start, err := alignfor.Text(rect, trimmed, h, align.VerticalTop)
+	if err != nil {
+		return err
+	}
+	return draw.Text(cvs, trimmed, start,
+		draw.TextCellOpts(cOpts...),
+		draw.TextMaxX(rect.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeTrim),
+	)
+}
+
+// headerTitle returns the title of the column trimmed to the width with the
+// sort marker appended if the rows are sorted by the column.
+func (t *Table) headerTitle(col, width int) (string, error) {
+	title := t.columns[col].Title
+	if t.sortCol != col {
+		return title, nil
+	}
+
+	marker := sortAscRune
+	if t.sortDesc {
+		marker = sortDescRune
+	}
+	fits := runewidth.StringWidth(title) <= width-2
+	// Don't display titles trimmed down to just the three dots.
+	if title == "" || (!fits && width-2 < 2) {
+		return string(marker), nil
+	}
+	if !fits {
+		tr, err := draw.TrimText(title, width-2, draw.OverrunModeThreeDot)
+		if err != nil {
+			return "", err
+		}
+		title = tr
+	}
+	return fmt.Sprintf("%s %c", title, marker), nil
+}
+
+// drawHeader draws the header row.
+func (t *Table) drawHeader(cvs *canvas.Canvas) error {
+	for i, l := range t.layout {
+		title, err := t.headerTitle(i, l.width)
+		if err != nil {
+			return err
+		}
+		rect := image.Rect(l.x, 0, l.x+l.width, 1)
+		if err := drawText(cvs, title, rect, t.columns[i].Align, t.opts.headerCellOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawRow draws a single row at the provided line of the canvas.
+func (t *Table) drawRow(cvs *canvas.Canvas, row int, y int) error {
+	isSelected := row == t.selected
+	if isSelected {
+		ar := image.Rect(0, y, cvs.Area().Dx(), y+1)
+		if err := cvs.SetAreaCells(ar, ' ', t.opts.selectedCellOpts...); err != nil {
+			return err
+		}
+	}
+
+	for i, l := range t.layout {
+		c := t.rows[row][i]
+		cOpts := c.opts
+		if isSelected {
+			cOpts = append(append([]cell.Option{}, c.opts...), t.opts.selectedCellOpts...)
+		}
+		rect := image.Rect(l.x, y, l.x+l.width, y+1)
+		if err := drawText(cvs, c.text, rect, t.columns[i].Align, cOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Draw draws the Table widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (t *Table) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.invalidator = meta.Invalidator
+
+	ar := cvs.Area()
+	t.gestures.UpdateArea(ar)
+	t.layout = layoutColumns(t.columns, ar.Dx(), t.opts.columnSpacing)
+	header := t.headerRows()
+	t.bodyRows = ar.Dy() - header
+	if t.bodyRows < 0 {
+		t.bodyRows = 0
+	}
+	t.scroll()
+
+	if header > 0 {
+		if err := t.drawHeader(cvs); err != nil {
+			return err
+		}
+	}
+
+	for y := header; y < ar.Dy(); y++ {
+		pos := t.offset + y - header
+		if pos >= len(t.order) {
+			break
+		}
+		if err := t.drawRow(cvs, t.order[pos], y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSelection moves the selection by the provided number of displayed
+// rows. Returns the callback that must be called after t.mu is released.
+// Caller must hold t.mu.
+func (t *Table) moveSelection(delta int) func() error {
+	if len(t.rows) == 0 {
+		return nil
+	}
+
+	pos := t.position()
+	if pos < 0 {
+		// The first move selects the first visible row.
+		pos = t.offset
+	} else {
+		pos += delta
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(t.order) {
+		pos = len(t.order) - 1
+	}
+	return t.selectPos(pos)
+}
+
+// selectPos selects the row at the displayed position. Returns the callback
+// that must be called after t.mu is released.
+// Caller must hold t.mu.
+func (t *Table) selectPos(pos int) func() error {
+	row := t.order[pos]
+	t.followSelection = true
+	if row == t.selected {
+		return nil
+	}
+	t.selected = row
+	if fn := t.opts.onSelect; fn != nil {
+		return func() error { return fn(row) }
+	}
+	return nil
+}
+
+// activate returns the callback that activates the selected row or nil if no
+// row is selected. The returned function must be called after t.mu is
+// released.
+// Caller must hold t.mu.
+func (t *Table) activate() func() error {
+	fn, row := t.opts.onActivate, t.selected
+	if fn == nil || row < 0 {
+		return nil
+	}
+	return func() error { return fn(row) }
+}
+
+// keyboard processes the keyboard event and returns the callback that must be
+// called after t.mu is released.
+func (t *Table) keyboard(k *terminalapi.Keyboard) func() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	page := t.bodyRows
+	if page < 1 {
+		page = 1
+	}
+	switch sc := k.Shortcut(); {
+	case sc == t.opts.keyUp:
+		return t.moveSelection(-1)
+	case sc == t.opts.keyDown:
+		return t.moveSelection(1)
+	case sc == t.opts.keyPgUp:
+		return t.moveSelection(-page)
+	case sc == t.opts.keyPgDown:
+		return t.moveSelection(page)
+	case sc == t.opts.keyActivate:
+		return t.activate()
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events, moves the selection and activates the
+// selected row.
+// Implements widgetapi.Widget.Keyboard.
+func (t *Table) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	if fn := t.keyboard(k); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// mouse processes the mouse event and returns the callback that must be
+// called after t.mu is released.
+func (t *Table) mouse(m *terminalapi.Mouse) func() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonWheelUp:
+		if t.offset > 0 {
+			t.offset--
+		}
+		return nil
+	case mouse.ButtonWheelDown:
+		if t.offset < t.maxOffset() {
+			t.offset++
+		}
+		return nil
+	}
+
+	g := t.gestures.Event(m)
+	if g == gesture.None {
+		return nil
+	}
+
+	prev := t.clicked
+	t.clicked = -1
+	header := t.headerRows()
+	if m.Position.Y < header {
+		// Every click of a double click on the header was already reported
+		// as a click, sorting twice would undo the sort.
+		if !t.opts.disableSorting && g != gesture.DoubleClick && g != gesture.TripleClick {
+			t.sortByHeader(m.Position.X)
+		}
+		return nil
+	}
+
+	pos := t.offset + m.Position.Y - header
+	if pos >= len(t.order) {
+		return nil
+	}
+	t.clicked = t.order[pos]
+	sel := t.selectPos(pos)
+	// Only activate when both clicks were on the same row.
+	if g != gesture.DoubleClick || prev != t.clicked {
+		return sel
+	}
+	act := t.activate()
+	return func() error {
+		if sel != nil {
+			if err := sel(); err != nil {
+				return err
+			}
+		}
+		if act != nil {
+			return act()
+		}
+		return nil
+	}
+}
+
+// sortByHeader sorts the rows by the column whose title is at the provided X
+// coordinate. Reverses the order if the rows are already sorted by it.
+// Caller must hold t.mu.
+func (t *Table) sortByHeader(x int) {
+	for i, l := range t.layout {
+		if x < l.x || x >= l.x+l.width {
+			continue
+		}
+		if t.sortCol == i {
+			t.sortDesc = !t.sortDesc
+		} else {
+			t.sortCol = i
+			t.sortDesc = false
+		}
+		t.sort()
+		t.followSelection = true
+		return
+	}
+}
+
+// Mouse processes mouse events, selects rows, sorts the rows by a column and
+// scrolls the rows.
+// Implements widgetapi.Widget.Mouse.
+func (t *Table) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	if fn := t.mouse(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (t *Table) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// At least one row with at least one full-width rune.
+		MinimumSize:  image.Point{2, t.headerRows() + 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
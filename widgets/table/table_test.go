@@ -0,0 +1,1042 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/mouse/gesture"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks calls of the OnSelect and OnActivate functions.
+type callbackTracker struct {
+	// selected are the rows the OnSelect function was called with.
+	selected []int
+	// activated are the rows the OnActivate function was called with.
+	activated []int
+	// wantErr when set to true, makes the callbacks return an error.
+	wantErr bool
+}
+
+// onSelect is the OnSelect function.
+func (ct *callbackTracker) onSelect(row int) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.selected = append(ct.selected, row)
+	return nil
+}
+
+// onActivate is the OnActivate function.
+func (ct *callbackTracker) onActivate(row int) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.activated = append(ct.activated, row)
+	return nil
+}
+
+// click returns the mouse events of a left click at the point.
+func click(x, y int) []terminalapi.Event {
+	return []terminalapi.Event{
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonRelease},
+	}
+}
+
+// people returns columns and rows used in the tests.
+func people() ([]*Column, [][]*Cell) {
+	columns := []*Column{
+		{Title: "Name"},
+		{Title: "Age", Type: ColumnTypeNumber, Width: FixedWidth(3), Align: align.HorizontalRight},
+	}
+	rows := [][]*Cell{
+		{NewCell("bob"), NewNumberCell(30)},
+		{NewCell("alice"), NewNumberCell(4)},
+		{NewCell("carol"), NewNumberCell(52)},
+	}
+	return columns, rows
+}
+
+// mustHeader draws the default header of the people table.
+func mustHeader(c *canvas.Canvas, ageTitle string) {
+	headerOpts := draw.TextCellOpts(cell.Bold())
+	testdraw.MustText(c, "Name", image.Point{0, 0}, headerOpts)
+	testdraw.MustText(c, ageTitle, image.Point{c.Area().Dx() - len([]rune(ageTitle)), 0}, headerOpts)
+}
+
+// mustRow draws a row of the people table at the line.
+func mustRow(c *canvas.Canvas, y int, name, age string, selected bool) {
+	var cOpts []cell.Option
+	if selected {
+		cOpts = append(cOpts, cell.Inverse())
+		testcanvas.MustSetAreaCells(c, image.Rect(0, y, c.Area().Dx(), y+1), ' ', cOpts...)
+	}
+	testdraw.MustText(c, name, image.Point{0, y}, draw.TextCellOpts(cOpts...))
+	testdraw.MustText(c, age, image.Point{c.Area().Dx() - len(age), y}, draw.TextCellOpts(cOpts...))
+}
+
+func TestTable(t *testing.T) {
+	tests := []struct {
+		desc    string
+		columns []*Column
+		opts    []Option
+		canvas  image.Rectangle
+		// update if not nil is called before the first draw.
+		update func(*Table) error
+		// events are delivered after the first draw.
+		events []terminalapi.Event
+		// wantCallbackErr indicates that the callbacks should return an error
+		// and that the last event should return it.
+		wantCallbackErr bool
+		want            func(size image.Point) *faketerm.Terminal
+		wantSelected    []int
+		wantActivated   []int
+		wantErr         bool
+		wantUpdateErr   bool
+	}{
+		{
+			desc:    "fails without columns",
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on nil column",
+			columns: []*Column{
+				nil,
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on invalid title",
+			columns: []*Column{
+				{Title: "a\nb"},
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative fixed width",
+			columns: []*Column{
+				{Title: "a", Width: FixedWidth(-1)},
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on percentage width too high",
+			columns: []*Column{
+				{Title: "a", Width: PercentWidth(101)},
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on unsupported column type",
+			columns: []*Column{
+				{Title: "a", Type: ColumnType(-1)},
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative column spacing",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			opts: []Option{
+				ColumnSpacing(-1),
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "fails when the keys aren't unique",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			opts: []Option{
+				ActivateShortcut(keyboard.Shortcut{Key: keyboard.KeyArrowUp}),
+			},
+			canvas:  image.Rect(0, 0, 12, 4),
+			wantErr: true,
+		},
+		{
+			desc: "SetRows fails when the row doesn't have a cell for each column",
+			columns: []*Column{
+				{Title: "a"},
+				{Title: "b"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{NewCell("a")},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "SetRows fails on a nil cell",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{nil},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "SetRows fails on text with control characters",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{NewCell("a\tb")},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "SetRows fails on a text cell in a number column",
+			columns: []*Column{
+				{Title: "a", Type: ColumnTypeNumber},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{NewCell("1")},
+				})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "AddRow fails on an invalid row",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.AddRow([]*Cell{NewCell("a"), NewCell("b")})
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "Select fails on a row that doesn't exist",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.Select(0)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "SortBy fails on a column that doesn't exist",
+			columns: []*Column{
+				{Title: "a"},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				return tbl.SortBy(1, false)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "draws only the header without rows",
+			columns: []*Column{
+				{Title: "Name"},
+				{Title: "Age", Width: FixedWidth(3), Align: align.HorizontalRight},
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the header and the rows",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rows that don't fit aren't drawn",
+			canvas: image.Rect(0, 0, 12, 3),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "hides the header",
+			opts: []Option{
+				HideHeader(),
+			},
+			canvas: image.Rect(0, 0, 12, 2),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "bob", "30", false)
+				mustRow(c, 1, "alice", "4", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom header cell options",
+			columns: []*Column{
+				{Title: "Name"},
+			},
+			opts: []Option{
+				HeaderCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "Name", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "applies cell options of individual cells",
+			columns: []*Column{
+				{Title: "a"},
+				{Title: "b"},
+			},
+			opts: []Option{
+				HideHeader(),
+			},
+			canvas: image.Rect(0, 0, 7, 1),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{NewCell("red", cell.FgColor(cell.ColorRed)), NewCell("blue", cell.FgColor(cell.ColorBlue))},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "red", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "bl…", image.Point{4, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims text that doesn't fit the column",
+			columns: []*Column{
+				{Title: "Description"},
+			},
+			opts: []Option{
+				HideHeader(),
+			},
+			canvas: image.Rect(0, 0, 5, 2),
+			update: func(tbl *Table) error {
+				return tbl.SetRows([][]*Cell{
+					{NewCell("abcdefgh")},
+					{NewCell("你好世界")},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "abcd…", image.Point{0, 0})
+				testdraw.MustText(c, "你好…", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "column widths and spacing",
+			columns: []*Column{
+				{Title: "a", Width: PercentWidth(50)},
+				{Title: "b", Width: FixedWidth(2)},
+				{Title: "c"},
+			},
+			opts: []Option{
+				ColumnSpacing(2),
+				HeaderCellOpts(),
+			},
+			canvas: image.Rect(0, 0, 14, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				// Width available to the columns is 10, a=5, b=2, c=3.
+				testdraw.MustText(c, "a", image.Point{0, 0})
+				testdraw.MustText(c, "b", image.Point{7, 0})
+				testdraw.MustText(c, "c", image.Point{11, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "key down selects the first row",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", true)
+				mustRow(c, 2, "alice", "4", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0},
+		},
+		{
+			desc:   "keys move the selection up and down",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0, 1, 2, 1},
+		},
+		{
+			desc: "custom select keys",
+			opts: []Option{
+				SelectShortcuts(
+					keyboard.Shortcut{Key: 'k'},
+					keyboard.Shortcut{Key: 'j'},
+					keyboard.Shortcut{Key: 'u'},
+					keyboard.Shortcut{Key: 'd'},
+				),
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: 'j'},
+				&terminalapi.Keyboard{Key: 'j'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0, 1},
+		},
+		{
+			desc:   "scrolls to keep the selected row visible",
+			canvas: image.Rect(0, 0, 12, 3),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "alice", "4", false)
+				mustRow(c, 2, "carol", "52", true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0, 1, 2},
+		},
+		{
+			desc:   "page down moves the selection by the visible rows",
+			canvas: image.Rect(0, 0, 12, 3),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", true)
+				mustRow(c, 2, "alice", "4", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0, 2, 0},
+		},
+		{
+			desc:   "enter activates the selected row",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				// No row is selected yet.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:  []int{0, 1},
+			wantActivated: []int{1},
+		},
+		{
+			desc:   "forwards errors from the callbacks",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			wantCallbackErr: true,
+		},
+		{
+			desc:   "mouse click selects a row",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: click(1, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", false)
+				mustRow(c, 3, "carol", "52", true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{2},
+		},
+		{
+			desc:   "double click activates a row",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: append(click(1, 2), click(1, 2)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:  []int{1},
+			wantActivated: []int{1},
+		},
+		{
+			desc:   "quick clicks on different rows don't activate",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: append(click(1, 1), click(1, 2)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []int{0, 1},
+		},
+		{
+			desc:   "mouse click below the rows is ignored",
+			canvas: image.Rect(0, 0, 12, 5),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: click(1, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking a title sorts the rows",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: click(1, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "Name ▲", image.Point{0, 0}, draw.TextCellOpts(cell.Bold()))
+				testdraw.MustText(c, "Age", image.Point{9, 0}, draw.TextCellOpts(cell.Bold()))
+				mustRow(c, 1, "alice", "4", false)
+				mustRow(c, 2, "bob", "30", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking the title again reverses the order",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				// Recognize two separate clicks instead of a double click.
+				tbl.gestures = gesture.New(mouse.ButtonLeft, image.ZR, gesture.MultiClickInterval(0))
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: append(click(10, 0), click(10, 0)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "▼")
+				mustRow(c, 1, "carol", "52", false)
+				mustRow(c, 2, "bob", "30", false)
+				mustRow(c, 3, "alice", "4", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "double clicking a title sorts the rows once",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: append(click(1, 0), click(1, 0)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "Name ▲", image.Point{0, 0}, draw.TextCellOpts(cell.Bold()))
+				testdraw.MustText(c, "Age", image.Point{9, 0}, draw.TextCellOpts(cell.Bold()))
+				mustRow(c, 1, "alice", "4", false)
+				mustRow(c, 2, "bob", "30", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "clicking a title doesn't sort when sorting is disabled",
+			opts: []Option{
+				DisableSorting(),
+			},
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: click(1, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", false)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "sorts numbers numerically and keeps the selection",
+			canvas: image.Rect(0, 0, 16, 4),
+			columns: []*Column{
+				{Title: "Name"},
+				{Title: "Age", Type: ColumnTypeNumber, Width: FixedWidth(7), Align: align.HorizontalRight},
+			},
+			update: func(tbl *Table) error {
+				_, rows := people()
+				if err := tbl.SetRows(rows); err != nil {
+					return err
+				}
+				if err := tbl.Select(0); err != nil {
+					return err
+				}
+				return tbl.SortBy(1, false)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age ▲")
+				mustRow(c, 1, "alice", "4", false)
+				mustRow(c, 2, "bob", "30", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "mouse wheel scrolls the rows",
+			canvas: image.Rect(0, 0, 12, 3),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				return tbl.SetRows(rows)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 1}, Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Position: image.Point{0, 1}, Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Position: image.Point{0, 1}, Button: mouse.ButtonWheelDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "alice", "4", false)
+				mustRow(c, 2, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "AddRow keeps the selection",
+			canvas: image.Rect(0, 0, 12, 4),
+			update: func(tbl *Table) error {
+				_, rows := people()
+				if err := tbl.SetRows(rows[:2]); err != nil {
+					return err
+				}
+				if err := tbl.Select(1); err != nil {
+					return err
+				}
+				return tbl.AddRow(rows[2])
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustHeader(c, "Age")
+				mustRow(c, 1, "bob", "30", false)
+				mustRow(c, 2, "alice", "4", true)
+				mustRow(c, 3, "carol", "52", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			columns := tc.columns
+			if columns == nil && !tc.wantErr {
+				columns, _ = people()
+			}
+			ct := &callbackTracker{wantErr: tc.wantCallbackErr}
+			opts := append([]Option{
+				OnSelect(ct.onSelect),
+				OnActivate(ct.onActivate),
+			}, tc.opts...)
+
+			tbl, err := New(columns, opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(tbl)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("tc.update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so the widget knows the layout.
+			if err := tbl.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = tbl.Keyboard(e, &widgetapi.EventMeta{Focused: true})
+				case *terminalapi.Mouse:
+					err = tbl.Mouse(e, &widgetapi.EventMeta{})
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if i == len(tc.events)-1 && tc.wantCallbackErr {
+					if err == nil {
+						t.Errorf("event %v => got nil error, want the error from the callback", ev)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("event %v => unexpected error: %v", ev, err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tbl.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if diff := pretty.Compare(tc.wantSelected, ct.selected); diff != "" {
+				t.Errorf("OnSelect => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantActivated, ct.activated); diff != "" {
+				t.Errorf("OnActivate => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSelected(t *testing.T) {
+	columns, rows := people()
+	tbl, err := New(columns)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tbl.SetRows(rows); err != nil {
+		t.Fatalf("SetRows => unexpected error: %v", err)
+	}
+	if _, ok := tbl.Selected(); ok {
+		t.Errorf("Selected => true, want false before any row is selected")
+	}
+
+	if err := tbl.Select(2); err != nil {
+		t.Fatalf("Select => unexpected error: %v", err)
+	}
+	if got, ok := tbl.Selected(); !ok || got != 2 {
+		t.Errorf("Selected => %v, %v, want 2, true", got, ok)
+	}
+
+	if err := tbl.SetRows(rows); err != nil {
+		t.Fatalf("SetRows => unexpected error: %v", err)
+	}
+	if _, ok := tbl.Selected(); ok {
+		t.Errorf("Selected => true, want false after SetRows")
+	}
+}
+
+func TestAddRowKeepsProvidedRows(t *testing.T) {
+	columns, rows := people()
+	tbl, err := New(columns)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// The slice has spare capacity for the added row.
+	provided := rows[:2]
+	if err := tbl.SetRows(provided); err != nil {
+		t.Fatalf("SetRows => unexpected error: %v", err)
+	}
+	if err := tbl.AddRow([]*Cell{NewCell("dave"), NewNumberCell(7)}); err != nil {
+		t.Fatalf("AddRow => unexpected error: %v", err)
+	}
+	if got, want := rows[2][0].text, "carol"; got != want {
+		t.Errorf("AddRow => modified the rows provided to SetRows, got row %q, want %q", got, want)
+	}
+}
+
+func TestRequestsRedraw(t *testing.T) {
+	columns, rows := people()
+	tbl, err := New(columns)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 12, 4))
+	if err := tbl.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := tbl.SetRows(rows[:2]); err != nil {
+		t.Fatalf("SetRows => unexpected error: %v", err)
+	}
+	if err := tbl.AddRow(rows[2]); err != nil {
+		t.Fatalf("AddRow => unexpected error: %v", err)
+	}
+	if got, want := requests, 2; got != want {
+		t.Errorf("Table requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "minimum size for the header and one row",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 2},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "minimum size without the header",
+			opts: []Option{
+				HideHeader(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			columns, _ := people()
+			tbl, err := New(columns, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := tbl.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
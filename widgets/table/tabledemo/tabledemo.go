@@ -0,0 +1,154 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary tabledemo displays a table of processes with a random CPU usage.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/tcell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/table"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// processes are the names of the processes displayed in the table.
+var processes = []string{
+	"init", "sshd", "bash", "vim", "go", "gopls", "chrome", "日本語", "termdash",
+	"systemd", "cron", "dockerd", "containerd", "nginx", "postgres",
+}
+
+// rows returns the rows of the table with random CPU usage.
+func rows(r *rand.Rand) [][]*table.Cell {
+	var res [][]*table.Cell
+	for i, p := range processes {
+		cpu := r.Float64() * 100
+		var cOpts []cell.Option
+		if cpu > 80 {
+			cOpts = append(cOpts, cell.FgColor(cell.ColorRed))
+		}
+		res = append(res, []*table.Cell{
+			table.NewNumberCell(float64(1000 + i)),
+			table.NewCell(p),
+			table.NewNumberCellText(cpu, fmt.Sprintf("%.1f%%", cpu), cOpts...),
+		})
+	}
+	return res
+}
+
+// update periodically replaces the rows of the table.
+// Exits when the context expires.
+func update(ctx context.Context, tbl *table.Table, delay time.Duration) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sel, ok := tbl.Selected()
+			if err := tbl.SetRows(rows(r)); err != nil {
+				panic(err)
+			}
+			if ok {
+				if err := tbl.Select(sel); err != nil {
+					panic(err)
+				}
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := tcell.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	status, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := status.Write("Select a process with the arrow keys or the mouse, press Enter or double click to activate it. Click on a title to sort."); err != nil {
+		panic(err)
+	}
+
+	tbl, err := table.New(
+		[]*table.Column{
+			{Title: "PID", Type: table.ColumnTypeNumber, Width: table.FixedWidth(6)},
+			{Title: "Name"},
+			{Title: "CPU", Type: table.ColumnTypeNumber, Width: table.PercentWidth(20), Align: align.HorizontalRight},
+		},
+		table.OnSelect(func(row int) error {
+			return status.Write(fmt.Sprintf("Selected %s.", processes[row]), text.WriteReplace())
+		}),
+		table.OnActivate(func(row int) error {
+			return status.Write(fmt.Sprintf("Activated %s.", processes[row]), text.WriteReplace())
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := tbl.SetRows(rows(rand.New(rand.NewSource(time.Now().Unix())))); err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go update(ctx, tbl, 2*time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(linestyle.Light),
+				container.BorderTitle("Processes"),
+				container.PlaceWidget(tbl),
+				container.Focused(),
+			),
+			container.Bottom(
+				container.PlaceWidget(status),
+			),
+			container.SplitPercent(80),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -40,10 +40,10 @@ type options struct {
 	disableScrolling bool
 	mouseUpButton    mouse.Button
 	mouseDownButton  mouse.Button
-	keyUp            keyboard.Key
-	keyDown          keyboard.Key
-	keyPgUp          keyboard.Key
-	keyPgDown        keyboard.Key
+	keyUp            keyboard.Shortcut
+	keyDown          keyboard.Shortcut
+	keyPgUp          keyboard.Shortcut
+	keyPgDown        keyboard.Shortcut
 }
 
 // newOptions returns a new options instance.
@@ -53,10 +53,10 @@ func newOptions(opts ...Option) *options {
 		scrollDown:      DefaultScrollDownRune,
 		mouseUpButton:   DefaultScrollMouseButtonUp,
 		mouseDownButton: DefaultScrollMouseButtonDown,
-		keyUp:           DefaultScrollKeyUp,
-		keyDown:         DefaultScrollKeyDown,
-		keyPgUp:         DefaultScrollKeyPageUp,
-		keyPgDown:       DefaultScrollKeyPageDown,
+		keyUp:           keyboard.Shortcut{Key: DefaultScrollKeyUp},
+		keyDown:         keyboard.Shortcut{Key: DefaultScrollKeyDown},
+		keyPgUp:         keyboard.Shortcut{Key: DefaultScrollKeyPageUp},
+		keyPgDown:       keyboard.Shortcut{Key: DefaultScrollKeyPageDown},
 		maxTextCells:    DefaultMaxTextCells,
 	}
 	for _, o := range opts {
@@ -67,7 +67,7 @@ func newOptions(opts ...Option) *options {
 
 // validate validates the provided options.
 func (o *options) validate() error {
-	keys := map[keyboard.Key]bool{
+	keys := map[keyboard.Shortcut]bool{
 		o.keyUp:     true,
 		o.keyDown:   true,
 		o.keyPgUp:   true,
@@ -170,8 +170,20 @@ const (
 
 // ScrollKeys configures the keyboard keys that scroll the content.
 // The provided keys must be unique, e.g. the same key cannot be both up and
-// down.
+// down. The keys only scroll the content when pressed without any modifier
+// keys, use ScrollShortcuts to configure keys with modifiers.
 func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
+	return ScrollShortcuts(
+		keyboard.Shortcut{Key: up},
+		keyboard.Shortcut{Key: down},
+		keyboard.Shortcut{Key: pageUp},
+		keyboard.Shortcut{Key: pageDown},
+	)
+}
+
+// ScrollShortcuts is like ScrollKeys, but the keys must be pressed together
+// with the specified modifier keys.
+func ScrollShortcuts(up, down, pageUp, pageDown keyboard.Shortcut) Option {
 	return option(func(opts *options) {
 		opts.keyUp = up
 		opts.keyDown = down
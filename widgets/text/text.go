@@ -57,6 +57,10 @@ type Text struct {
 	// invalidated.
 	contentChanged bool
 
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// mu protects the Text widget.
 	mu sync.Mutex
 
@@ -81,6 +85,7 @@ func (t *Text) Reset() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.reset()
+	t.invalidator.Invalidate()
 }
 
 // reset implements Reset, caller must hold t.mu.
@@ -136,6 +141,7 @@ func (t *Text) Write(text string, wOpts ...WriteOption) error {
 		t.content = append(t.content, buffer.NewCell(r, opts.cellOpts))
 	}
 	t.contentChanged = true
+	t.invalidator.Invalidate()
 	return nil
 }
 
@@ -233,6 +239,7 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.invalidator = meta.Invalidator
 
 	width := cvs.Area().Dx()
 	if len(t.content) > 0 && (t.contentChanged || t.lastWidth != width) {
@@ -262,14 +269,14 @@ func (t *Text) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) erro
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	switch {
-	case k.Key == t.opts.keyUp:
+	switch sc := k.Shortcut(); {
+	case sc == t.opts.keyUp:
 		t.scroll.upOneLine()
-	case k.Key == t.opts.keyDown:
+	case sc == t.opts.keyDown:
 		t.scroll.downOneLine()
-	case k.Key == t.opts.keyPgUp:
+	case sc == t.opts.keyPgUp:
 		t.scroll.upOnePage()
-	case k.Key == t.opts.keyPgDown:
+	case sc == t.opts.keyPgDown:
 		t.scroll.downOnePage()
 	}
 	return nil
@@ -449,6 +449,60 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "scrolls down using custom shortcut a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				ScrollShortcuts(
+					keyboard.Shortcut{Key: keyboard.KeyArrowUp, Modifiers: keyboard.ModCtrl},
+					keyboard.Shortcut{Key: keyboard.KeyArrowDown, Modifiers: keyboard.ModCtrl},
+					keyboard.Shortcut{Key: keyboard.KeyPgUp, Modifiers: keyboard.ModCtrl},
+					keyboard.Shortcut{Key: keyboard.KeyPgDn, Modifiers: keyboard.ModCtrl},
+				),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key:       keyboard.KeyArrowDown,
+					Modifiers: keyboard.ModCtrl,
+				}, &widgetapi.EventMeta{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't scroll when the key is pressed with a modifier",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key:       keyboard.KeyArrowDown,
+					Modifiers: keyboard.ModShift,
+				}, &widgetapi.EventMeta{})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "scrolls down using custom key a page at a time",
 			canvas: image.Rect(0, 0, 10, 3),
@@ -1021,7 +1075,12 @@ func TestTextDraws(t *testing.T) {
 				tc.events(widget)
 			}
 
-			if err := widget.Draw(c, tc.meta); err != nil {
+			meta := tc.meta
+			if meta == nil {
+				// The infrastructure guarantees a non-nil meta.
+				meta = &widgetapi.Meta{}
+			}
+			if err := widget.Draw(c, meta); err != nil {
 				t.Fatalf("Draw => unexpected error: %v", err)
 			}
 
@@ -1041,6 +1100,29 @@ func TestTextDraws(t *testing.T) {
 	}
 }
 
+func TestRequestsRedrawOnWrite(t *testing.T) {
+	widget, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	if err := widget.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := widget.Write("hello"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	widget.Reset()
+	if got, want := requests, 2; got != want {
+		t.Errorf("Text requested %d redraws, want %d", got, want)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
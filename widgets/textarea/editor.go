@@ -0,0 +1,390 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textarea
+
+// editor.go contains data types that edit the content of the text area.
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// position is a position of the cursor within the lines.
+type position struct {
+	// line is the index of the line.
+	line int
+	// col is the index of the rune within the line the cursor is on. Equal to
+	// the length of the line when the cursor is after the last rune.
+	col int
+}
+
+// row is a row of the text area, i.e. a part of a line after soft wrapping.
+type row struct {
+	// line is the index of the line the row belongs to.
+	line int
+	// start and end are the indexes of the runes of the line on the row,
+	// start <= idx < end.
+	start, end int
+	// last indicates that this is the last row of the line.
+	last bool
+}
+
+// editor maintains the cursor position and allows editing of the lines in the
+// text area.
+// This object isn't thread-safe.
+type editor struct {
+	// lines are the lines of text, there is always at least one line.
+	lines [][]rune
+
+	// cur is the current position of the cursor.
+	cur position
+
+	// goalX is the cell on the row the cursor returns to when moving up or
+	// down across rows that are shorter. Negative when not set.
+	goalX int
+
+	// onChange if provided is the handler called when the lines change.
+	onChange ChangeFn
+}
+
+// newEditor returns a new editor instance.
+func newEditor(onChange ChangeFn) *editor {
+	return &editor{
+		lines:    [][]rune{nil},
+		goalX:    -1,
+		onChange: onChange,
+	}
+}
+
+// content returns the lines joined with newline characters.
+func (e *editor) content() string {
+	var b strings.Builder
+	for i, l := range e.lines {
+		if i > 0 {
+			b.WriteRune('\n')
+		}
+		b.WriteString(string(l))
+	}
+	return b.String()
+}
+
+// reset resets the content back to zero.
+func (e *editor) reset() {
+	*e = *newEditor(e.onChange)
+}
+
+// changed calls the onChange handler if provided.
+func (e *editor) changed() {
+	if e.onChange != nil {
+		e.onChange(e.content())
+	}
+}
+
+// insertRune inserts the rune at the position of the cursor without calling
+// the onChange handler. Returns true if the rune was inserted.
+func (e *editor) insertRune(r rune) bool {
+	e.goalX = -1
+	if r == '\n' {
+		l := e.lines[e.cur.line]
+		after := append([]rune{}, l[e.cur.col:]...)
+		e.lines[e.cur.line] = l[:e.cur.col]
+
+		e.lines = append(e.lines, nil)
+		copy(e.lines[e.cur.line+2:], e.lines[e.cur.line+1:])
+		e.lines[e.cur.line+1] = after
+		e.cur = position{line: e.cur.line + 1}
+		return true
+	}
+	if runewidth.RuneWidth(r) == 0 {
+		// Don't insert invisible runes.
+		return false
+	}
+
+	l := e.lines[e.cur.line]
+	l = append(l, 0)
+	copy(l[e.cur.col+1:], l[e.cur.col:])
+	l[e.cur.col] = r
+	e.lines[e.cur.line] = l
+	e.cur.col++
+	return true
+}
+
+// insert inserts the rune at the current position of the cursor. The newline
+// rune splits the line.
+func (e *editor) insert(r rune) {
+	if e.insertRune(r) {
+		e.changed()
+	}
+}
+
+// insertAll inserts the runes at the current position of the cursor.
+// Unlike insert, calls the onChange handler only once after all the runes
+// were inserted.
+func (e *editor) insertAll(rs []rune) {
+	changed := false
+	for _, r := range rs {
+		if e.insertRune(r) {
+			changed = true
+		}
+	}
+	if changed {
+		e.changed()
+	}
+}
+
+// delete deletes the rune at the current position of the cursor. Joins the
+// next line to the current one if the cursor is at the end of the line.
+func (e *editor) delete() {
+	e.goalX = -1
+	l := e.lines[e.cur.line]
+	switch {
+	case e.cur.col < len(l):
+		e.lines[e.cur.line] = append(l[:e.cur.col], l[e.cur.col+1:]...)
+
+	case e.cur.line < len(e.lines)-1:
+		e.lines[e.cur.line] = append(l, e.lines[e.cur.line+1]...)
+		e.lines = append(e.lines[:e.cur.line+1], e.lines[e.cur.line+2:]...)
+
+	default:
+		// Cursor at the end of the text, nothing to do.
+		return
+	}
+	e.changed()
+}
+
+// deleteBefore deletes the rune that is immediately to the left of the cursor.
+func (e *editor) deleteBefore() {
+	if e.cur == (position{}) {
+		// Cursor at the beginning, nothing to do.
+		return
+	}
+	e.cursorLeft()
+	e.delete()
+}
+
+// cursorLeft moves the cursor one rune to the left, onto the end of the
+// previous line if at the start of a line.
+func (e *editor) cursorLeft() {
+	e.goalX = -1
+	switch {
+	case e.cur.col > 0:
+		e.cur.col--
+	case e.cur.line > 0:
+		e.cur.line--
+		e.cur.col = len(e.lines[e.cur.line])
+	}
+}
+
+// cursorRight moves the cursor one rune to the right, onto the start of the
+// next line if at the end of a line.
+func (e *editor) cursorRight() {
+	e.goalX = -1
+	switch {
+	case e.cur.col < len(e.lines[e.cur.line]):
+		e.cur.col++
+	case e.cur.line < len(e.lines)-1:
+		e.cur = position{line: e.cur.line + 1}
+	}
+}
+
+// cursorLineStart moves the cursor to the beginning of the line.
+func (e *editor) cursorLineStart() {
+	e.goalX = -1
+	e.cur.col = 0
+}
+
+// cursorLineEnd moves the cursor to the end of the line.
+func (e *editor) cursorLineEnd() {
+	e.goalX = -1
+	e.cur.col = len(e.lines[e.cur.line])
+}
+
+// cursorStart moves the cursor to the beginning of the text.
+func (e *editor) cursorStart() {
+	e.goalX = -1
+	e.cur = position{}
+}
+
+// cursorEnd moves the cursor to the end of the text.
+func (e *editor) cursorEnd() {
+	e.goalX = -1
+	last := len(e.lines) - 1
+	e.cur = position{line: last, col: len(e.lines[last])}
+}
+
+// isWordRune asserts whether the rune is part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// runeAt returns the rune at the position, the end of a line is reported as a
+// newline character.
+func (e *editor) runeAt(p position) rune {
+	if l := e.lines[p.line]; p.col < len(l) {
+		return l[p.col]
+	}
+	return '\n'
+}
+
+// cursorWordLeft moves the cursor onto the beginning of the current or the
+// previous word.
+func (e *editor) cursorWordLeft() {
+	before := func() (rune, bool) {
+		if e.cur == (position{}) {
+			return 0, false
+		}
+		e.cursorLeft()
+		r := e.runeAt(e.cur)
+		e.cursorRight()
+		return r, true
+	}
+
+	for r, ok := before(); ok && !isWordRune(r); r, ok = before() {
+		e.cursorLeft()
+	}
+	for r, ok := before(); ok && isWordRune(r); r, ok = before() {
+		e.cursorLeft()
+	}
+	e.goalX = -1
+}
+
+// cursorWordRight moves the cursor onto the end of the current or the next
+// word.
+func (e *editor) cursorWordRight() {
+	atEnd := func() bool {
+		last := len(e.lines) - 1
+		return e.cur == position{line: last, col: len(e.lines[last])}
+	}
+
+	for !atEnd() && !isWordRune(e.runeAt(e.cur)) {
+		e.cursorRight()
+	}
+	for !atEnd() && isWordRune(e.runeAt(e.cur)) {
+		e.cursorRight()
+	}
+}
+
+// rows returns the rows of text after wrapping the lines at rune boundaries to
+// the width. Lines aren't wrapped if the width is zero.
+//
+// The cursor can be after the last rune of a line that fills the whole width.
+// In that case an additional empty row is added after the line so that the
+// cursor can be displayed.
+func (e *editor) rows(width int) []*row {
+	var res []*row
+	for i, l := range e.lines {
+		if len(l) == 0 || width <= 0 {
+			res = append(res, &row{line: i, start: 0, end: len(l), last: true})
+			continue
+		}
+
+		wrapped, err := wrap.Cells(buffer.NewCells(string(l)), width, wrap.AtRunes)
+		if err != nil {
+			// The editor only contains valid runes, fall back to a single
+			// row just in case.
+			wrapped = [][]*buffer.Cell{buffer.NewCells(string(l))}
+		}
+		start := 0
+		for _, cells := range wrapped {
+			res = append(res, &row{line: i, start: start, end: start + len(cells)})
+			start += len(cells)
+		}
+		lastRow := res[len(res)-1]
+		lastRow.last = true
+
+		if i == e.cur.line && e.cur.col == len(l) && runewidth.StringWidth(string(l[lastRow.start:])) >= width {
+			lastRow.last = false
+			res = append(res, &row{line: i, start: len(l), end: len(l), last: true})
+		}
+	}
+	return res
+}
+
+// contains asserts whether the cursor at the position is displayed on the
+// row.
+func (r *row) contains(p position) bool {
+	if p.line != r.line || p.col < r.start {
+		return false
+	}
+	return p.col < r.end || (r.last && p.col == r.end)
+}
+
+// cursorRow returns the index of the row the cursor is on and the cell on the
+// row where it is displayed.
+func (e *editor) cursorRow(rows []*row) (int, int) {
+	for i, r := range rows {
+		if r.contains(e.cur) {
+			l := e.lines[r.line]
+			return i, runewidth.StringWidth(string(l[r.start:e.cur.col]))
+		}
+	}
+	return 0, 0
+}
+
+// colAt returns the index of the rune displayed at the cell on the row.
+// Returns the end of the row if the cell is after the text on the row.
+func (e *editor) colAt(r *row, cellX int) int {
+	l := e.lines[r.line]
+	x := 0
+	for col := r.start; col < r.end; col++ {
+		x += runewidth.RuneWidth(l[col])
+		if x > cellX {
+			return col
+		}
+	}
+	if !r.last && r.end > r.start {
+		// The end of the row is displayed at the start of the next row.
+		return r.end - 1
+	}
+	return r.end
+}
+
+// cursorRows moves the cursor n rows down or up if n is negative when the
+// lines are wrapped to the width. The cursor remains on the same cell of the
+// row if possible.
+func (e *editor) cursorRows(width, n int) {
+	rows := e.rows(width)
+	idx, x := e.cursorRow(rows)
+	if e.goalX < 0 {
+		e.goalX = x
+	}
+
+	target := idx + n
+	if target < 0 {
+		target = 0
+	}
+	if target > len(rows)-1 {
+		target = len(rows) - 1
+	}
+	r := rows[target]
+	e.cur = position{line: r.line, col: e.colAt(r, e.goalX)}
+}
+
+// cursorCell moves the cursor onto the cell of the row when the lines are
+// wrapped to the width. Moves the cursor onto the last row if the row
+// doesn't exist.
+func (e *editor) cursorCell(width, rowIdx, cellX int) {
+	rows := e.rows(width)
+	if rowIdx > len(rows)-1 {
+		rowIdx = len(rows) - 1
+	}
+	r := rows[rowIdx]
+	e.goalX = -1
+	e.cur = position{line: r.line, col: e.colAt(r, cellX)}
+}
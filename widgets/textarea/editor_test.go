@@ -0,0 +1,368 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textarea
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// newEditorWith returns an editor with the text and the cursor at the
+// position.
+func newEditorWith(text string, cur position) *editor {
+	e := newEditor(nil)
+	e.insertAll([]rune(text))
+	e.cur = cur
+	return e
+}
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		cur  position
+		// edit applies the edits to the editor.
+		edit        func(e *editor)
+		want        string
+		wantCur     position
+		wantChanges []string
+	}{
+		{
+			desc: "inserts runes and new lines",
+			edit: func(e *editor) {
+				for _, r := range "ab\nc" {
+					e.insert(r)
+				}
+			},
+			want:        "ab\nc",
+			wantCur:     position{line: 1, col: 1},
+			wantChanges: []string{"a", "ab", "ab\n", "ab\nc"},
+		},
+		{
+			desc: "splits the line at the cursor",
+			text: "abcd",
+			cur:  position{line: 0, col: 2},
+			edit: func(e *editor) {
+				e.insert('\n')
+			},
+			want:        "ab\ncd",
+			wantCur:     position{line: 1, col: 0},
+			wantChanges: []string{"ab\ncd"},
+		},
+		{
+			desc: "insertAll calls the change handler once",
+			text: "ad",
+			cur:  position{line: 0, col: 1},
+			edit: func(e *editor) {
+				e.insertAll([]rune("b\nc"))
+			},
+			want:        "ab\ncd",
+			wantCur:     position{line: 1, col: 1},
+			wantChanges: []string{"ab\ncd"},
+		},
+		{
+			desc: "doesn't insert invisible runes",
+			edit: func(e *editor) {
+				e.insert(0x08)
+			},
+			want: "",
+		},
+		{
+			desc: "deletes the rune under the cursor",
+			text: "abc",
+			cur:  position{line: 0, col: 1},
+			edit: func(e *editor) {
+				e.delete()
+			},
+			want:        "ac",
+			wantCur:     position{line: 0, col: 1},
+			wantChanges: []string{"ac"},
+		},
+		{
+			desc: "delete at the end of line joins the next line",
+			text: "ab\ncd",
+			cur:  position{line: 0, col: 2},
+			edit: func(e *editor) {
+				e.delete()
+			},
+			want:        "abcd",
+			wantCur:     position{line: 0, col: 2},
+			wantChanges: []string{"abcd"},
+		},
+		{
+			desc: "delete at the end of text does nothing",
+			text: "ab",
+			cur:  position{line: 0, col: 2},
+			edit: func(e *editor) {
+				e.delete()
+			},
+			want:    "ab",
+			wantCur: position{line: 0, col: 2},
+		},
+		{
+			desc: "deleteBefore at the start of line joins the previous line",
+			text: "ab\ncd",
+			cur:  position{line: 1, col: 0},
+			edit: func(e *editor) {
+				e.deleteBefore()
+			},
+			want:        "abcd",
+			wantCur:     position{line: 0, col: 2},
+			wantChanges: []string{"abcd"},
+		},
+		{
+			desc: "deleteBefore at the start of text does nothing",
+			text: "ab",
+			edit: func(e *editor) {
+				e.deleteBefore()
+			},
+			want: "ab",
+		},
+		{
+			desc: "cursor left and right cross lines",
+			text: "ab\ncd",
+			cur:  position{line: 1, col: 0},
+			edit: func(e *editor) {
+				e.cursorLeft()
+				e.cursorLeft()
+				e.cursorRight()
+				e.cursorRight()
+				e.cursorRight()
+			},
+			want:    "ab\ncd",
+			wantCur: position{line: 1, col: 1},
+		},
+		{
+			desc: "cursor stays within the text",
+			text: "ab",
+			edit: func(e *editor) {
+				e.cursorLeft()
+				e.cursorEnd()
+				e.cursorRight()
+			},
+			want:    "ab",
+			wantCur: position{line: 0, col: 2},
+		},
+		{
+			desc: "line start and end",
+			text: "ab\ncde",
+			cur:  position{line: 1, col: 1},
+			edit: func(e *editor) {
+				e.cursorLineEnd()
+			},
+			want:    "ab\ncde",
+			wantCur: position{line: 1, col: 3},
+		},
+		{
+			desc: "word right moves to the end of words",
+			text: "foo bar\n  baz",
+			edit: func(e *editor) {
+				e.cursorWordRight()
+				e.cursorWordRight()
+				e.cursorWordRight()
+			},
+			want:    "foo bar\n  baz",
+			wantCur: position{line: 1, col: 5},
+		},
+		{
+			desc: "word left moves to the start of words",
+			text: "foo bar\n  baz",
+			cur:  position{line: 1, col: 4},
+			edit: func(e *editor) {
+				e.cursorWordLeft()
+				e.cursorWordLeft()
+			},
+			want:    "foo bar\n  baz",
+			wantCur: position{line: 0, col: 4},
+		},
+		{
+			desc: "word left stops at the start of text",
+			text: "  foo",
+			cur:  position{line: 0, col: 1},
+			edit: func(e *editor) {
+				e.cursorWordLeft()
+			},
+			want: "  foo",
+		},
+		{
+			desc: "cursor down keeps the cell across wrapped rows",
+			text: "abcdef\nxy\nuvwxyz",
+			cur:  position{line: 0, col: 2},
+			edit: func(e *editor) {
+				e.cursorRows(4, 1) // "ef"
+				e.cursorRows(4, 1) // "xy"
+				e.cursorRows(4, 1) // "uvwx"
+			},
+			want:    "abcdef\nxy\nuvwxyz",
+			wantCur: position{line: 2, col: 2},
+		},
+		{
+			desc: "cursor up moves onto the end of a shorter row",
+			text: "abcdef",
+			cur:  position{line: 0, col: 6},
+			edit: func(e *editor) {
+				e.cursorRows(4, -1)
+			},
+			want:    "abcdef",
+			wantCur: position{line: 0, col: 2},
+		},
+		{
+			desc: "cursor up from a longer row stays on the row",
+			text: "abcdef",
+			cur:  position{line: 0, col: 5},
+			edit: func(e *editor) {
+				e.cursorRows(3, -1)
+			},
+			want:    "abcdef",
+			wantCur: position{line: 0, col: 2},
+		},
+		{
+			desc: "page down stops at the last row",
+			text: "a\nb\nc",
+			edit: func(e *editor) {
+				e.cursorRows(4, 10)
+			},
+			want:    "a\nb\nc",
+			wantCur: position{line: 2, col: 0},
+		},
+		{
+			desc: "moves onto the cell",
+			text: "abcdef\nxy",
+			edit: func(e *editor) {
+				e.cursorCell(4, 1, 1)
+			},
+			want:    "abcdef\nxy",
+			wantCur: position{line: 0, col: 5},
+		},
+		{
+			desc: "moves after the text on the last row",
+			text: "abcdef\nxy",
+			edit: func(e *editor) {
+				e.cursorCell(4, 5, 3)
+			},
+			want:    "abcdef\nxy",
+			wantCur: position{line: 1, col: 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := newEditorWith(tc.text, tc.cur)
+			var changes []string
+			e.onChange = func(text string) {
+				changes = append(changes, text)
+			}
+			tc.edit(e)
+
+			if got := e.content(); got != tc.want {
+				t.Errorf("content => %q, want %q", got, tc.want)
+			}
+			if diff := pretty.Compare(tc.wantCur, e.cur); diff != "" {
+				t.Errorf("cursor => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantChanges, changes); diff != "" {
+				t.Errorf("onChange => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	tests := []struct {
+		desc    string
+		text    string
+		cur     position
+		width   int
+		want    []*row
+		wantRow int
+		wantX   int
+	}{
+		{
+			desc:  "lines that fit",
+			text:  "ab\n\ncd",
+			cur:   position{line: 2, col: 1},
+			width: 4,
+			want: []*row{
+				{line: 0, start: 0, end: 2, last: true},
+				{line: 1, start: 0, end: 0, last: true},
+				{line: 2, start: 0, end: 2, last: true},
+			},
+			wantRow: 2,
+			wantX:   1,
+		},
+		{
+			desc:  "doesn't wrap with zero width",
+			text:  "abcdef",
+			width: 0,
+			want: []*row{
+				{line: 0, start: 0, end: 6, last: true},
+			},
+		},
+		{
+			desc:  "wraps long lines",
+			text:  "abcdefg",
+			cur:   position{line: 0, col: 4},
+			width: 3,
+			want: []*row{
+				{line: 0, start: 0, end: 3},
+				{line: 0, start: 3, end: 6},
+				{line: 0, start: 6, end: 7, last: true},
+			},
+			wantRow: 1,
+			wantX:   1,
+		},
+		{
+			desc:  "wraps full-width runes",
+			text:  "世界x",
+			cur:   position{line: 0, col: 1},
+			width: 3,
+			want: []*row{
+				{line: 0, start: 0, end: 1},
+				{line: 0, start: 1, end: 3, last: true},
+			},
+			wantRow: 1,
+			wantX:   0,
+		},
+		{
+			desc:  "adds a row for the cursor after a full line",
+			text:  "abc\nd",
+			cur:   position{line: 0, col: 3},
+			width: 3,
+			want: []*row{
+				{line: 0, start: 0, end: 3},
+				{line: 0, start: 3, end: 3, last: true},
+				{line: 1, start: 0, end: 1, last: true},
+			},
+			wantRow: 1,
+			wantX:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := newEditorWith(tc.text, tc.cur)
+			got := e.rows(tc.width)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("rows => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			gotRow, gotX := e.cursorRow(got)
+			if gotRow != tc.wantRow || gotX != tc.wantX {
+				t.Errorf("cursorRow => %d, %d, want %d, %d", gotRow, gotX, tc.wantRow, tc.wantX)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textarea
+
+// options.go contains configurable options for TextArea.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	textCellOpts       []cell.Option
+	cursorCellOpts     []cell.Option
+	placeHolder        string
+	placeHolderOpts    []cell.Option
+	lineNumbers        bool
+	lineNumberCellOpts []cell.Option
+	defaultText        string
+
+	submitKey                keyboard.Shortcut
+	onSubmit                 SubmitFn
+	onChange                 ChangeFn
+	clearOnSubmit            bool
+	exclusiveKeyboardOnFocus bool
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.placeHolder != "" {
+		if err := wrap.ValidText(o.placeHolder); err != nil {
+			return fmt.Errorf("invalid PlaceHolder: %v", err)
+		}
+	}
+	if o.defaultText != "" {
+		if err := wrap.ValidText(o.defaultText); err != nil {
+			return fmt.Errorf("invalid DefaultText: %v", err)
+		}
+	}
+	if o.submitKey == (keyboard.Shortcut{Key: keyboard.KeyEnter}) {
+		return fmt.Errorf("invalid SubmitKey(%v), the Enter key inserts a new line", o.submitKey)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		cursorCellOpts:     []cell.Option{cell.Inverse()},
+		placeHolderOpts:    []cell.Option{cell.FgColor(cell.ColorNumber(DefaultPlaceHolderColorNumber))},
+		lineNumberCellOpts: []cell.Option{cell.FgColor(cell.ColorNumber(DefaultLineNumberColorNumber))},
+		submitKey:          keyboard.Shortcut{Key: DefaultSubmitKey},
+	}
+}
+
+// TextCellOpts sets the cell options for the text.
+func TextCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.textCellOpts = cOpts
+	})
+}
+
+// CursorCellOpts sets the cell options for the cell the cursor is on.
+// Defaults to inverse colors.
+func CursorCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cursorCellOpts = cOpts
+	})
+}
+
+// DefaultPlaceHolderColorNumber is the default color number for the text of
+// the PlaceHolder option.
+const DefaultPlaceHolderColorNumber = 194
+
+// PlaceHolder sets text to be displayed when the text area isn't focused and
+// is empty. The text may contain newline characters.
+func PlaceHolder(text string, cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.placeHolder = text
+		if len(cOpts) > 0 {
+			opts.placeHolderOpts = cOpts
+		}
+	})
+}
+
+// DefaultLineNumberColorNumber is the default color number for the line
+// numbers.
+const DefaultLineNumberColorNumber = 244
+
+// LineNumbers displays a gutter with the numbers of the lines on the left
+// side of the text. Lines that are wrapped onto multiple rows are only
+// numbered on their first row.
+func LineNumbers() Option {
+	return option(func(opts *options) {
+		opts.lineNumbers = true
+	})
+}
+
+// LineNumberCellOpts sets the cell options for the line numbers.
+// Defaults to DefaultLineNumberColorNumber.
+func LineNumberCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.lineNumberCellOpts = cOpts
+	})
+}
+
+// DefaultText sets the text initially displayed in the text area. The text
+// may contain newline characters.
+func DefaultText(text string) Option {
+	return option(func(opts *options) {
+		opts.defaultText = text
+	})
+}
+
+// DefaultSubmitKey is the default key that submits the text.
+const DefaultSubmitKey = keyboard.KeyCtrlS
+
+// SubmitKey sets the keyboard shortcut that submits the text. Must not be the
+// Enter key without modifiers, which inserts a new line.
+// Defaults to DefaultSubmitKey.
+func SubmitKey(s keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.submitKey = s
+	})
+}
+
+// SubmitFn if provided is called when the user submits the text with the
+// submit key.
+// The callback function must be thread-safe as the keyboard event that
+// triggers the submission comes from a separate goroutine.
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type SubmitFn func(text string) error
+
+// OnSubmit sets a function that will be called with the text typed by the user
+// when they press the submit key.
+func OnSubmit(fn SubmitFn) Option {
+	return option(func(opts *options) {
+		opts.onSubmit = fn
+	})
+}
+
+// ChangeFn if provided is called when the content of the text area changes
+// due to user input, the argument is the new content.
+// The callback function must be thread-safe as the keyboard event that
+// triggers the change comes from a separate goroutine. The function must not
+// call methods of the text area.
+type ChangeFn func(text string)
+
+// OnChange sets a function that will be called when the content of the text
+// area changes.
+func OnChange(fn ChangeFn) Option {
+	return option(func(opts *options) {
+		opts.onChange = fn
+	})
+}
+
+// ClearOnSubmit sets the text area to be cleared when the text is submitted.
+func ClearOnSubmit() Option {
+	return option(func(opts *options) {
+		opts.clearOnSubmit = true
+	})
+}
+
+// ExclusiveKeyboardOnFocus when set ensures that when this widget is focused,
+// no other widget receives any keyboard events.
+func ExclusiveKeyboardOnFocus() Option {
+	return option(func(opts *options) {
+		opts.exclusiveKeyboardOnFocus = true
+	})
+}
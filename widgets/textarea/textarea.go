@@ -0,0 +1,403 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package textarea implements a widget that edits multiple lines of text.
+package textarea
+
+import (
+	"image"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// TextArea allows the user to edit multiple lines of text.
+//
+// The lines are soft wrapped at rune boundaries to the width of the widget
+// and the text scrolls vertically to keep the cursor visible. The text can be
+// navigated using arrows, the Home, End, PgUp and PgDn keys, word by word
+// with the Ctrl or Alt key held together with the left and right arrows and
+// using mouse. The Enter key inserts a new line, the text is submitted with
+// the key set by the SubmitKey option or read at any time by calling Read.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type TextArea struct {
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// editor tracks the edits and the state of the text area.
+	editor *editor
+
+	// offset is the index of the first displayed row.
+	offset int
+	// followCursor indicates that the offset must be adjusted so that the
+	// cursor is visible. Unset when the rows are scrolled with the mouse.
+	followCursor bool
+
+	// textAr is the area that was occupied by the text last time Draw() was
+	// called.
+	textAr image.Rectangle
+	// numRows is the number of rows last time Draw() was called.
+	numRows int
+
+	// invalidator is used to request a redraw when the text changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new TextArea.
+func New(opts ...Option) (*TextArea, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	ta := &TextArea{
+		editor:       newEditor(opt.onChange),
+		followCursor: true,
+		opts:         opt,
+	}
+	ta.editor.insertAll([]rune(opt.defaultText))
+	return ta, nil
+}
+
+// Read reads the content of the text area. The lines are separated by
+// newline characters.
+func (ta *TextArea) Read() string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	return ta.editor.content()
+}
+
+// ReadAndClear reads the content of the text area and clears it.
+func (ta *TextArea) ReadAndClear() string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	c := ta.editor.content()
+	ta.editor.reset()
+	ta.offset = 0
+	ta.invalidator.Invalidate()
+	return c
+}
+
+// SetText replaces the content of the text area and places the cursor at the
+// end of the text. Doesn't call the ChangeFn.
+// The text may contain newline characters and must follow the rules of
+// wrap.ValidText unless empty.
+func (ta *TextArea) SetText(text string) error {
+	if text != "" {
+		if err := wrap.ValidText(text); err != nil {
+			return err
+		}
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	onChange := ta.editor.onChange
+	ta.editor = newEditor(nil)
+	ta.editor.insertAll([]rune(text))
+	ta.editor.onChange = onChange
+	ta.followCursor = true
+	ta.invalidator.Invalidate()
+	return nil
+}
+
+// gutterWidth returns the width of the gutter with line numbers.
+// ta.mu must be held when calling this method.
+func (ta *TextArea) gutterWidth() int {
+	if !ta.opts.lineNumbers {
+		return 0
+	}
+	// The numbers are followed by a space.
+	return len(strconv.Itoa(len(ta.editor.lines))) + 1
+}
+
+// minTextWidth is the minimum width of the text area, enough for one
+// full-width rune.
+const minTextWidth = 2
+
+// Draw draws the TextArea widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (ta *TextArea) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.invalidator = meta.Invalidator
+
+	ar := cvs.Area()
+	gutter := ta.gutterWidth()
+	ta.textAr = image.Rect(ar.Min.X+gutter, ar.Min.Y, ar.Max.X, ar.Max.Y)
+	if ta.textAr.Dx() < minTextWidth || ta.textAr.Dy() < 1 {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	rows := ta.editor.rows(ta.textAr.Dx())
+	ta.numRows = len(rows)
+	curRow, curX := ta.editor.cursorRow(rows)
+	height := ta.textAr.Dy()
+	if ta.followCursor {
+		if curRow < ta.offset {
+			ta.offset = curRow
+		}
+		if curRow >= ta.offset+height {
+			ta.offset = curRow - height + 1
+		}
+	}
+	ta.clampOffset()
+
+	if !meta.Focused && ta.opts.placeHolder != "" && ta.editor.content() == "" {
+		return ta.drawPlaceHolder(cvs)
+	}
+
+	for i := ta.offset; i < len(rows) && i < ta.offset+height; i++ {
+		r := rows[i]
+		y := ta.textAr.Min.Y + i - ta.offset
+		if gutter > 0 && r.start == 0 {
+			num := strconv.Itoa(r.line + 1)
+			p := image.Point{ar.Min.X + gutter - 1 - len(num), y}
+			if err := draw.Text(cvs, num, p, draw.TextCellOpts(ta.opts.lineNumberCellOpts...)); err != nil {
+				return err
+			}
+		}
+
+		text := string(ta.editor.lines[r.line][r.start:r.end])
+		if text == "" {
+			continue
+		}
+		if err := draw.Text(cvs, text, image.Point{ta.textAr.Min.X, y},
+			draw.TextMaxX(ta.textAr.Max.X),
+			draw.TextCellOpts(ta.opts.textCellOpts...),
+		); err != nil {
+			return err
+		}
+	}
+
+	if meta.Focused && curRow >= ta.offset && curRow < ta.offset+height {
+		p := image.Point{ta.textAr.Min.X + curX, ta.textAr.Min.Y + curRow - ta.offset}
+		if err := cvs.SetCellOpts(p, ta.opts.cursorCellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawPlaceHolder draws the place holder text.
+func (ta *TextArea) drawPlaceHolder(cvs *canvas.Canvas) error {
+	for i, l := range strings.Split(ta.opts.placeHolder, "\n") {
+		if i >= ta.textAr.Dy() {
+			break
+		}
+		if l == "" {
+			continue
+		}
+		if err := draw.Text(cvs, l, image.Point{ta.textAr.Min.X, ta.textAr.Min.Y + i},
+			draw.TextMaxX(ta.textAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(ta.opts.placeHolderOpts...),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clampOffset ensures that the offset doesn't scroll beyond the last row.
+// ta.mu must be held when calling this method.
+func (ta *TextArea) clampOffset() {
+	if max := ta.numRows - ta.textAr.Dy(); ta.offset > max {
+		ta.offset = max
+	}
+	if ta.offset < 0 {
+		ta.offset = 0
+	}
+}
+
+// keyboard processes keyboard events.
+// Returns the callback that must be called after ta.mu is released.
+func (ta *TextArea) keyboard(k *terminalapi.Keyboard) func() error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.followCursor = true
+
+	switch k.Shortcut() {
+	case ta.opts.submitKey:
+		text := ta.editor.content()
+		if ta.opts.clearOnSubmit {
+			ta.editor.reset()
+		}
+		if fn := ta.opts.onSubmit; fn != nil {
+			return func() error { return fn(text) }
+		}
+		return nil
+
+	case keyboard.Shortcut{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl},
+		keyboard.Shortcut{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModAlt}:
+		ta.editor.cursorWordLeft()
+		return nil
+
+	case keyboard.Shortcut{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl},
+		keyboard.Shortcut{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModAlt}:
+		ta.editor.cursorWordRight()
+		return nil
+
+	case keyboard.Shortcut{Key: keyboard.KeyHome, Modifiers: keyboard.ModCtrl}:
+		ta.editor.cursorStart()
+		return nil
+
+	case keyboard.Shortcut{Key: keyboard.KeyEnd, Modifiers: keyboard.ModCtrl}:
+		ta.editor.cursorEnd()
+		return nil
+	}
+
+	// Other keys pressed with modifiers are left for shortcuts, only
+	// characters typed with the Shift key are inserted.
+	if k.Modifiers != keyboard.ModNone && (k.Modifiers != keyboard.ModShift || k.Key < 0) {
+		return nil
+	}
+
+	width := ta.textAr.Dx()
+	page := ta.textAr.Dy()
+	if page < 1 {
+		page = 1
+	}
+	switch k.Key {
+	case keyboard.KeyBackspace, keyboard.KeyBackspace2:
+		ta.editor.deleteBefore()
+
+	case keyboard.KeyDelete:
+		ta.editor.delete()
+
+	case keyboard.KeyArrowLeft:
+		ta.editor.cursorLeft()
+
+	case keyboard.KeyArrowRight:
+		ta.editor.cursorRight()
+
+	case keyboard.KeyArrowUp:
+		ta.editor.cursorRows(width, -1)
+
+	case keyboard.KeyArrowDown:
+		ta.editor.cursorRows(width, 1)
+
+	case keyboard.KeyPgUp:
+		ta.editor.cursorRows(width, -page)
+
+	case keyboard.KeyPgDn:
+		ta.editor.cursorRows(width, page)
+
+	case keyboard.KeyHome, keyboard.KeyCtrlA:
+		ta.editor.cursorLineStart()
+
+	case keyboard.KeyEnd, keyboard.KeyCtrlE:
+		ta.editor.cursorLineEnd()
+
+	case keyboard.KeyEnter:
+		ta.editor.insert('\n')
+
+	default:
+		if err := wrap.ValidText(string(k.Key)); err != nil {
+			// Ignore unsupported runes.
+			return nil
+		}
+		ta.editor.insert(rune(k.Key))
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (ta *TextArea) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	if fn := ta.keyboard(k); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// Paste inserts the pasted text at the position of the cursor.
+// Runes that cannot be typed into the text area are dropped, line breaks in
+// the pasted text start new lines. The ChangeFn is called only once for the
+// whole pasted text.
+// Implements widgetapi.Paster.
+func (ta *TextArea) Paste(p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	var rs []rune
+	for _, r := range p.Text {
+		if err := wrap.ValidText(string(r)); err != nil {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	ta.editor.insertAll(rs)
+	ta.followCursor = true
+	return nil
+}
+
+// Mouse moves the cursor onto the clicked cell and scrolls the text with the
+// mouse wheel.
+// Implements widgetapi.Widget.Mouse.
+func (ta *TextArea) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonWheelUp:
+		ta.offset--
+		ta.followCursor = false
+		ta.clampOffset()
+
+	case mouse.ButtonWheelDown:
+		ta.offset++
+		ta.followCursor = false
+		ta.clampOffset()
+
+	case mouse.ButtonLeft:
+		if !m.Position.In(ta.textAr) {
+			return nil
+		}
+		rowIdx := ta.offset + m.Position.Y - ta.textAr.Min.Y
+		ta.editor.cursorCell(ta.textAr.Dx(), rowIdx, m.Position.X-ta.textAr.Min.X)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (ta *TextArea) Options() widgetapi.Options {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	return widgetapi.Options{
+		MinimumSize:              image.Point{ta.gutterWidth() + minTextWidth, 1},
+		WantKeyboard:             widgetapi.KeyScopeFocused,
+		WantMouse:                widgetapi.MouseScopeWidget,
+		ExclusiveKeyboardOnFocus: ta.opts.exclusiveKeyboardOnFocus,
+	}
+}
@@ -0,0 +1,569 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textarea
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks calls of the OnSubmit function.
+type callbackTracker struct {
+	// submitted are the texts OnSubmit was called with.
+	submitted []string
+	// wantErr when set to true, makes the callback return an error.
+	wantErr bool
+}
+
+// submit is the OnSubmit function.
+func (ct *callbackTracker) submit(text string) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.submitted = append(ct.submitted, text)
+	return nil
+}
+
+// mustCursor draws the default cursor at the point.
+func mustCursor(c *canvas.Canvas, p image.Point) {
+	if err := c.SetCellOpts(p, cell.Inverse()); err != nil {
+		panic(err)
+	}
+}
+
+// keys returns keyboard events for the runes.
+func keys(s string) []terminalapi.Event {
+	var res []terminalapi.Event
+	for _, r := range s {
+		k := keyboard.Key(r)
+		if r == '\n' {
+			k = keyboard.KeyEnter
+		}
+		res = append(res, &terminalapi.Keyboard{Key: k})
+	}
+	return res
+}
+
+func TestTextArea(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		events        []terminalapi.Event
+		canvas        image.Rectangle
+		unfocused     bool
+		want          func(size image.Point) *faketerm.Terminal
+		wantSubmitted []string
+		wantRead      string
+		wantNewErr    bool
+		wantEventErr  bool
+	}{
+		{
+			desc: "fails on invalid DefaultText",
+			opts: []Option{
+				DefaultText("a\tb"),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on invalid PlaceHolder",
+			opts: []Option{
+				PlaceHolder("\r"),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails when Enter submits the text",
+			opts: []Option{
+				SubmitKey(keyboard.Shortcut{Key: keyboard.KeyEnter}),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "draws resize needed when the canvas is too small",
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the default text and the cursor",
+			opts: []Option{
+				DefaultText("ab\ncd"),
+			},
+			canvas: image.Rect(0, 0, 6, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "cd", image.Point{0, 1})
+				mustCursor(c, image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "ab\ncd",
+		},
+		{
+			desc: "doesn't draw the cursor when not focused",
+			opts: []Option{
+				DefaultText("ab"),
+				TextCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			canvas:    image.Rect(0, 0, 6, 3),
+			unfocused: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "ab",
+		},
+		{
+			desc: "draws the place holder when empty and not focused",
+			opts: []Option{
+				PlaceHolder("type\nhere", cell.FgColor(cell.ColorBlue)),
+			},
+			canvas:    image.Rect(0, 0, 6, 3),
+			unfocused: true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				opts := draw.TextCellOpts(cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "type", image.Point{0, 0}, opts)
+				testdraw.MustText(c, "here", image.Point{0, 1}, opts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "wraps long lines",
+			opts: []Option{
+				DefaultText("abcdefg"),
+			},
+			canvas: image.Rect(0, 0, 4, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "abcd", image.Point{0, 0})
+				testdraw.MustText(c, "efg", image.Point{0, 1})
+				mustCursor(c, image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "abcdefg",
+		},
+		{
+			desc: "scrolls to keep the cursor visible",
+			opts: []Option{
+				DefaultText("a\nb\nc\nd"),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "c", image.Point{0, 0})
+				testdraw.MustText(c, "d", image.Point{0, 1})
+				mustCursor(c, image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "a\nb\nc\nd",
+		},
+		{
+			desc:   "typing inserts text and Enter starts a new line",
+			canvas: image.Rect(0, 0, 4, 2),
+			events: keys("ab\nc"),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "c", image.Point{0, 1})
+				mustCursor(c, image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "ab\nc",
+		},
+		{
+			desc:   "ignores control runes and keys with modifiers",
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: 'a', Modifiers: keyboard.ModAlt},
+				&terminalapi.Keyboard{Key: 'B', Modifiers: keyboard.ModShift},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "B", image.Point{0, 0})
+				mustCursor(c, image.Point{1, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "B",
+		},
+		{
+			desc: "edits at the cursor moved with the keyboard",
+			opts: []Option{
+				DefaultText("foo bar\nbaz"),
+			},
+			canvas: image.Rect(0, 0, 8, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl},
+				&terminalapi.Keyboard{Key: keyboard.KeyDelete},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace2},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome, Modifiers: keyboard.ModCtrl},
+				&terminalapi.Keyboard{Key: 'x'},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModAlt},
+				&terminalapi.Keyboard{Key: '!'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "xoo ba", image.Point{0, 0})
+				testdraw.MustText(c, "baz!", image.Point{0, 1})
+				mustCursor(c, image.Point{4, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "xoo ba\nbaz!",
+		},
+		{
+			desc: "pasted line breaks start new lines",
+			opts: []Option{
+				DefaultText("ad"),
+			},
+			canvas: image.Rect(0, 0, 4, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Paste{Text: "b\n\tc"},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "cd", image.Point{0, 1})
+				mustCursor(c, image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "ab\ncd",
+		},
+		{
+			desc: "draws the line numbers",
+			opts: []Option{
+				DefaultText("ab\ncdefgh"),
+				LineNumbers(),
+				LineNumberCellOpts(cell.FgColor(cell.ColorGreen)),
+			},
+			canvas: image.Rect(0, 0, 6, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				opts := draw.TextCellOpts(cell.FgColor(cell.ColorGreen))
+				testdraw.MustText(c, "1", image.Point{0, 0}, opts)
+				testdraw.MustText(c, "ab", image.Point{2, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1}, opts)
+				testdraw.MustText(c, "cdef", image.Point{2, 1})
+				testdraw.MustText(c, "gh", image.Point{2, 2})
+				mustCursor(c, image.Point{4, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "ab\ncdefgh",
+		},
+		{
+			desc: "mouse click moves the cursor",
+			opts: []Option{
+				DefaultText("ab\ncd"),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Keyboard{Key: 'x'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "axb", image.Point{0, 0})
+				testdraw.MustText(c, "cd", image.Point{0, 1})
+				mustCursor(c, image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "axb\ncd",
+		},
+		{
+			desc: "mouse wheel scrolls without moving the cursor",
+			opts: []Option{
+				DefaultText("a\nb\nc\nd"),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "b", image.Point{0, 0})
+				testdraw.MustText(c, "c", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRead: "a\nb\nc\nd",
+		},
+		{
+			desc: "submits the text with the submit key",
+			opts: []Option{
+				DefaultText("ab"),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlS},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				mustCursor(c, image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSubmitted: []string{"ab"},
+			wantRead:      "ab",
+		},
+		{
+			desc: "submits with a custom key and clears the text",
+			opts: []Option{
+				DefaultText("ab\ncd"),
+				SubmitKey(keyboard.Shortcut{Key: keyboard.KeyEnter, Modifiers: keyboard.ModAlt}),
+				ClearOnSubmit(),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlS},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter, Modifiers: keyboard.ModAlt},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustCursor(c, image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSubmitted: []string{"ab\ncd"},
+		},
+		{
+			desc: "forwards errors from the submit function",
+			opts: []Option{
+				DefaultText("ab"),
+			},
+			canvas: image.Rect(0, 0, 4, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlS},
+			},
+			wantEventErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &callbackTracker{wantErr: tc.wantEventErr}
+			ta, err := New(append(tc.opts, OnSubmit(ct.submit))...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+			meta := &widgetapi.Meta{Focused: !tc.unfocused}
+
+			// Draw once so the size is known to the events.
+			if err := ta.Draw(testcanvas.MustNew(tc.canvas), meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var eventErr error
+			for _, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = ta.Keyboard(e, &widgetapi.EventMeta{})
+				case *terminalapi.Mouse:
+					err = ta.Mouse(e, &widgetapi.EventMeta{})
+				case *terminalapi.Paste:
+					err = ta.Paste(e, &widgetapi.EventMeta{})
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if err != nil {
+					eventErr = err
+				}
+			}
+			if (eventErr != nil) != tc.wantEventErr {
+				t.Errorf("events => unexpected error: %v, wantEventErr: %v", eventErr, tc.wantEventErr)
+			}
+			if eventErr != nil {
+				return
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := ta.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if diff := pretty.Compare(tc.wantSubmitted, ct.submitted); diff != "" {
+				t.Errorf("OnSubmit => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if got := ta.Read(); got != tc.wantRead {
+				t.Errorf("Read => %q, want %q", got, tc.wantRead)
+			}
+		})
+	}
+}
+
+func TestSetText(t *testing.T) {
+	var changes []string
+	ta, err := New(OnChange(func(text string) {
+		changes = append(changes, text)
+	}))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := ta.SetText("a\tb"); err == nil {
+		t.Errorf("SetText => got nil error, want an error for a control rune")
+	}
+	if err := ta.SetText("ab\ncd"); err != nil {
+		t.Fatalf("SetText => unexpected error: %v", err)
+	}
+	if err := ta.Keyboard(&terminalapi.Keyboard{Key: 'e'}, &widgetapi.EventMeta{}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]string{"ab\ncde"}, changes); diff != "" {
+		t.Errorf("OnChange => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	if got, want := ta.ReadAndClear(), "ab\ncde"; got != want {
+		t.Errorf("ReadAndClear => %q, want %q", got, want)
+	}
+	if got, want := ta.Read(), ""; got != want {
+		t.Errorf("Read => %q, want %q", got, want)
+	}
+}
+
+func TestRequestsRedraw(t *testing.T) {
+	ta, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	if err := ta.Draw(testcanvas.MustNew(image.Rect(0, 0, 4, 2)), &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := ta.SetText("ab"); err != nil {
+		t.Fatalf("SetText => unexpected error: %v", err)
+	}
+	ta.ReadAndClear()
+	if got, want := requests, 2; got != want {
+		t.Errorf("TextArea requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "default options",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "the gutter increases the minimum size",
+			opts: []Option{
+				LineNumbers(),
+				DefaultText("\n\n\n\n\n\n\n\n\n"),
+				ExclusiveKeyboardOnFocus(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:              image.Point{5, 1},
+				WantKeyboard:             widgetapi.KeyScopeFocused,
+				WantMouse:                widgetapi.MouseScopeWidget,
+				ExclusiveKeyboardOnFocus: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ta, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := ta.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
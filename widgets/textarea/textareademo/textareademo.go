@@ -0,0 +1,114 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary textareademo shows the functionality of a multi-line text area.
+// Exits when Ctrl+Q is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/tcell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/text"
+	"github.com/mum4k/termdash/widgets/textarea"
+)
+
+func main() {
+	t, err := tcell.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	status, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := status.Write("Type a message, Enter starts a new line, Ctrl+S sends it."); err != nil {
+		panic(err)
+	}
+
+	sent, err := text.New(text.RollContent(), text.WrapAtWords())
+	if err != nil {
+		panic(err)
+	}
+
+	ta, err := textarea.New(
+		textarea.LineNumbers(),
+		textarea.PlaceHolder("Click here to write a message."),
+		textarea.ClearOnSubmit(),
+		textarea.OnChange(func(data string) {
+			lines := strings.Count(data, "\n") + 1
+			status.Write(fmt.Sprintf("%d lines, %d characters.", lines, len([]rune(data))), text.WriteReplace())
+		}),
+		textarea.OnSubmit(func(data string) error {
+			if err := sent.Write(data+"\n", text.WriteCellOpts(cell.FgColor(cell.ColorNumber(33)))); err != nil {
+				return err
+			}
+			return sent.Write("----\n")
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS CTRL+Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.SplitVertical(
+					container.Left(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Message"),
+						container.PlaceWidget(ta),
+						container.Focused(),
+					),
+					container.Right(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Sent"),
+						container.PlaceWidget(sent),
+					),
+				),
+			),
+			container.Bottom(
+				container.PlaceWidget(status),
+			),
+			container.SplitPercent(80),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == keyboard.KeyCtrlQ {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+// bindings.go contains the editing actions and the keys bound to them.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Action is an editing action that can be bound to keyboard shortcuts with
+// the KeyBinding option.
+type Action int
+
+// String implements fmt.Stringer()
+func (a Action) String() string {
+	if n, ok := actionNames[a]; ok {
+		return n
+	}
+	return "ActionUnknown"
+}
+
+// actionNames maps Action values to human readable names.
+var actionNames = map[Action]string{
+	ActionSubmit:           "ActionSubmit",
+	ActionDeleteBefore:     "ActionDeleteBefore",
+	ActionDelete:           "ActionDelete",
+	ActionDeleteWordBefore: "ActionDeleteWordBefore",
+	ActionDeleteToStart:    "ActionDeleteToStart",
+	ActionCursorLeft:       "ActionCursorLeft",
+	ActionCursorRight:      "ActionCursorRight",
+	ActionCursorWordLeft:   "ActionCursorWordLeft",
+	ActionCursorWordRight:  "ActionCursorWordRight",
+	ActionCursorStart:      "ActionCursorStart",
+	ActionCursorEnd:        "ActionCursorEnd",
+	ActionSelectLeft:       "ActionSelectLeft",
+	ActionSelectRight:      "ActionSelectRight",
+	ActionSelectWordLeft:   "ActionSelectWordLeft",
+	ActionSelectWordRight:  "ActionSelectWordRight",
+	ActionSelectStart:      "ActionSelectStart",
+	ActionSelectEnd:        "ActionSelectEnd",
+	ActionUndo:             "ActionUndo",
+	ActionRedo:             "ActionRedo",
+	ActionPrevious:         "ActionPrevious",
+	ActionNext:             "ActionNext",
+	ActionComplete:         "ActionComplete",
+	ActionDismiss:          "ActionDismiss",
+}
+
+const (
+	// ActionSubmit submits the content, see OnSubmit.
+	// Bound to Enter by default.
+	ActionSubmit Action = iota
+	// ActionDeleteBefore deletes the selected text or the rune before the
+	// cursor.
+	// Bound to Backspace by default.
+	ActionDeleteBefore
+	// ActionDelete deletes the selected text or the rune under the cursor.
+	// Bound to Delete by default.
+	ActionDelete
+	// ActionDeleteWordBefore deletes the selected text or the word before
+	// the cursor.
+	// Bound to Ctrl+W and Alt+Backspace by default.
+	ActionDeleteWordBefore
+	// ActionDeleteToStart deletes the selected text or all the text before
+	// the cursor.
+	// Bound to Ctrl+U by default.
+	ActionDeleteToStart
+	// ActionCursorLeft moves the cursor one rune to the left.
+	// Bound to the left arrow by default.
+	ActionCursorLeft
+	// ActionCursorRight moves the cursor one rune to the right.
+	// Bound to the right arrow by default.
+	ActionCursorRight
+	// ActionCursorWordLeft moves the cursor onto the beginning of the
+	// previous word.
+	// Bound to Ctrl+Left and Alt+Left by default.
+	ActionCursorWordLeft
+	// ActionCursorWordRight moves the cursor onto the end of the next word.
+	// Bound to Ctrl+Right and Alt+Right by default.
+	ActionCursorWordRight
+	// ActionCursorStart moves the cursor to the beginning of the text.
+	// Bound to Home and Ctrl+A by default.
+	ActionCursorStart
+	// ActionCursorEnd moves the cursor to the end of the text.
+	// Bound to End and Ctrl+E by default.
+	ActionCursorEnd
+	// ActionSelectLeft extends the selection by one rune to the left.
+	// Bound to Shift+Left by default.
+	ActionSelectLeft
+	// ActionSelectRight extends the selection by one rune to the right.
+	// Bound to Shift+Right by default.
+	ActionSelectRight
+	// ActionSelectWordLeft extends the selection onto the beginning of the
+	// previous word.
+	// Bound to Ctrl+Shift+Left by default.
+	ActionSelectWordLeft
+	// ActionSelectWordRight extends the selection onto the end of the next
+	// word.
+	// Bound to Ctrl+Shift+Right by default.
+	ActionSelectWordRight
+	// ActionSelectStart extends the selection to the beginning of the text.
+	// Bound to Shift+Home by default.
+	ActionSelectStart
+	// ActionSelectEnd extends the selection to the end of the text.
+	// Bound to Shift+End by default.
+	ActionSelectEnd
+	// ActionUndo reverts the last edit.
+	// Bound to Ctrl+Z by default.
+	ActionUndo
+	// ActionRedo reapplies the last edit reverted by ActionUndo.
+	// Bound to Ctrl+Y by default.
+	ActionRedo
+	// ActionPrevious selects the previous suggestion in the dropdown when it
+	// is displayed, otherwise recalls the previous value from the history.
+	// Bound to the up arrow by default when the History or Suggestions
+	// options are provided.
+	ActionPrevious
+	// ActionNext selects the next suggestion in the dropdown when it is
+	// displayed, otherwise recalls the next value from the history.
+	// Bound to the down arrow by default when the History or Suggestions
+	// options are provided.
+	ActionNext
+	// ActionComplete replaces the text with the selected suggestion.
+	// Bound to Tab by default when the History or Suggestions options are
+	// provided.
+	ActionComplete
+	// ActionDismiss hides the suggestions until the text is edited again.
+	// Bound to Esc by default when the History or Suggestions options are
+	// provided.
+	ActionDismiss
+)
+
+// defaultBindings returns the shortcuts bound to the actions by default.
+func defaultBindings() map[Action][]keyboard.Shortcut {
+	return map[Action][]keyboard.Shortcut{
+		ActionSubmit: {
+			{Key: keyboard.KeyEnter},
+		},
+		ActionDeleteBefore: {
+			{Key: keyboard.KeyBackspace},
+			{Key: keyboard.KeyBackspace2},
+		},
+		ActionDelete: {
+			{Key: keyboard.KeyDelete},
+		},
+		ActionDeleteWordBefore: {
+			{Key: keyboard.KeyCtrlW},
+			{Key: keyboard.KeyBackspace2, Modifiers: keyboard.ModAlt},
+		},
+		ActionDeleteToStart: {
+			{Key: keyboard.KeyCtrlU},
+		},
+		ActionCursorLeft: {
+			{Key: keyboard.KeyArrowLeft},
+		},
+		ActionCursorRight: {
+			{Key: keyboard.KeyArrowRight},
+		},
+		ActionCursorWordLeft: {
+			{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl},
+			{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModAlt},
+		},
+		ActionCursorWordRight: {
+			{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl},
+			{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModAlt},
+		},
+		ActionCursorStart: {
+			{Key: keyboard.KeyHome},
+			{Key: keyboard.KeyCtrlA},
+		},
+		ActionCursorEnd: {
+			{Key: keyboard.KeyEnd},
+			{Key: keyboard.KeyCtrlE},
+		},
+		ActionSelectLeft: {
+			{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModShift},
+		},
+		ActionSelectRight: {
+			{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModShift},
+		},
+		ActionSelectWordLeft: {
+			{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl | keyboard.ModShift},
+		},
+		ActionSelectWordRight: {
+			{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl | keyboard.ModShift},
+		},
+		ActionSelectStart: {
+			{Key: keyboard.KeyHome, Modifiers: keyboard.ModShift},
+		},
+		ActionSelectEnd: {
+			{Key: keyboard.KeyEnd, Modifiers: keyboard.ModShift},
+		},
+		ActionUndo: {
+			{Key: keyboard.KeyCtrlZ},
+		},
+		ActionRedo: {
+			{Key: keyboard.KeyCtrlY},
+		},
+	}
+}
+
+// recallBindings returns the shortcuts bound by default to the actions that
+// recall values from the history and suggestions. These are only bound when
+// the History or Suggestions options are provided.
+func recallBindings() map[Action][]keyboard.Shortcut {
+	return map[Action][]keyboard.Shortcut{
+		ActionPrevious: {
+			{Key: keyboard.KeyArrowUp},
+		},
+		ActionNext: {
+			{Key: keyboard.KeyArrowDown},
+		},
+		ActionComplete: {
+			{Key: keyboard.KeyTab},
+		},
+		ActionDismiss: {
+			{Key: keyboard.KeyEsc},
+		},
+	}
+}
+
+// keyActions returns the actions indexed by the shortcuts bound to them.
+// Returns an error if a shortcut is bound to more than one action.
+func keyActions(bindings map[Action][]keyboard.Shortcut) (map[keyboard.Shortcut]Action, error) {
+	res := map[keyboard.Shortcut]Action{}
+	for a, shortcuts := range bindings {
+		if _, ok := actionNames[a]; !ok {
+			return nil, fmt.Errorf("invalid KeyBinding, unknown action %d", a)
+		}
+		for _, s := range shortcuts {
+			if other, ok := res[s]; ok && other != a {
+				return nil, fmt.Errorf("invalid KeyBinding, shortcut %v is bound to both %v and %v", s, other, a)
+			}
+			res[s] = a
+		}
+	}
+	return res, nil
+}
@@ -19,6 +19,7 @@ package textinput
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/mum4k/termdash/private/numbers"
 	"github.com/mum4k/termdash/private/runewidth"
@@ -256,6 +257,31 @@ func (fd *fieldData) fitRunes(firstRune, curPos, cells int) (string, int, int) {
 	return b.String(), start, end
 }
 
+// snapshot is a state of the field editor that can be restored by undo or
+// redo.
+type snapshot struct {
+	// data are the data in the text input field.
+	data fieldData
+	// curDataPos is the position of the cursor within the data.
+	curDataPos int
+}
+
+// editKind identifies the kind of an edit. Consecutive edits of the same kind
+// are undone together, e.g. all the runes of a typed word.
+type editKind int
+
+const (
+	// editOther is an edit that is always undone on its own.
+	editOther editKind = iota
+	// editInsert is an insertion of a single rune.
+	editInsert
+	// editDelete is a deletion of a single rune.
+	editDelete
+)
+
+// maxUndo is the maximum number of edits that can be undone.
+const maxUndo = 100
+
 // fieldEditor maintains the cursor position and allows editing of the data in
 // the text input field.
 // This object isn't thread-safe.
@@ -268,6 +294,11 @@ type fieldEditor struct {
 	// possible.
 	curDataPos int
 
+	// selAnchor is the position within the data where the selection started.
+	// The selection spans the runes between the anchor and the cursor.
+	// Negative when nothing is selected.
+	selAnchor int
+
 	// firstRune is the index of the first displayed rune in the text input
 	// field.
 	firstRune int
@@ -275,39 +306,46 @@ type fieldEditor struct {
 	// width is the width of the text input field last time viewFor was called.
 	width int
 
+	// undoStack and redoStack hold the states that can be restored by undo
+	// and redo, the most recent state is the last one.
+	undoStack []*snapshot
+	redoStack []*snapshot
+
+	// lastEdit is the kind of the last edit, editOther when the cursor moved
+	// since.
+	lastEdit editKind
+
 	// onChange if provided is the handler called when fieldData changes
 	onChange ChangeFn
 }
 
 // newFieldEditor returns a new fieldEditor instance.
 func newFieldEditor(onChange ChangeFn) *fieldEditor {
-	return &fieldEditor{onChange: onChange}
+	return &fieldEditor{
+		selAnchor: -1,
+		onChange:  onChange,
+	}
 }
 
 // minFieldWidth is the minimum supported width of the text input field.
 const minFieldWidth = 4
 
+// cellsBetween returns the number of cells the runes in the range
+// start <= idx < end take on the screen.
+func (fe *fieldEditor) cellsBetween(start, end int) int {
+	cells := 0
+	for i := start; i < end && i < len(fe.data); i++ {
+		cells += runewidth.RuneWidth(fe.data[i])
+	}
+	return cells
+}
+
 // curCell returns the index of the cell the cursor is in within the text input field.
 func (fe *fieldEditor) curCell(width int) int {
 	if width == 0 {
 		return 0
 	}
-	// The index of rune within the visible range the cursor is at.
-	runeNum := fe.curDataPos - fe.firstRune
-
-	cellNum := 0
-	rn := 0
-	for i, r := range fe.data {
-		if i < fe.firstRune {
-			continue
-		}
-		if rn >= runeNum {
-			break
-		}
-		rn++
-		cellNum += runewidth.RuneWidth(r)
-	}
-	return cellNum
+	return fe.cellsBetween(fe.firstRune, fe.curDataPos)
 }
 
 // viewFor returns the currently visible data inside a text field with the
@@ -322,6 +360,43 @@ func (fe *fieldEditor) viewFor(width int) (string, int, error) {
 	return runes, fe.curCell(width), nil
 }
 
+// selection returns the range start <= idx < end of the selected runes.
+// Returns false if no runes are selected.
+func (fe *fieldEditor) selection() (int, int, bool) {
+	if fe.selAnchor < 0 || fe.selAnchor == fe.curDataPos {
+		return 0, 0, false
+	}
+	if fe.selAnchor < fe.curDataPos {
+		return fe.selAnchor, fe.curDataPos, true
+	}
+	return fe.curDataPos, fe.selAnchor, true
+}
+
+// selectionCells returns the range of cells start <= cell < end the selected
+// runes occupy within the text input field as of the last call to viewFor.
+// Returns false if no visible runes are selected.
+func (fe *fieldEditor) selectionCells() (int, int, bool) {
+	start, end, ok := fe.selection()
+	if !ok || end <= fe.firstRune {
+		return 0, 0, false
+	}
+	if start < fe.firstRune {
+		start = fe.firstRune
+	}
+
+	// The last cell is reserved for the cursor or the right arrow.
+	maxCell := fe.width - 1
+	startCell := fe.cellsBetween(fe.firstRune, start)
+	endCell := fe.cellsBetween(fe.firstRune, end)
+	if endCell > maxCell {
+		endCell = maxCell
+	}
+	if startCell >= endCell {
+		return 0, 0, false
+	}
+	return startCell, endCell, true
+}
+
 // content returns the string content in the field editor.
 func (fe *fieldEditor) content() string {
 	return string(fe.data)
@@ -332,60 +407,345 @@ func (fe *fieldEditor) reset() {
 	*fe = *newFieldEditor(fe.onChange)
 }
 
-// insert inserts the rune at the current position of the cursor.
+// clearHistory forgets all the edits so that they cannot be undone.
+func (fe *fieldEditor) clearHistory() {
+	fe.undoStack = nil
+	fe.redoStack = nil
+	fe.lastEdit = editOther
+}
+
+// changed calls the onChange handler if provided.
+func (fe *fieldEditor) changed() {
+	if fe.onChange != nil {
+		fe.onChange(string(fe.data))
+	}
+}
+
+// snapshot returns the current state of the field editor.
+func (fe *fieldEditor) snapshot() *snapshot {
+	return &snapshot{
+		data:       append(fieldData(nil), fe.data...),
+		curDataPos: fe.curDataPos,
+	}
+}
+
+// restore restores the state of the field editor.
+func (fe *fieldEditor) restore(s *snapshot) {
+	fe.data = s.data
+	fe.curDataPos = s.curDataPos
+	fe.selAnchor = -1
+	fe.lastEdit = editOther
+}
+
+// save records the current state so that the upcoming edit of the specified
+// kind can be undone. Consecutive edits of the same kind other than editOther
+// are recorded only once, unless they replace selected runes.
+func (fe *fieldEditor) save(kind editKind) {
+	_, _, selected := fe.selection()
+	if selected || kind == editOther || kind != fe.lastEdit {
+		fe.undoStack = append(fe.undoStack, fe.snapshot())
+		if len(fe.undoStack) > maxUndo {
+			fe.undoStack = fe.undoStack[1:]
+		}
+	}
+	fe.redoStack = nil
+	fe.lastEdit = kind
+}
+
+// undo reverts the last edit.
+func (fe *fieldEditor) undo() {
+	if len(fe.undoStack) == 0 {
+		return
+	}
+	fe.redoStack = append(fe.redoStack, fe.snapshot())
+	last := len(fe.undoStack) - 1
+	fe.restore(fe.undoStack[last])
+	fe.undoStack = fe.undoStack[:last]
+	fe.changed()
+}
+
+// redo reapplies the last edit reverted by undo.
+func (fe *fieldEditor) redo() {
+	if len(fe.redoStack) == 0 {
+		return
+	}
+	fe.undoStack = append(fe.undoStack, fe.snapshot())
+	last := len(fe.redoStack) - 1
+	fe.restore(fe.redoStack[last])
+	fe.redoStack = fe.redoStack[:last]
+	fe.changed()
+}
+
+// deleteRange deletes the runes in the range start <= idx < end and moves the
+// cursor onto start. Clears the selection.
+func (fe *fieldEditor) deleteRange(start, end int) {
+	fe.data = append(fe.data[:start], fe.data[end:]...)
+	fe.curDataPos = start
+	fe.selAnchor = -1
+}
+
+// deleteSelection deletes the selected runes if there are any.
+// Returns true if the data changed.
+func (fe *fieldEditor) deleteSelection() bool {
+	start, end, ok := fe.selection()
+	if !ok {
+		return false
+	}
+	fe.save(editOther)
+	fe.deleteRange(start, end)
+	return true
+}
+
+// insert inserts the rune at the current position of the cursor, replacing
+// the selected runes.
 func (fe *fieldEditor) insert(r rune) {
 	rw := runewidth.RuneWidth(r)
 	if rw == 0 {
 		// Don't insert invisible runes.
 		return
 	}
+	fe.save(editInsert)
+	if start, end, ok := fe.selection(); ok {
+		fe.deleteRange(start, end)
+	}
 	fe.data.insertAt(fe.curDataPos, r)
 	fe.curDataPos++
-	if fe.onChange != nil {
-		fe.onChange(string(fe.data))
+	fe.changed()
+}
+
+// insertAll inserts the runes at the current position of the cursor,
+// replacing the selected runes.
+// Unlike insert, calls the onChange handler only once after all the runes
+// were inserted.
+func (fe *fieldEditor) insertAll(rs []rune) {
+	var visible []rune
+	for _, r := range rs {
+		if runewidth.RuneWidth(r) == 0 {
+			// Don't insert invisible runes.
+			continue
+		}
+		visible = append(visible, r)
+	}
+	if len(visible) == 0 {
+		return
 	}
+
+	fe.save(editOther)
+	if start, end, ok := fe.selection(); ok {
+		fe.deleteRange(start, end)
+	}
+	for _, r := range visible {
+		fe.data.insertAt(fe.curDataPos, r)
+		fe.curDataPos++
+	}
+	fe.changed()
 }
 
-// delete deletes the rune at the current position of the cursor.
+// setContent replaces the data with the text and moves the cursor to its end.
+// Runes with zero width are dropped. The edit can be undone.
+func (fe *fieldEditor) setContent(text string) {
+	var data fieldData
+	for _, r := range text {
+		if runewidth.RuneWidth(r) == 0 {
+			continue
+		}
+		data = append(data, r)
+	}
+	if string(data) == string(fe.data) {
+		fe.moveTo(len(fe.data), false)
+		return
+	}
+
+	fe.save(editOther)
+	fe.data = data
+	fe.moveTo(len(fe.data), false)
+	fe.changed()
+}
+
+// cursorAtEnd asserts whether the cursor is after the last rune.
+func (fe *fieldEditor) cursorAtEnd() bool {
+	return fe.curDataPos == len(fe.data)
+}
+
+// delete deletes the selected runes or the rune at the current position of
+// the cursor.
 func (fe *fieldEditor) delete() {
+	if fe.deleteSelection() {
+		fe.changed()
+		return
+	}
 	if fe.curDataPos >= len(fe.data) {
 		// Cursor not on a rune, nothing to do.
 		return
 	}
+	fe.save(editDelete)
 	fe.data.deleteAt(fe.curDataPos)
-	if fe.onChange != nil {
-		fe.onChange(string(fe.data))
-	}
+	fe.changed()
 }
 
-// deleteBefore deletes the rune that is immediately to the left of the cursor.
+// deleteBefore deletes the selected runes or the rune that is immediately to
+// the left of the cursor.
 func (fe *fieldEditor) deleteBefore() {
+	if fe.deleteSelection() {
+		fe.changed()
+		return
+	}
 	if fe.curDataPos == 0 {
 		// Cursor at the beginning, nothing to do.
 		return
 	}
-	fe.cursorLeft()
-	fe.delete()
+	fe.save(editDelete)
+	fe.deleteRange(fe.curDataPos-1, fe.curDataPos)
+	fe.changed()
+}
+
+// deleteWordBefore deletes the selected runes or the word before the cursor.
+func (fe *fieldEditor) deleteWordBefore() {
+	if fe.deleteSelection() {
+		fe.changed()
+		return
+	}
+	start := fe.wordLeftIdx()
+	if start == fe.curDataPos {
+		return
+	}
+	fe.save(editOther)
+	fe.deleteRange(start, fe.curDataPos)
+	fe.changed()
+}
+
+// deleteToStart deletes the selected runes or all the runes before the
+// cursor.
+func (fe *fieldEditor) deleteToStart() {
+	if fe.deleteSelection() {
+		fe.changed()
+		return
+	}
+	if fe.curDataPos == 0 {
+		return
+	}
+	fe.save(editOther)
+	fe.deleteRange(0, fe.curDataPos)
+	fe.changed()
 }
 
-// cursorRight moves the cursor one position to the right.
+// isWordRune asserts whether the rune is part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordLeftIdx returns the position of the beginning of the current or the
+// previous word.
+func (fe *fieldEditor) wordLeftIdx() int {
+	i := fe.curDataPos
+	for i > 0 && !isWordRune(fe.data[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(fe.data[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordRightIdx returns the position of the end of the current or the next
+// word.
+func (fe *fieldEditor) wordRightIdx() int {
+	i := fe.curDataPos
+	for i < len(fe.data) && !isWordRune(fe.data[i]) {
+		i++
+	}
+	for i < len(fe.data) && isWordRune(fe.data[i]) {
+		i++
+	}
+	return i
+}
+
+// moveTo moves the cursor onto the position within the data.
+// Extends the selection when selecting, otherwise clears it.
+func (fe *fieldEditor) moveTo(pos int, selecting bool) {
+	switch {
+	case !selecting:
+		fe.selAnchor = -1
+	case fe.selAnchor < 0:
+		fe.selAnchor = fe.curDataPos
+	}
+	_, fe.curDataPos = numbers.MinMaxInts([]int{pos, 0})
+	fe.curDataPos, _ = numbers.MinMaxInts([]int{fe.curDataPos, len(fe.data)})
+	fe.lastEdit = editOther
+}
+
+// cursorRight moves the cursor one position to the right or onto the end of
+// the selection.
 func (fe *fieldEditor) cursorRight() {
-	fe.curDataPos, _ = numbers.MinMaxInts([]int{fe.curDataPos + 1, len(fe.data)})
+	if _, end, ok := fe.selection(); ok {
+		fe.moveTo(end, false)
+		return
+	}
+	fe.moveTo(fe.curDataPos+1, false)
 }
 
-// cursorLeft moves the cursor one position to the left.
+// cursorLeft moves the cursor one position to the left or onto the start of
+// the selection.
 func (fe *fieldEditor) cursorLeft() {
-	_, fe.curDataPos = numbers.MinMaxInts([]int{fe.curDataPos - 1, 0})
+	if start, _, ok := fe.selection(); ok {
+		fe.moveTo(start, false)
+		return
+	}
+	fe.moveTo(fe.curDataPos-1, false)
+}
+
+// cursorWordRight moves the cursor onto the end of the current or the next
+// word.
+func (fe *fieldEditor) cursorWordRight() {
+	fe.moveTo(fe.wordRightIdx(), false)
+}
+
+// cursorWordLeft moves the cursor onto the beginning of the current or the
+// previous word.
+func (fe *fieldEditor) cursorWordLeft() {
+	fe.moveTo(fe.wordLeftIdx(), false)
 }
 
 // cursorStart moves the cursor to the beginning of the data.
 func (fe *fieldEditor) cursorStart() {
-	fe.curDataPos = 0
+	fe.moveTo(0, false)
 }
 
 // cursorEnd moves the cursor to the end of the data.
 func (fe *fieldEditor) cursorEnd() {
-	fe.curDataPos = len(fe.data)
+	fe.moveTo(len(fe.data), false)
+}
+
+// selectRight extends the selection by one rune to the right.
+func (fe *fieldEditor) selectRight() {
+	fe.moveTo(fe.curDataPos+1, true)
+}
+
+// selectLeft extends the selection by one rune to the left.
+func (fe *fieldEditor) selectLeft() {
+	fe.moveTo(fe.curDataPos-1, true)
+}
+
+// selectWordRight extends the selection onto the end of the current or the
+// next word.
+func (fe *fieldEditor) selectWordRight() {
+	fe.moveTo(fe.wordRightIdx(), true)
+}
+
+// selectWordLeft extends the selection onto the beginning of the current or
+// the previous word.
+func (fe *fieldEditor) selectWordLeft() {
+	fe.moveTo(fe.wordLeftIdx(), true)
+}
+
+// selectStart extends the selection to the beginning of the data.
+func (fe *fieldEditor) selectStart() {
+	fe.moveTo(0, true)
+}
+
+// selectEnd extends the selection to the end of the data.
+func (fe *fieldEditor) selectEnd() {
+	fe.moveTo(len(fe.data), true)
 }
 
 // cursorRelCell sets the cursor onto the cell index within the visible
@@ -423,4 +783,6 @@ func (fe *fieldEditor) cursorRelCell(cellIdx int) {
 	default:
 		fe.curDataPos = dataIdx
 	}
+	fe.selAnchor = -1
+	fe.lastEdit = editOther
 }
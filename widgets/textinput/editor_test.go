@@ -1887,3 +1887,238 @@ func TestFieldEditor(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldEditorEdits(t *testing.T) {
+	tests := []struct {
+		desc              string
+		ops               func(*fieldEditor)
+		wantContent       string
+		wantCurDataPos    int
+		wantOnChangeCalls int
+	}{
+		{
+			desc: "typing replaces the selection",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("abcd"))
+				fe.selectLeft()
+				fe.selectLeft()
+				fe.insert('x')
+			},
+			wantContent:       "abx",
+			wantCurDataPos:    3,
+			wantOnChangeCalls: 2,
+		},
+		{
+			desc: "selection extends in both directions from the anchor",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("abcd"))
+				fe.cursorLeft()
+				fe.cursorLeft()
+				fe.selectRight()
+				fe.selectStart()
+				fe.delete()
+			},
+			wantContent:       "cd",
+			wantCurDataPos:    0,
+			wantOnChangeCalls: 2,
+		},
+		{
+			desc: "cursor left and right collapse the selection",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("abcd"))
+				fe.selectWordLeft()
+				fe.cursorRight()
+				fe.deleteBefore()
+			},
+			wantContent:       "abc",
+			wantCurDataPos:    3,
+			wantOnChangeCalls: 2,
+		},
+		{
+			desc: "deletes words before the cursor",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("foo, bar_1 baz"))
+				fe.cursorWordLeft()
+				fe.deleteWordBefore()
+			},
+			wantContent:       "foo, baz",
+			wantCurDataPos:    5,
+			wantOnChangeCalls: 2,
+		},
+		{
+			desc: "deletes to the start",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("foo bar"))
+				fe.cursorStart()
+				fe.cursorWordRight()
+				fe.deleteToStart()
+				fe.deleteToStart()
+			},
+			wantContent:       " bar",
+			wantCurDataPos:    0,
+			wantOnChangeCalls: 2,
+		},
+		{
+			desc: "undoes consecutive inserts and deletes together",
+			ops: func(fe *fieldEditor) {
+				fe.insert('a')
+				fe.insert('b')
+				fe.insert('c')
+				fe.deleteBefore()
+				fe.deleteBefore()
+				fe.undo()
+			},
+			wantContent:       "abc",
+			wantCurDataPos:    3,
+			wantOnChangeCalls: 6,
+		},
+		{
+			desc: "moving the cursor starts a new undo step",
+			ops: func(fe *fieldEditor) {
+				fe.insert('a')
+				fe.cursorStart()
+				fe.insert('b')
+				fe.undo()
+			},
+			wantContent:       "a",
+			wantCurDataPos:    0,
+			wantOnChangeCalls: 3,
+		},
+		{
+			desc: "redoes undone edits until a new edit",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("ab"))
+				fe.insertAll([]rune("cd"))
+				fe.undo()
+				fe.undo()
+				fe.undo()
+				fe.redo()
+				fe.insert('x')
+				fe.redo()
+			},
+			wantContent:       "abx",
+			wantCurDataPos:    3,
+			wantOnChangeCalls: 6,
+		},
+		{
+			desc: "reset clears the history",
+			ops: func(fe *fieldEditor) {
+				fe.insertAll([]rune("ab"))
+				fe.reset()
+				fe.undo()
+			},
+			wantContent:       "",
+			wantCurDataPos:    0,
+			wantOnChangeCalls: 1,
+		},
+		{
+			desc: "limits the number of edits that can be undone",
+			ops: func(fe *fieldEditor) {
+				for i := 0; i < maxUndo+1; i++ {
+					fe.insertAll([]rune("a"))
+				}
+				for i := 0; i < maxUndo+1; i++ {
+					fe.undo()
+				}
+			},
+			wantContent:       "a",
+			wantCurDataPos:    1,
+			wantOnChangeCalls: 2*maxUndo + 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var changeCount int
+			fe := newFieldEditor(func(data string) {
+				changeCount++
+			})
+			tc.ops(fe)
+
+			if got := fe.content(); got != tc.wantContent {
+				t.Errorf("content -> %q, want %q", got, tc.wantContent)
+			}
+			if fe.curDataPos != tc.wantCurDataPos {
+				t.Errorf("curDataPos -> %d, want %d", fe.curDataPos, tc.wantCurDataPos)
+			}
+			if tc.wantOnChangeCalls != changeCount {
+				t.Errorf("unexpected number of onChange calls -> %d, want %d", changeCount, tc.wantOnChangeCalls)
+			}
+		})
+	}
+}
+
+func TestSelectionCells(t *testing.T) {
+	tests := []struct {
+		desc      string
+		width     int
+		ops       func(*fieldEditor) error
+		wantStart int
+		wantEnd   int
+		wantOK    bool
+	}{
+		{
+			desc:  "nothing selected",
+			width: 10,
+			ops: func(fe *fieldEditor) error {
+				fe.insertAll([]rune("abc"))
+				return nil
+			},
+		},
+		{
+			desc:  "selection within the visible range",
+			width: 10,
+			ops: func(fe *fieldEditor) error {
+				fe.insertAll([]rune("a世c"))
+				fe.selectLeft()
+				fe.selectLeft()
+				return nil
+			},
+			wantStart: 1,
+			wantEnd:   4,
+			wantOK:    true,
+		},
+		{
+			desc:  "selection starts before the visible range",
+			width: 4,
+			ops: func(fe *fieldEditor) error {
+				fe.insertAll([]rune("abcdef"))
+				fe.cursorStart()
+				fe.selectEnd()
+				return nil
+			},
+			wantStart: 0,
+			wantEnd:   3,
+			wantOK:    true,
+		},
+		{
+			desc:  "selection ends after the visible range",
+			width: 4,
+			ops: func(fe *fieldEditor) error {
+				fe.insertAll([]rune("abcdef"))
+				fe.selectStart()
+				return nil
+			},
+			wantStart: 0,
+			wantEnd:   3,
+			wantOK:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fe := newFieldEditor(nil)
+			if err := tc.ops(fe); err != nil {
+				t.Fatalf("ops => unexpected error: %v", err)
+			}
+			if _, _, err := fe.viewFor(tc.width); err != nil {
+				t.Fatalf("viewFor => unexpected error: %v", err)
+			}
+
+			gotStart, gotEnd, gotOK := fe.selectionCells()
+			if gotStart != tc.wantStart || gotEnd != tc.wantEnd || gotOK != tc.wantOK {
+				t.Errorf("selectionCells => (%d, %d, %v), want (%d, %d, %v)", gotStart, gotEnd, gotOK, tc.wantStart, tc.wantEnd, tc.wantOK)
+			}
+		})
+	}
+}
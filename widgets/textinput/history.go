@@ -0,0 +1,97 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+// history.go contains code that tracks the submitted values.
+
+// history stores the values submitted by the user and allows them to browse
+// through them.
+// This object isn't thread-safe.
+type history struct {
+	// entries are the stored values, the most recent is the last one.
+	entries []string
+
+	// size is the maximum number of stored values.
+	size int
+
+	// idx is the index of the entry currently displayed in the text input
+	// field. Equal to the length of entries when the user isn't browsing the
+	// history.
+	idx int
+
+	// draft is the text the user was editing before they started browsing the
+	// history.
+	draft string
+}
+
+// newHistory returns a new history that stores at most size values.
+func newHistory(size int) *history {
+	return &history{size: size}
+}
+
+// add stores the submitted value and stops browsing.
+// Empty values and values equal to the most recent entry aren't stored.
+func (h *history) add(value string) {
+	defer h.stop()
+	if value == "" {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == value {
+		return
+	}
+
+	h.entries = append(h.entries, value)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+}
+
+// stop stops browsing, the next call to previous returns the most recent
+// entry.
+func (h *history) stop() {
+	h.idx = len(h.entries)
+	h.draft = ""
+}
+
+// previous returns the entry before the displayed one. The current argument
+// is the text in the field, it is returned by next once the user browses
+// past the most recent entry.
+// Returns false if there is no previous entry.
+func (h *history) previous(current string) (string, bool) {
+	if h.idx == 0 {
+		return "", false
+	}
+	if h.idx == len(h.entries) {
+		h.draft = current
+	}
+	h.idx--
+	return h.entries[h.idx], true
+}
+
+// next returns the entry after the displayed one or the draft after the most
+// recent entry.
+// Returns false if the user isn't browsing the history.
+func (h *history) next() (string, bool) {
+	if h.idx >= len(h.entries) {
+		return "", false
+	}
+	h.idx++
+	if h.idx == len(h.entries) {
+		draft := h.draft
+		h.draft = ""
+		return draft, true
+	}
+	return h.entries[h.idx], true
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// recalled is a value returned when browsing the history.
+type recalled struct {
+	value string
+	ok    bool
+}
+
+func TestHistory(t *testing.T) {
+	tests := []struct {
+		desc string
+		size int
+		add  []string
+		// browse calls previous or next on the history and returns the
+		// recalled values.
+		browse      func(h *history) []recalled
+		want        []recalled
+		wantEntries []string
+	}{
+		{
+			desc: "empty history",
+			size: 2,
+			browse: func(h *history) []recalled {
+				var res []recalled
+				v, ok := h.previous("draft")
+				res = append(res, recalled{v, ok})
+				v, ok = h.next()
+				return append(res, recalled{v, ok})
+			},
+			want: []recalled{
+				{"", false},
+				{"", false},
+			},
+		},
+		{
+			desc: "doesn't store empty and repeated values",
+			size: 3,
+			add:  []string{"a", "", "a", "b", "a"},
+			browse: func(h *history) []recalled {
+				return nil
+			},
+			wantEntries: []string{"a", "b", "a"},
+		},
+		{
+			desc: "drops the oldest values",
+			size: 2,
+			add:  []string{"a", "b", "c"},
+			browse: func(h *history) []recalled {
+				return nil
+			},
+			wantEntries: []string{"b", "c"},
+		},
+		{
+			desc: "browses back and forth and returns the draft",
+			size: 3,
+			add:  []string{"a", "b"},
+			browse: func(h *history) []recalled {
+				var res []recalled
+				for i := 0; i < 3; i++ {
+					v, ok := h.previous("draft")
+					res = append(res, recalled{v, ok})
+				}
+				for i := 0; i < 3; i++ {
+					v, ok := h.next()
+					res = append(res, recalled{v, ok})
+				}
+				return res
+			},
+			want: []recalled{
+				{"b", true},
+				{"a", true},
+				{"", false},
+				{"b", true},
+				{"draft", true},
+				{"", false},
+			},
+			wantEntries: []string{"a", "b"},
+		},
+		{
+			desc: "stopping starts from the most recent entry",
+			size: 3,
+			add:  []string{"a", "b"},
+			browse: func(h *history) []recalled {
+				var res []recalled
+				v, ok := h.previous("")
+				res = append(res, recalled{v, ok})
+				v, ok = h.previous("")
+				res = append(res, recalled{v, ok})
+				h.stop()
+				v, ok = h.previous("")
+				return append(res, recalled{v, ok})
+			},
+			want: []recalled{
+				{"b", true},
+				{"a", true},
+				{"b", true},
+			},
+			wantEntries: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := newHistory(tc.size)
+			for _, v := range tc.add {
+				h.add(v)
+			}
+
+			got := tc.browse(h)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("browse => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantEntries, h.entries); diff != "" {
+				t.Errorf("entries => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+// masks.go contains options that restrict the input to values of a format.
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// isDigit asserts whether the rune is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// NumericFilter is a FilterFn that accepts digits and the minus sign.
+func NumericFilter(r rune) bool {
+	return isDigit(r) || r == '-'
+}
+
+// DecimalFilter is a FilterFn that accepts digits, the minus sign and the
+// decimal point.
+func DecimalFilter(r rune) bool {
+	return NumericFilter(r) || r == '.'
+}
+
+// DurationFilter is a FilterFn that accepts the runes of durations like
+// "1h30m" or "1.5s", see time.ParseDuration.
+func DurationFilter(r rune) bool {
+	return DecimalFilter(r) || strings.ContainsRune("nsuµmh", r)
+}
+
+// DateFilter returns a FilterFn that accepts the runes of dates formatted
+// according to the layout, see time.Parse. Letters are only accepted if the
+// layout contains any, e.g. month names.
+func DateFilter(layout string) FilterFn {
+	letters := strings.IndexFunc(layout, unicode.IsLetter) >= 0
+	return func(r rune) bool {
+		if isDigit(r) || (letters && unicode.IsLetter(r)) {
+			return true
+		}
+		return !unicode.IsLetter(r) && !isDigit(r) && strings.ContainsRune(layout, r)
+	}
+}
+
+// errEmpty is returned by the masks for empty text.
+var errEmpty = errors.New("a value is required")
+
+// MaskNumeric restricts the input to whole numbers in the range
+// min <= value <= max.
+// Sets the NumericFilter and a ValidateFn, overriding the Filter and the
+// Validate options. Only the minus sign of negative numbers is accepted if
+// min is negative.
+func MaskNumeric(min, max int) Option {
+	return option(func(opts *options) {
+		if min > max {
+			opts.maskErr = fmt.Errorf("invalid MaskNumeric(%d, %d), min must be less than or equal to max", min, max)
+			return
+		}
+		opts.maskErr = nil
+		opts.filter = func(r rune) bool {
+			return isDigit(r) || (r == '-' && min < 0)
+		}
+		opts.validateFn = func(text string) error {
+			if text == "" {
+				return errEmpty
+			}
+			rangeErr := fmt.Errorf("must be between %d and %d", min, max)
+			v, err := strconv.Atoi(text)
+			switch {
+			case errors.Is(err, strconv.ErrRange):
+				return rangeErr
+			case err != nil:
+				return errors.New("must be a whole number")
+			case v < min || v > max:
+				return rangeErr
+			}
+			return nil
+		}
+	})
+}
+
+// MaskDecimal restricts the input to decimal numbers like "-1.5".
+// Sets the DecimalFilter and a ValidateFn, overriding the Filter and the
+// Validate options.
+func MaskDecimal() Option {
+	return option(func(opts *options) {
+		opts.maskErr = nil
+		opts.filter = DecimalFilter
+		opts.validateFn = func(text string) error {
+			if text == "" {
+				return errEmpty
+			}
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return errors.New("must be a decimal number")
+			}
+			return nil
+		}
+	})
+}
+
+// MaskDuration restricts the input to durations like "1h30m", see
+// time.ParseDuration.
+// Sets the DurationFilter and a ValidateFn, overriding the Filter and the
+// Validate options.
+func MaskDuration() Option {
+	return option(func(opts *options) {
+		opts.maskErr = nil
+		opts.filter = DurationFilter
+		opts.validateFn = func(text string) error {
+			if text == "" {
+				return errEmpty
+			}
+			if _, err := time.ParseDuration(text); err != nil {
+				return errors.New("must be a duration like 1h30m")
+			}
+			return nil
+		}
+	})
+}
+
+// MaskDate restricts the input to dates formatted according to the layout,
+// e.g. "2006-01-02", see time.Parse.
+// Sets the DateFilter and a ValidateFn, overriding the Filter and the
+// Validate options.
+func MaskDate(layout string) Option {
+	return option(func(opts *options) {
+		opts.maskErr = nil
+		opts.filter = DateFilter(layout)
+		opts.validateFn = func(text string) error {
+			if text == "" {
+				return errEmpty
+			}
+			if _, err := time.Parse(layout, text); err != nil {
+				return fmt.Errorf("must be a date like %s", layout)
+			}
+			return nil
+		}
+	})
+}
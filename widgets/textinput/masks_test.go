@@ -0,0 +1,199 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import (
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		desc     string
+		filter   FilterFn
+		accepted string
+		rejected string
+	}{
+		{
+			desc:     "NumericFilter",
+			filter:   NumericFilter,
+			accepted: "0123456789-",
+			rejected: "a.+ ٣",
+		},
+		{
+			desc:     "DecimalFilter",
+			filter:   DecimalFilter,
+			accepted: "09-.",
+			rejected: "a,+ e",
+		},
+		{
+			desc:     "DurationFilter",
+			filter:   DurationFilter,
+			accepted: "09-.nsuµmh",
+			rejected: "adw: ",
+		},
+		{
+			desc:     "DateFilter with a numeric layout",
+			filter:   DateFilter("2006-01-02 15:04"),
+			accepted: "09-: ",
+			rejected: "aJ/.",
+		},
+		{
+			desc:     "DateFilter with month names",
+			filter:   DateFilter("Jan 2, 2006"),
+			accepted: "09aJ ,",
+			rejected: "-/:",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, r := range tc.accepted {
+				if !tc.filter(r) {
+					t.Errorf("filter(%q) => false, want true", r)
+				}
+			}
+			for _, r := range tc.rejected {
+				if tc.filter(r) {
+					t.Errorf("filter(%q) => true, want false", r)
+				}
+			}
+		})
+	}
+}
+
+func TestMasks(t *testing.T) {
+	tests := []struct {
+		desc string
+		mask Option
+		// overrides are applied after the mask.
+		overrides  []Option
+		text       string
+		wantNewErr bool
+		wantErr    bool
+		// wantErrMsg if not empty is the expected error message.
+		wantErrMsg string
+	}{
+		{
+			desc:       "MaskNumeric fails when min is above max",
+			mask:       MaskNumeric(2, 1),
+			wantNewErr: true,
+		},
+		{
+			desc: "Validate overrides MaskNumeric with min above max",
+			mask: MaskNumeric(2, 1),
+			overrides: []Option{
+				Validate(func(string) error { return nil }),
+			},
+			text: "3",
+		},
+		{
+			desc: "another mask overrides MaskNumeric with min above max",
+			mask: MaskNumeric(2, 1),
+			overrides: []Option{
+				MaskDecimal(),
+			},
+			text: "1.5",
+		},
+		{
+			desc:    "MaskNumeric rejects empty text",
+			mask:    MaskNumeric(1, 65535),
+			text:    "",
+			wantErr: true,
+		},
+		{
+			desc: "MaskNumeric accepts numbers in the range",
+			mask: MaskNumeric(1, 65535),
+			text: "65535",
+		},
+		{
+			desc:    "MaskNumeric rejects numbers outside of the range",
+			mask:    MaskNumeric(1, 65535),
+			text:    "0",
+			wantErr: true,
+		},
+		{
+			desc:       "MaskNumeric reports the range for numbers that overflow",
+			mask:       MaskNumeric(1, 65535),
+			text:       "99999999999999999999",
+			wantErr:    true,
+			wantErrMsg: "must be between 1 and 65535",
+		},
+		{
+			desc:       "MaskNumeric rejects misplaced minus signs",
+			mask:       MaskNumeric(-10, 10),
+			text:       "1-",
+			wantErr:    true,
+			wantErrMsg: "must be a whole number",
+		},
+		{
+			desc: "MaskNumeric accepts negative numbers",
+			mask: MaskNumeric(-10, 10),
+			text: "-10",
+		},
+		{
+			desc: "MaskDecimal accepts decimal numbers",
+			mask: MaskDecimal(),
+			text: "-1.5",
+		},
+		{
+			desc:    "MaskDecimal rejects two decimal points",
+			mask:    MaskDecimal(),
+			text:    "1.5.",
+			wantErr: true,
+		},
+		{
+			desc: "MaskDuration accepts durations",
+			mask: MaskDuration(),
+			text: "1h30m",
+		},
+		{
+			desc:    "MaskDuration rejects durations without units",
+			mask:    MaskDuration(),
+			text:    "15",
+			wantErr: true,
+		},
+		{
+			desc: "MaskDate accepts dates in the layout",
+			mask: MaskDate("2006-01-02"),
+			text: "2024-02-29",
+		},
+		{
+			desc:    "MaskDate rejects invalid dates",
+			mask:    MaskDate("2006-01-02"),
+			text:    "2023-02-29",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ti, err := New(append([]Option{tc.mask}, tc.overrides...)...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = ti.opts.validateFn(tc.text)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateFn(%q) => unexpected error: %v, wantErr: %v", tc.text, err, tc.wantErr)
+			}
+			if err != nil && tc.wantErrMsg != "" && err.Error() != tc.wantErrMsg {
+				t.Errorf("validateFn(%q) => got error %q, want %q", tc.text, err, tc.wantErrMsg)
+			}
+		})
+	}
+}
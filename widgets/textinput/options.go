@@ -22,6 +22,7 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/private/wrap"
@@ -43,13 +44,18 @@ func (o option) set(opts *options) {
 
 // options holds the provided options.
 type options struct {
-	fillColor        cell.Color
-	textColor        cell.Color
-	placeHolderColor cell.Color
-	highlightedColor cell.Color
-	cursorColor      cell.Color
-	border           linestyle.LineStyle
-	borderColor      cell.Color
+	fillColor          cell.Color
+	textColor          cell.Color
+	placeHolderColor   cell.Color
+	highlightedColor   cell.Color
+	cursorColor        cell.Color
+	selectionColor     cell.Color
+	suggestionColor    cell.Color
+	invalidFillColor   cell.Color
+	invalidBorderColor cell.Color
+	errorColor         cell.Color
+	border             linestyle.LineStyle
+	borderColor        cell.Color
 
 	widthPerc     *int
 	maxWidthCells *int
@@ -61,7 +67,21 @@ type options struct {
 	hideTextWith rune
 	defaultText  string
 
+	bindings map[Action][]keyboard.Shortcut
+	// actions are the actions indexed by the bound shortcuts, populated by
+	// validate.
+	actions map[keyboard.Shortcut]Action
+
+	historySize    *int
+	suggest        SuggestFn
+	suggestionRows int
+
+	// maskErr is an error in the arguments of a mask option. Cleared by the
+	// options that override the mask.
+	maskErr error
+
 	filter                   FilterFn
+	validateFn               ValidateFn
 	onSubmit                 SubmitFn
 	onChange                 ChangeFn
 	clearOnSubmit            bool
@@ -94,17 +114,45 @@ func (o *options) validate() error {
 			}
 		}
 	}
+	if size := o.historySize; size != nil && *size < 1 {
+		return fmt.Errorf("invalid History(%d), must be value in range 1 <= value", *size)
+	}
+	if rows := o.suggestionRows; rows < 0 {
+		return fmt.Errorf("invalid SuggestionRows(%d), must be value in range 0 <= value", rows)
+	}
+	if o.maskErr != nil {
+		return o.maskErr
+	}
+	if o.historySize != nil || o.suggest != nil {
+		for a, shortcuts := range recallBindings() {
+			if _, ok := o.bindings[a]; !ok {
+				o.bindings[a] = shortcuts
+			}
+		}
+	}
+	actions, err := keyActions(o.bindings)
+	if err != nil {
+		return err
+	}
+	o.actions = actions
 	return nil
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		fillColor:        cell.ColorNumber(DefaultFillColorNumber),
-		placeHolderColor: cell.ColorNumber(DefaultPlaceHolderColorNumber),
-		highlightedColor: cell.ColorNumber(DefaultHighlightedColorNumber),
-		cursorColor:      cell.ColorNumber(DefaultCursorColorNumber),
-		labelAlign:       DefaultLabelAlign,
+		fillColor:          cell.ColorNumber(DefaultFillColorNumber),
+		placeHolderColor:   cell.ColorNumber(DefaultPlaceHolderColorNumber),
+		highlightedColor:   cell.ColorNumber(DefaultHighlightedColorNumber),
+		cursorColor:        cell.ColorNumber(DefaultCursorColorNumber),
+		selectionColor:     cell.ColorNumber(DefaultSelectionColorNumber),
+		suggestionColor:    cell.ColorNumber(DefaultSuggestionColorNumber),
+		suggestionRows:     DefaultSuggestionRows,
+		invalidFillColor:   cell.ColorNumber(DefaultInvalidFillColorNumber),
+		invalidBorderColor: cell.ColorNumber(DefaultInvalidBorderColorNumber),
+		errorColor:         cell.ColorNumber(DefaultErrorColorNumber),
+		labelAlign:         DefaultLabelAlign,
+		bindings:           defaultBindings(),
 	}
 }
 
@@ -131,7 +179,8 @@ func TextColor(c cell.Color) Option {
 // HighlightedColor option.
 const DefaultHighlightedColorNumber = 0
 
-// HighlightedColor sets the color of the text rune directly under the cursor.
+// HighlightedColor sets the color of the text rune directly under the cursor
+// and of the selected text.
 // Defaults to the default terminal color.
 func HighlightedColor(c cell.Color) Option {
 	return option(func(opts *options) {
@@ -151,6 +200,19 @@ func CursorColor(c cell.Color) Option {
 	})
 }
 
+// DefaultSelectionColorNumber is the default color number for the
+// SelectionColor option.
+const DefaultSelectionColorNumber = 244
+
+// SelectionColor sets the background color of the selected text. The selected
+// text is displayed in the HighlightedColor.
+// Defaults to DefaultSelectionColorNumber.
+func SelectionColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectionColor = c
+	})
+}
+
 // Border adds a border around the text input field.
 func Border(ls linestyle.LineStyle) Option {
 	return option(func(opts *options) {
@@ -246,10 +308,68 @@ type FilterFn func(rune) bool
 // input.
 func Filter(fn FilterFn) Option {
 	return option(func(opts *options) {
+		opts.maskErr = nil
 		opts.filter = fn
 	})
 }
 
+// ValidateFn if provided is called with the text in the text input field each
+// time the user edits or submits it. Returns an error if the text isn't valid.
+//
+// The function is called while the TextInput is mutex locked, so it must not
+// call methods of the TextInput and should return quickly.
+type ValidateFn func(text string) error
+
+// Validate sets a function that validates the text. While the text is
+// invalid, the text input field is displayed in the InvalidFillColor and
+// the InvalidBorderColor, the error is displayed below the field if the
+// widget has space for it and the text cannot be submitted.
+func Validate(fn ValidateFn) Option {
+	return option(func(opts *options) {
+		opts.maskErr = nil
+		opts.validateFn = fn
+	})
+}
+
+// DefaultInvalidFillColorNumber is the default color number for the
+// InvalidFillColor option.
+const DefaultInvalidFillColorNumber = 124
+
+// InvalidFillColor sets the fill color for the text input field while the
+// text is invalid, see Validate.
+// Defaults to DefaultInvalidFillColorNumber.
+func InvalidFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.invalidFillColor = c
+	})
+}
+
+// DefaultInvalidBorderColorNumber is the default color number for the
+// InvalidBorderColor option.
+const DefaultInvalidBorderColorNumber = 196
+
+// InvalidBorderColor sets the color of the border while the text is invalid,
+// see Validate.
+// Defaults to DefaultInvalidBorderColorNumber.
+func InvalidBorderColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.invalidBorderColor = c
+	})
+}
+
+// DefaultErrorColorNumber is the default color number for the ErrorColor
+// option.
+const DefaultErrorColorNumber = 196
+
+// ErrorColor sets the color of the error message displayed below the text
+// input field while the text is invalid, see Validate.
+// Defaults to DefaultErrorColorNumber.
+func ErrorColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.errorColor = c
+	})
+}
+
 // SubmitFn if provided is called when the user submits the content of the text
 // input field, the argument text contains all the text in the field.
 // Submitting the input field clears its content.
@@ -259,11 +379,13 @@ func Filter(fn FilterFn) Option {
 type SubmitFn func(text string) error
 
 // OnSubmit sets a function that will be called with the text typed by the user
-// when they submit the content by pressing the Enter key.
+// when they submit the content by pressing the Enter key or the shortcut bound
+// to ActionSubmit.
 // The SubmitFn must not attempt to read from or modify the TextInput instance
 // in any way as while the SubmitFn is executing, the TextInput is mutex
 // locked. If the intention is to clear the content on submission, use the
 // ClearOnSubmit() option.
+// The function isn't called while the text is invalid, see Validate.
 func OnSubmit(fn SubmitFn) Option {
 	return option(func(opts *options) {
 		opts.onSubmit = fn
@@ -309,3 +431,72 @@ func DefaultText(text string) Option {
 		opts.defaultText = text
 	})
 }
+
+// KeyBinding binds the action to the keyboard shortcuts, replacing the
+// shortcuts it is bound to by default. Providing no shortcuts unbinds the
+// action. A shortcut can only be bound to one action.
+// Keys that aren't bound to any action and are pressed without the Ctrl or
+// Alt modifiers are typed into the text input field.
+func KeyBinding(a Action, shortcuts ...keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.bindings[a] = shortcuts
+	})
+}
+
+// History enables a history of the last n values submitted by the user that
+// can be recalled with the Up and Down keys, see ActionPrevious and
+// ActionNext. Empty values and repeated submissions of the same value are
+// stored only once. Must be a value in the range 1 <= n.
+func History(n int) Option {
+	return option(func(opts *options) {
+		opts.historySize = &n
+	})
+}
+
+// SuggestFn if provided is called with the text in the text input field
+// each time the user edits it while the cursor is at the end of the text.
+// Returns suggestions for completing the text, the user can accept the
+// selected suggestion with the Tab key, see ActionComplete.
+//
+// The function is called while the TextInput is mutex locked, so it must not
+// call methods of the TextInput and should return quickly.
+type SuggestFn func(prefix string) []string
+
+// Suggestions sets a function that provides suggestions for completing the
+// text. The suggestions are displayed in a dropdown below the text input
+// field if the widget has space for it, see SuggestionRows. Otherwise the
+// remainder of the selected suggestion is displayed after the cursor as ghost
+// text. Suggestions that contain control characters or newlines are ignored.
+func Suggestions(fn SuggestFn) Option {
+	return option(func(opts *options) {
+		opts.suggest = fn
+	})
+}
+
+// DefaultSuggestionRows is the default value for the SuggestionRows option.
+const DefaultSuggestionRows = 5
+
+// SuggestionRows sets the maximum number of rows of the dropdown with
+// suggestions. The widget asks the container for this many rows below the
+// text input field when the Suggestions option is provided and displays the
+// suggestions as ghost text if it doesn't get them. Zero always displays the
+// ghost text.
+// Defaults to DefaultSuggestionRows.
+func SuggestionRows(n int) Option {
+	return option(func(opts *options) {
+		opts.suggestionRows = n
+	})
+}
+
+// DefaultSuggestionColorNumber is the default color number for the
+// SuggestionColor option.
+const DefaultSuggestionColorNumber = 244
+
+// SuggestionColor sets the color of the ghost text with the remainder of the
+// selected suggestion.
+// Defaults to DefaultSuggestionColorNumber.
+func SuggestionColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.suggestionColor = c
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"image"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -42,7 +43,10 @@ import (
 //
 // The text can be submitted by pressing enter or read at any time by calling
 // Read. The text input field can be navigated using arrows, the Home and End
-// button and using mouse.
+// button and using mouse. Text can be selected with the Shift key held and
+// edits can be undone, see Action for all the key bindings. Previously
+// submitted values and suggestions for completing the text can be recalled
+// with the History and Suggestions options.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type TextInput struct {
@@ -56,6 +60,34 @@ type TextInput struct {
 	// time Draw() was called.
 	forField image.Rectangle
 
+	// forDropdown is the area below the text input field available for the
+	// dropdown with suggestions last time Draw() was called. Empty if there
+	// is no space for the dropdown.
+	forDropdown image.Rectangle
+
+	// history stores the submitted values, nil unless the History option was
+	// provided.
+	history *history
+
+	// invalid is the error returned by the ValidateFn for the text in the
+	// field. Only validated once the user edits or submits the text.
+	invalid error
+
+	// suggestions are the suggestions for the current text, empty when there
+	// are none or they were hidden.
+	suggestions []string
+
+	// suggestIdx is the index of the selected suggestion.
+	suggestIdx int
+
+	// firstSuggestion is the index of the first suggestion displayed in the
+	// dropdown.
+	firstSuggestion int
+
+	// invalidator is used to request a redraw when the text changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
 	// opts are the provided options.
 	opts *options
 }
@@ -76,6 +108,10 @@ func New(opts ...Option) (*TextInput, error) {
 	for _, r := range ti.opts.defaultText {
 		ti.editor.insert(r)
 	}
+	ti.editor.clearHistory()
+	if size := ti.opts.historySize; size != nil {
+		ti.history = newHistory(*size)
+	}
 	return ti, nil
 }
 
@@ -105,6 +141,9 @@ func (ti *TextInput) ReadAndClear() string {
 
 	c := ti.editor.content()
 	ti.editor.reset()
+	ti.hideSuggestions()
+	ti.invalid = nil
+	ti.invalidator.Invalidate()
 	return c
 }
 
@@ -124,7 +163,11 @@ func (ti *TextInput) drawLabel(cvs *canvas.Canvas, labelAr image.Rectangle) erro
 
 // drawField draws the text input field.
 func (ti *TextInput) drawField(cvs *canvas.Canvas, text string) error {
-	if err := cvs.SetAreaCells(ti.forField, textFieldRune, cell.BgColor(ti.opts.fillColor)); err != nil {
+	fillColor := ti.opts.fillColor
+	if ti.invalid != nil {
+		fillColor = ti.opts.invalidFillColor
+	}
+	if err := cvs.SetAreaCells(ti.forField, textFieldRune, cell.BgColor(fillColor)); err != nil {
 		return err
 	}
 
@@ -139,6 +182,132 @@ func (ti *TextInput) drawField(cvs *canvas.Canvas, text string) error {
 	)
 }
 
+// drawSelection highlights the selected text within the text input field.
+func (ti *TextInput) drawSelection(cvs *canvas.Canvas) error {
+	start, end, ok := ti.editor.selectionCells()
+	if !ok {
+		return nil
+	}
+	for x := start; x < end; x++ {
+		p := image.Point{x + ti.forField.Min.X, ti.forField.Min.Y}
+		if err := cvs.SetCellOpts(
+			p,
+			cell.FgColor(ti.opts.highlightedColor),
+			cell.BgColor(ti.opts.selectionColor),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitBelow splits the area into the area for the text input field and the
+// areas below it for the validation error message and the dropdown with
+// suggestions. The returned messageAr is empty if the Validate option wasn't
+// provided and the dropdownAr is empty if the Suggestions option wasn't
+// provided. Both are empty if there isn't any space below the field.
+func (ti *TextInput) splitBelow(cvsAr image.Rectangle) (fieldAr, messageAr, dropdownAr image.Rectangle) {
+	height := fieldHeight(ti.opts.border)
+	if (ti.opts.suggest == nil && ti.opts.validateFn == nil) || cvsAr.Dy() <= height {
+		return cvsAr, image.ZR, image.ZR
+	}
+	fieldAr = image.Rect(cvsAr.Min.X, cvsAr.Min.Y, cvsAr.Max.X, cvsAr.Min.Y+height)
+
+	below := fieldAr.Max.Y
+	if ti.opts.validateFn != nil {
+		messageAr = image.Rect(cvsAr.Min.X, below, cvsAr.Max.X, below+1)
+		below++
+	}
+	if ti.opts.suggest != nil && below < cvsAr.Max.Y {
+		dropdownAr = image.Rect(cvsAr.Min.X, below, cvsAr.Max.X, cvsAr.Max.Y)
+	}
+	return fieldAr, messageAr, dropdownAr
+}
+
+// drawMessage draws the validation error message in the area.
+func (ti *TextInput) drawMessage(cvs *canvas.Canvas, messageAr image.Rectangle) error {
+	// Errors can contain newlines and other characters that cannot be
+	// displayed.
+	msg := strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) {
+			return ' '
+		}
+		return r
+	}, ti.invalid.Error())
+	return draw.Text(
+		cvs, msg, messageAr.Min,
+		draw.TextMaxX(messageAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cell.FgColor(ti.opts.errorColor)),
+	)
+}
+
+// suggestionsShown asserts whether the suggestions are displayed.
+func (ti *TextInput) suggestionsShown() bool {
+	return len(ti.suggestions) > 0 && ti.editor.cursorAtEnd()
+}
+
+// dropdownShown asserts whether the suggestions are displayed in the
+// dropdown.
+func (ti *TextInput) dropdownShown() bool {
+	return ti.suggestionsShown() && !ti.forDropdown.Empty()
+}
+
+// drawSuggestions draws the suggestions in the dropdown or the selected
+// suggestion as ghost text after the cursor if there is no space for the
+// dropdown.
+func (ti *TextInput) drawSuggestions(cvs *canvas.Canvas, curPos int) error {
+	if ti.forDropdown.Empty() {
+		text := ti.editor.content()
+		selected := ti.suggestions[ti.suggestIdx]
+		if !strings.HasPrefix(selected, text) || selected == text {
+			return nil
+		}
+		return draw.Text(
+			cvs, strings.TrimPrefix(selected, text),
+			image.Point{ti.forField.Min.X + curPos, ti.forField.Min.Y},
+			draw.TextMaxX(ti.forField.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeTrim),
+			draw.TextCellOpts(cell.FgColor(ti.opts.suggestionColor)),
+		)
+	}
+
+	rows := ti.forDropdown.Dy()
+	if ti.suggestIdx < ti.firstSuggestion {
+		ti.firstSuggestion = ti.suggestIdx
+	}
+	if ti.suggestIdx >= ti.firstSuggestion+rows {
+		ti.firstSuggestion = ti.suggestIdx - rows + 1
+	}
+
+	for i := ti.firstSuggestion; i < len(ti.suggestions) && i < ti.firstSuggestion+rows; i++ {
+		y := ti.forDropdown.Min.Y + i - ti.firstSuggestion
+		rowAr := image.Rect(ti.forDropdown.Min.X, y, ti.forDropdown.Max.X, y+1)
+		cOpts := []cell.Option{
+			cell.FgColor(ti.opts.textColor),
+			cell.BgColor(ti.opts.fillColor),
+		}
+		if i == ti.suggestIdx {
+			cOpts = []cell.Option{
+				cell.FgColor(ti.opts.highlightedColor),
+				cell.BgColor(ti.opts.selectionColor),
+			}
+		}
+		if err := cvs.SetAreaCells(rowAr, textFieldRune, cOpts...); err != nil {
+			return err
+		}
+		if err := draw.Text(
+			cvs, ti.suggestions[i], rowAr.Min,
+			draw.TextMaxX(rowAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(cOpts...),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // drawCursor draws the cursor within the text input field.
 func (ti *TextInput) drawCursor(cvs *canvas.Canvas, curPos int) error {
 	p := image.Point{
@@ -165,8 +334,10 @@ func (ti *TextInput) drawCursor(cvs *canvas.Canvas, curPos int) error {
 func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
+	ti.invalidator = meta.Invalidator
 
-	labelAr, textAr, err := split(cvs.Area(), ti.opts.label, ti.opts.widthPerc)
+	fieldAr, messageAr, dropdownAr := ti.splitBelow(cvs.Area())
+	labelAr, textAr, err := split(fieldAr, ti.opts.label, ti.opts.widthPerc)
 	if err != nil {
 		return err
 	}
@@ -180,6 +351,10 @@ func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	if ti.forField.Dx() < minFieldWidth || ti.forField.Dy() < minFieldHeight {
 		return draw.ResizeNeeded(cvs)
 	}
+	ti.forDropdown = image.ZR
+	if !dropdownAr.Empty() {
+		ti.forDropdown = image.Rect(ti.forField.Min.X, dropdownAr.Min.Y, ti.forField.Max.X, dropdownAr.Max.Y)
+	}
 
 	if !labelAr.Eq(image.ZR) {
 		if err := ti.drawLabel(cvs, labelAr); err != nil {
@@ -188,7 +363,17 @@ func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	if ti.opts.border != linestyle.None {
-		if err := draw.Border(cvs, textAr, draw.BorderCellOpts(cell.FgColor(ti.opts.borderColor))); err != nil {
+		borderColor := ti.opts.borderColor
+		if ti.invalid != nil {
+			borderColor = ti.opts.invalidBorderColor
+		}
+		if err := draw.Border(cvs, textAr, draw.BorderCellOpts(cell.FgColor(borderColor))); err != nil {
+			return err
+		}
+	}
+	if ti.invalid != nil && !messageAr.Empty() {
+		ar := image.Rect(textAr.Min.X, messageAr.Min.Y, textAr.Max.X, messageAr.Max.Y)
+		if err := ti.drawMessage(cvs, ar); err != nil {
 			return err
 		}
 	}
@@ -203,6 +388,14 @@ func (ti *TextInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	if meta.Focused {
+		if ti.suggestionsShown() {
+			if err := ti.drawSuggestions(cvs, curPos); err != nil {
+				return err
+			}
+		}
+		if err := ti.drawSelection(cvs); err != nil {
+			return err
+		}
 		if err := ti.drawCursor(cvs, curPos); err != nil {
 			return err
 		}
@@ -226,27 +419,99 @@ func (ti *TextInput) keyboard(k *terminalapi.Keyboard) (bool, string) {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
 
-	switch k.Key {
-	case keyboard.KeyBackspace, keyboard.KeyBackspace2:
-		ti.editor.deleteBefore()
+	if a, ok := ti.opts.actions[k.Shortcut()]; ok {
+		return ti.act(a)
+	}
 
-	case keyboard.KeyDelete:
-		ti.editor.delete()
+	// Keys pressed with modifiers are left for shortcuts, only characters
+	// typed with the Shift key are inserted.
+	if k.Modifiers != keyboard.ModNone && (k.Modifiers != keyboard.ModShift || k.Key < 0) {
+		return false, ""
+	}
+	if err := wrap.ValidText(string(k.Key)); err != nil {
+		// Ignore unsupported runes.
+		return false, ""
+	}
+	if ti.opts.filter != nil && !ti.opts.filter(rune(k.Key)) {
+		// Ignore filtered runes.
+		return false, ""
+	}
+	ti.editor.insert(rune(k.Key))
+	ti.edited()
+	return false, ""
+}
 
-	case keyboard.KeyArrowLeft:
-		ti.editor.cursorLeft()
+// edited updates the suggestions after the user edited the text and stops
+// browsing the history.
+func (ti *TextInput) edited() {
+	if ti.history != nil {
+		ti.history.stop()
+	}
+	ti.validateText()
+	ti.hideSuggestions()
+	text := ti.editor.content()
+	if ti.opts.suggest == nil || text == "" || !ti.editor.cursorAtEnd() {
+		return
+	}
+	for _, s := range ti.opts.suggest(text) {
+		if err := wrap.ValidText(s); err != nil || strings.ContainsRune(s, '\n') {
+			continue
+		}
+		ti.suggestions = append(ti.suggestions, s)
+	}
+}
 
-	case keyboard.KeyArrowRight:
-		ti.editor.cursorRight()
+// hideSuggestions hides the suggestions until the text is edited again.
+func (ti *TextInput) hideSuggestions() {
+	ti.suggestions = nil
+	ti.suggestIdx = 0
+	ti.firstSuggestion = 0
+}
 
-	case keyboard.KeyHome, keyboard.KeyCtrlA:
-		ti.editor.cursorStart()
+// recall replaces the text with the value recalled from the history.
+func (ti *TextInput) recall(value string, ok bool) {
+	if !ok {
+		return
+	}
+	ti.editor.setContent(value)
+	ti.validateText()
+	ti.hideSuggestions()
+}
 
-	case keyboard.KeyEnd, keyboard.KeyCtrlE:
-		ti.editor.cursorEnd()
+// complete replaces the text with the suggestion.
+func (ti *TextInput) complete(suggestion string) {
+	ti.editor.setContent(suggestion)
+	ti.validateText()
+	ti.hideSuggestions()
+	if ti.history != nil {
+		ti.history.stop()
+	}
+}
 
-	case keyboard.KeyEnter:
+// validateText validates the text in the field if the Validate option was
+// provided.
+func (ti *TextInput) validateText() {
+	if ti.opts.validateFn != nil {
+		ti.invalid = ti.opts.validateFn(ti.editor.content())
+	}
+}
+
+// act performs the editing action.
+// Returns a bool indicating if the content was submitted and the text in the
+// field at submission time.
+func (ti *TextInput) act(a Action) (bool, string) {
+	switch a {
+	case ActionSubmit:
+		ti.validateText()
+		if ti.invalid != nil {
+			// Invalid text cannot be submitted.
+			return false, ""
+		}
 		text := ti.editor.content()
+		if ti.history != nil {
+			ti.history.add(text)
+		}
+		ti.hideSuggestions()
 		if ti.opts.clearOnSubmit {
 			ti.editor.reset()
 		}
@@ -254,18 +519,94 @@ func (ti *TextInput) keyboard(k *terminalapi.Keyboard) (bool, string) {
 			return true, text
 		}
 
-	default:
-		if err := wrap.ValidText(string(k.Key)); err != nil {
-			// Ignore unsupported runes.
-			return false, ""
+	case ActionDeleteBefore:
+		ti.editor.deleteBefore()
+		ti.edited()
+
+	case ActionDelete:
+		ti.editor.delete()
+		ti.edited()
+
+	case ActionDeleteWordBefore:
+		ti.editor.deleteWordBefore()
+		ti.edited()
+
+	case ActionDeleteToStart:
+		ti.editor.deleteToStart()
+		ti.edited()
+
+	case ActionCursorLeft:
+		ti.editor.cursorLeft()
+
+	case ActionCursorRight:
+		ti.editor.cursorRight()
+
+	case ActionCursorWordLeft:
+		ti.editor.cursorWordLeft()
+
+	case ActionCursorWordRight:
+		ti.editor.cursorWordRight()
+
+	case ActionCursorStart:
+		ti.editor.cursorStart()
+
+	case ActionCursorEnd:
+		ti.editor.cursorEnd()
+
+	case ActionSelectLeft:
+		ti.editor.selectLeft()
+
+	case ActionSelectRight:
+		ti.editor.selectRight()
+
+	case ActionSelectWordLeft:
+		ti.editor.selectWordLeft()
+
+	case ActionSelectWordRight:
+		ti.editor.selectWordRight()
+
+	case ActionSelectStart:
+		ti.editor.selectStart()
+
+	case ActionSelectEnd:
+		ti.editor.selectEnd()
+
+	case ActionUndo:
+		ti.editor.undo()
+		ti.edited()
+
+	case ActionRedo:
+		ti.editor.redo()
+		ti.edited()
+
+	case ActionPrevious:
+		switch {
+		case ti.dropdownShown():
+			if ti.suggestIdx > 0 {
+				ti.suggestIdx--
+			}
+		case ti.history != nil:
+			ti.recall(ti.history.previous(ti.editor.content()))
 		}
-		if ti.opts.filter != nil && !ti.opts.filter(rune(k.Key)) {
-			// Ignore filtered runes.
-			return false, ""
+
+	case ActionNext:
+		switch {
+		case ti.dropdownShown():
+			if ti.suggestIdx < len(ti.suggestions)-1 {
+				ti.suggestIdx++
+			}
+		case ti.history != nil:
+			ti.recall(ti.history.next())
 		}
-		ti.editor.insert(rune(k.Key))
-	}
 
+	case ActionComplete:
+		if ti.suggestionsShown() {
+			ti.complete(ti.suggestions[ti.suggestIdx])
+		}
+
+	case ActionDismiss:
+		ti.hideSuggestions()
+	}
 	return false, ""
 }
 
@@ -281,13 +622,46 @@ func (ti *TextInput) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta
 	return nil
 }
 
+// Paste inserts the pasted text at the position of the cursor.
+// Runes that cannot be typed into the text input field or are rejected by the
+// FilterFn are dropped. Line breaks in the pasted text don't submit the
+// content. The ChangeFn is called only once for the whole pasted text.
+// Implements widgetapi.Paster.
+func (ti *TextInput) Paste(p *terminalapi.Paste, meta *widgetapi.EventMeta) error {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	var rs []rune
+	for _, r := range p.Text {
+		if err := wrap.ValidText(string(r)); err != nil {
+			continue
+		}
+		if ti.opts.filter != nil && !ti.opts.filter(r) {
+			continue
+		}
+		rs = append(rs, r)
+	}
+	ti.editor.insertAll(rs)
+	ti.edited()
+	return nil
+}
+
 // Mouse processes mouse events.
 // Implements widgetapi.Widget.Mouse.
 func (ti *TextInput) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
 	ti.mu.Lock()
 	defer ti.mu.Unlock()
 
-	if m.Button != mouse.ButtonLeft || !m.Position.In(ti.forField) {
+	if m.Button != mouse.ButtonLeft {
+		return nil
+	}
+	if ti.dropdownShown() && m.Position.In(ti.forDropdown) {
+		if i := ti.firstSuggestion + m.Position.Y - ti.forDropdown.Min.Y; i < len(ti.suggestions) {
+			ti.complete(ti.suggestions[i])
+		}
+		return nil
+	}
+	if !m.Position.In(ti.forField) {
 		return nil
 	}
 
@@ -299,6 +673,15 @@ func (ti *TextInput) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) erro
 // minFieldHeight is the minimum height in cells needed for the text input field.
 const minFieldHeight = 1
 
+// fieldHeight returns the height in cells of the text input field including
+// the border.
+func fieldHeight(border linestyle.LineStyle) int {
+	if border != linestyle.None {
+		return minFieldHeight + 2
+	}
+	return minFieldHeight
+}
+
 // Options implements widgetapi.Widget.Options.
 func (ti *TextInput) Options() widgetapi.Options {
 	ti.mu.Lock()
@@ -309,10 +692,16 @@ func (ti *TextInput) Options() widgetapi.Options {
 		needWidth += lw
 	}
 
-	needHeight := minFieldHeight
+	needHeight := fieldHeight(ti.opts.border)
 	if ti.opts.border != linestyle.None {
 		needWidth += 2
-		needHeight += 2
+	}
+	maxHeight := needHeight
+	if ti.opts.validateFn != nil {
+		maxHeight++ // For the error message.
+	}
+	if ti.opts.suggest != nil {
+		maxHeight += ti.opts.suggestionRows
 	}
 
 	maxWidth := 0
@@ -328,7 +717,7 @@ func (ti *TextInput) Options() widgetapi.Options {
 		},
 		MaximumSize: image.Point{
 			maxWidth,
-			needHeight,
+			maxHeight,
 		},
 		WantKeyboard:             widgetapi.KeyScopeFocused,
 		WantMouse:                widgetapi.MouseScopeWidget,
@@ -17,6 +17,7 @@ package textinput
 import (
 	"errors"
 	"image"
+	"strings"
 	"sync"
 	"testing"
 
@@ -131,6 +132,49 @@ func TestTextInput(t *testing.T) {
 			},
 			wantNewErr: true,
 		},
+		{
+			desc: "fails on History too low",
+			opts: []Option{
+				History(0),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on negative SuggestionRows",
+			opts: []Option{
+				SuggestionRows(-1),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on a mask with min above max",
+			opts: []Option{
+				MaskNumeric(10, 1),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on a shortcut bound to two actions",
+			opts: []Option{
+				KeyBinding(ActionUndo, keyboard.Shortcut{Key: keyboard.KeyDelete}),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on a shortcut bound to an action and to ActionComplete with History",
+			opts: []Option{
+				History(5),
+				KeyBinding(ActionSubmit, keyboard.Shortcut{Key: keyboard.KeyTab}),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on an unknown action",
+			opts: []Option{
+				KeyBinding(Action(-1), keyboard.Shortcut{Key: keyboard.KeyF1}),
+			},
+			wantNewErr: true,
+		},
 		{
 			desc:   "takes all space without label",
 			canvas: image.Rect(0, 0, 10, 1),
@@ -1081,7 +1125,11 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 		{
-			desc:   "moves cursor left",
+			desc: "highlights the selected text",
+			opts: []Option{
+				SelectionColor(cell.ColorBlue),
+				HighlightedColor(cell.ColorRed),
+			},
 			canvas: image.Rect(0, 0, 10, 1),
 			meta: &widgetapi.Meta{
 				Focused: true,
@@ -1090,7 +1138,8 @@ func TestTextInput(t *testing.T) {
 				&terminalapi.Keyboard{Key: 'a'},
 				&terminalapi.Keyboard{Key: 'b'},
 				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModShift},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModShift},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1104,34 +1153,34 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"a",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
 					cvs,
-					image.Point{2, 0},
+					image.Point{1, 0},
 					cursorRune,
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
-					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+					cell.FgColor(cell.ColorRed),
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{2, 0},
+					'c',
+					cell.BgColor(cell.ColorBlue),
+					cell.FgColor(cell.ColorRed),
 				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc: "sets custom highlight color",
-			opts: []Option{
-				HighlightedColor(cell.ColorRed),
-			},
+			desc:   "doesn't highlight the selection when not focused",
 			canvas: image.Rect(0, 0, 10, 1),
-			meta: &widgetapi.Meta{
-				Focused: true,
-			},
+			meta:   &widgetapi.Meta{},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModShift},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1145,31 +1194,61 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"a",
 					image.Point{0, 0},
 				)
-				testcanvas.MustSetCell(
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "submits with a custom key binding",
+			opts: []Option{
+				KeyBinding(ActionSubmit, keyboard.Shortcut{Key: keyboard.KeyTab}),
+			},
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 10, 1),
+			meta:     &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
 					cvs,
-					image.Point{2, 0},
-					cursorRune,
-					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
-					cell.FgColor(cell.ColorRed),
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"a",
+					image.Point{0, 0},
 				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
+			wantCallback: &callbackTracker{
+				text:  "a",
+				count: 1,
+			},
 		},
 		{
-			desc:   "moves cursor to start",
+			desc: "draws the remainder of the suggestion as ghost text",
+			opts: []Option{
+				Suggestions(fruits),
+			},
 			canvas: image.Rect(0, 0, 10, 1),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: 'p'},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1183,12 +1262,18 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"ap",
 					image.Point{0, 0},
 				)
+				testdraw.MustText(
+					cvs,
+					"ple",
+					image.Point{2, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultSuggestionColorNumber))),
+				)
 				testcanvas.MustSetCell(
 					cvs,
-					image.Point{0, 0},
+					image.Point{2, 0},
 					cursorRune,
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
 					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
@@ -1198,17 +1283,17 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 		{
-			desc:   "moves cursor right",
-			canvas: image.Rect(0, 0, 10, 1),
+			desc: "draws the suggestions in a dropdown below the field",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyHome},
-				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1222,7 +1307,7 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"a",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
@@ -1232,22 +1317,57 @@ func TestTextInput(t *testing.T) {
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
 					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
 				)
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 1, 10, 2),
+					textFieldRune,
+					cell.FgColor(cell.ColorDefault),
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"apple",
+					image.Point{0, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorDefault),
+						cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+					),
+				)
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 2, 10, 3),
+					textFieldRune,
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+					cell.BgColor(cell.ColorNumber(DefaultSelectionColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"apricot",
+					image.Point{0, 2},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+						cell.BgColor(cell.ColorNumber(DefaultSelectionColorNumber)),
+					),
+				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "moves cursor to end",
-			canvas: image.Rect(0, 0, 10, 1),
+			desc: "scrolls the dropdown to the selected suggestion",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyHome},
-				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1261,32 +1381,49 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"a",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
 					cvs,
-					image.Point{3, 0},
+					image.Point{1, 0},
 					cursorRune,
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
 					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
 				)
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 1, 10, 2),
+					textFieldRune,
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+					cell.BgColor(cell.ColorNumber(DefaultSelectionColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"apricot",
+					image.Point{0, 1},
+					draw.TextCellOpts(
+						cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+						cell.BgColor(cell.ColorNumber(DefaultSelectionColorNumber)),
+					),
+				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "deletes rune the cursor is on",
-			canvas: image.Rect(0, 0, 10, 1),
+			desc: "completes the suggestion selected in the dropdown",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyHome},
-				&terminalapi.Keyboard{Key: keyboard.KeyDelete},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1300,12 +1437,12 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"bc",
+					"apricot",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
 					cvs,
-					image.Point{0, 0},
+					image.Point{7, 0},
 					cursorRune,
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
 					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
@@ -1315,16 +1452,17 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 		{
-			desc:   "deletes rune just before the cursor",
-			canvas: image.Rect(0, 0, 10, 1),
+			desc: "completes the suggestion clicked in the dropdown",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Keyboard{Key: keyboard.KeyBackspace},
+				&terminalapi.Mouse{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1338,12 +1476,12 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"ab",
+					"apple",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
 					cvs,
-					image.Point{2, 0},
+					image.Point{5, 0},
 					cursorRune,
 					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
 					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
@@ -1353,19 +1491,17 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 		{
-			desc:   "left mouse button moves the cursor",
-			canvas: image.Rect(0, 0, 10, 1),
+			desc: "hides the dismissed suggestions",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
 			meta: &widgetapi.Meta{
 				Focused: true,
 			},
 			events: []terminalapi.Event{
 				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Mouse{
-					Button:   mouse.ButtonLeft,
-					Position: image.Point{1, 0},
-				},
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1379,7 +1515,7 @@ func TestTextInput(t *testing.T) {
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"a",
 					image.Point{0, 0},
 				)
 				testcanvas.MustSetCell(
@@ -1394,19 +1530,14 @@ func TestTextInput(t *testing.T) {
 			},
 		},
 		{
-			desc:   "ignores other mouse buttons",
-			canvas: image.Rect(0, 0, 10, 1),
-			meta: &widgetapi.Meta{
-				Focused: true,
+			desc: "marks invalid text with the fill color and displays the error",
+			opts: []Option{
+				Validate(noX),
 			},
+			canvas: image.Rect(0, 0, 10, 2),
+			meta:   &widgetapi.Meta{},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Mouse{
-					Button:   mouse.ButtonRight,
-					Position: image.Point{1, 0},
-				},
+				&terminalapi.Keyboard{Key: 'x'},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
@@ -1416,77 +1547,605 @@ func TestTextInput(t *testing.T) {
 					cvs,
 					image.Rect(0, 0, 10, 1),
 					textFieldRune,
-					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+					cell.BgColor(cell.ColorNumber(DefaultInvalidFillColorNumber)),
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
+					"x",
 					image.Point{0, 0},
 				)
-				testcanvas.MustSetCell(
+				testdraw.MustText(
 					cvs,
-					image.Point{3, 0},
-					cursorRune,
-					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
-					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+					"no x",
+					image.Point{0, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultErrorColorNumber))),
 				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "ignores mouse events outside of the text field",
-			canvas: image.Rect(0, 0, 10, 1),
-			meta: &widgetapi.Meta{
-				Focused: true,
+			desc: "marks invalid text with the border color and custom colors",
+			opts: []Option{
+				Validate(noX),
+				Border(linestyle.Light),
+				InvalidBorderColor(cell.ColorRed),
+				InvalidFillColor(cell.ColorYellow),
+				ErrorColor(cell.ColorBlue),
 			},
+			canvas: image.Rect(0, 0, 10, 4),
+			meta:   &widgetapi.Meta{},
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: 'a'},
-				&terminalapi.Keyboard{Key: 'b'},
-				&terminalapi.Keyboard{Key: 'c'},
-				&terminalapi.Mouse{
-					Button:   mouse.ButtonLeft,
-					Position: image.Point{5, 15},
-				},
+				&terminalapi.Keyboard{Key: 'x'},
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				cvs := testcanvas.MustNew(ft.Area())
 
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 3), draw.BorderCellOpts(cell.FgColor(cell.ColorRed)))
 				testcanvas.MustSetAreaCells(
 					cvs,
-					image.Rect(0, 0, 10, 1),
+					image.Rect(1, 1, 9, 2),
 					textFieldRune,
-					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+					cell.BgColor(cell.ColorYellow),
 				)
 				testdraw.MustText(
 					cvs,
-					"abc",
-					image.Point{0, 0},
+					"x",
+					image.Point{1, 1},
 				)
-				testcanvas.MustSetCell(
+				testdraw.MustText(
 					cvs,
-					image.Point{3, 0},
-					cursorRune,
-					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
-					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+					"no x",
+					image.Point{0, 3},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlue)),
 				)
 				testcanvas.MustApply(cvs, ft)
 				return ft
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			gotCallback := tc.callback
-			if gotCallback != nil {
-				tc.opts = append(tc.opts, OnSubmit(gotCallback.submit))
-			}
+		{
+			desc: "doesn't display the error without space below the field",
+			opts: []Option{
+				Validate(noX),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'x'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
 
-			ti, err := New(tc.opts...)
-			if (err != nil) != tc.wantNewErr {
-				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultInvalidFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"x",
+					image.Point{0, 0},
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "removes the error once the text is valid",
+			opts: []Option{
+				Validate(noX),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'x'},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace},
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"a",
+					image.Point{0, 0},
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "doesn't submit invalid text",
+			opts: []Option{
+				Validate(noX),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'x'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			callback: &callbackTracker{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultInvalidFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"x",
+					image.Point{0, 0},
+				)
+				testdraw.MustText(
+					cvs,
+					"no x",
+					image.Point{0, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultErrorColorNumber))),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc: "validates the text on submit",
+			opts: []Option{
+				Validate(func(text string) error {
+					if text == "" {
+						return errors.New("required")
+					}
+					return nil
+				}),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			callback: &callbackTracker{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultInvalidFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"required",
+					image.Point{0, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorNumber(DefaultErrorColorNumber))),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCallback: &callbackTracker{},
+		},
+		{
+			desc:   "moves cursor left",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{2, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "sets custom highlight color",
+			opts: []Option{
+				HighlightedColor(cell.ColorRed),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{2, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorRed),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves cursor to start",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{0, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves cursor right",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{1, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves cursor to end",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{3, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "deletes rune the cursor is on",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: keyboard.KeyDelete},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"bc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{0, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "deletes rune just before the cursor",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"ab",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{2, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "left mouse button moves the cursor",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Mouse{
+					Button:   mouse.ButtonLeft,
+					Position: image.Point{1, 0},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{1, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "ignores other mouse buttons",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Mouse{
+					Button:   mouse.ButtonRight,
+					Position: image.Point{1, 0},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{3, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "ignores mouse events outside of the text field",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Mouse{
+					Button:   mouse.ButtonLeft,
+					Position: image.Point{5, 15},
+				},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(
+					cvs,
+					image.Rect(0, 0, 10, 1),
+					textFieldRune,
+					cell.BgColor(cell.ColorNumber(DefaultFillColorNumber)),
+				)
+				testdraw.MustText(
+					cvs,
+					"abc",
+					image.Point{0, 0},
+				)
+				testcanvas.MustSetCell(
+					cvs,
+					image.Point{3, 0},
+					cursorRune,
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+					cell.FgColor(cell.ColorNumber(DefaultHighlightedColorNumber)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotCallback := tc.callback
+			if gotCallback != nil {
+				tc.opts = append(tc.opts, OnSubmit(gotCallback.submit))
+			}
+
+			ti, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
 			}
 			if err != nil {
 				return
@@ -1589,9 +2248,43 @@ func TestTextInput(t *testing.T) {
 	}
 }
 
+// noX is a ValidateFn that rejects text containing the letter x.
+func noX(text string) error {
+	if strings.ContainsRune(text, 'x') {
+		return errors.New("no x")
+	}
+	return nil
+}
+
+// fruits is a SuggestFn that suggests fruits starting with the prefix.
+func fruits(prefix string) []string {
+	var res []string
+	for _, f := range []string{"apple", "apricot", "banana", "invalid\tfruit"} {
+		if strings.HasPrefix(f, prefix) {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// keys returns keyboard events for the runes, newline characters are sent as
+// the Enter key.
+func keys(s string) []terminalapi.Event {
+	var res []terminalapi.Event
+	for _, r := range s {
+		k := keyboard.Key(r)
+		if r == '\n' {
+			k = keyboard.KeyEnter
+		}
+		res = append(res, &terminalapi.Keyboard{Key: k})
+	}
+	return res
+}
+
 func TestTextInputRead(t *testing.T) {
 	tests := []struct {
 		desc   string
+		opts   []Option
 		events []terminalapi.Event
 		want   string
 	}{
@@ -1609,11 +2302,195 @@ func TestTextInputRead(t *testing.T) {
 			},
 			want: "abc",
 		},
+		{
+			desc: "ignores keys pressed with the ctrl or alt modifiers",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b', Modifiers: keyboard.ModAlt},
+				&terminalapi.Keyboard{Key: 'c', Modifiers: keyboard.ModCtrl},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace, Modifiers: keyboard.ModAlt},
+			},
+			want: "a",
+		},
+		{
+			desc: "inserts characters pressed with the shift modifier",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'B', Modifiers: keyboard.ModShift},
+			},
+			want: "aB",
+		},
+		{
+			desc: "replaces text selected with the shift modifier",
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModShift},
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+			want: "ac",
+		},
+		{
+			desc: "deletes selected words",
+			events: append(keys("foo bar baz"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl | keyboard.ModShift},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModCtrl | keyboard.ModShift},
+				&terminalapi.Keyboard{Key: keyboard.KeyDelete},
+			),
+			want: "baz",
+		},
+		{
+			desc: "moving the cursor clears the selection",
+			events: append(keys("abc"),
+				&terminalapi.Keyboard{Key: keyboard.KeyHome, Modifiers: keyboard.ModShift},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace2},
+			),
+			want: "ab",
+		},
+		{
+			desc: "deletes the word before the cursor",
+			events: append(keys("foo bar  "),
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlW},
+			),
+			want: "foo ",
+		},
+		{
+			desc: "deletes the text before the cursor",
+			events: append(keys("foo bar"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft, Modifiers: keyboard.ModAlt},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlU},
+			),
+			want: "bar",
+		},
+		{
+			desc: "moves over words",
+			events: append(keys("foo bar"),
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight, Modifiers: keyboard.ModCtrl},
+				&terminalapi.Keyboard{Key: '!'},
+			),
+			want: "foo! bar",
+		},
+		{
+			desc: "undoes typed words and redoes them",
+			events: append(keys("ab"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: 'd'},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlZ},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlZ},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlZ},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlY},
+			),
+			want: "ab",
+		},
+		{
+			desc: "recalls submitted values from the history",
+			opts: []Option{
+				History(2),
+				ClearOnSubmit(),
+			},
+			events: append(keys("a\nb\nb\n\nc\nx"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			),
+			want: "c",
+		},
+		{
+			desc: "returns to the edited text after browsing the history",
+			opts: []Option{
+				History(2),
+				ClearOnSubmit(),
+			},
+			events: append(keys("a\nx"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: 'y'},
+			),
+			want: "xy",
+		},
+		{
+			desc: "ignores the history when not enabled",
+			events: append(keys("a\nx"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			),
+			want: "ax",
+		},
+		{
+			desc: "keeps the invalid text after a blocked submit",
+			opts: []Option{
+				MaskNumeric(1, 10),
+				ClearOnSubmit(),
+			},
+			events: keys("1a2\n"),
+			want:   "12",
+		},
+		{
+			desc: "clears the valid text after submit",
+			opts: []Option{
+				MaskNumeric(1, 10),
+				ClearOnSubmit(),
+			},
+			events: keys("1a0\n"),
+			want:   "",
+		},
+		{
+			desc: "completes the text with a suggestion",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			events: append(keys("ap"),
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			),
+			want: "apple",
+		},
+		{
+			desc: "doesn't complete when the cursor isn't at the end",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			events: append(keys("ap"),
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			),
+			want: "ap",
+		},
+		{
+			desc: "doesn't complete dismissed suggestions",
+			opts: []Option{
+				Suggestions(fruits),
+			},
+			events: append(keys("b"),
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+			),
+			want: "b",
+		},
+		{
+			desc: "uses custom key bindings",
+			opts: []Option{
+				KeyBinding(ActionUndo, keyboard.Shortcut{Key: 'z', Modifiers: keyboard.ModAlt}),
+				KeyBinding(ActionDeleteToStart),
+			},
+			events: append(keys("ab"),
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlU},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlZ},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: 'z', Modifiers: keyboard.ModAlt},
+			),
+			want: "ab",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			ti, err := New()
+			ti, err := New(tc.opts...)
 			if err != nil {
 				t.Fatalf("New => unexpected error: %v", err)
 			}
@@ -1658,6 +2535,127 @@ func TestTextInputRead(t *testing.T) {
 	}
 }
 
+// changeTracker tracks calls to the ChangeFn.
+type changeTracker struct {
+	// texts are the texts received by the ChangeFn.
+	texts []string
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// change is the callback function called OnChange.
+func (ct *changeTracker) change(text string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.texts = append(ct.texts, text)
+}
+
+func TestRequestsRedrawOnReadAndClear(t *testing.T) {
+	ti, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 1))
+	if err := ti.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	ti.Read()
+	ti.ReadAndClear()
+	if got, want := requests, 1; got != want {
+		t.Errorf("TextInput requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestTextInputPaste(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// events are sent before the paste event.
+		events      []*terminalapi.Keyboard
+		paste       *terminalapi.Paste
+		want        string
+		wantChanges []string
+	}{
+		{
+			desc:        "inserts the pasted text",
+			paste:       &terminalapi.Paste{Text: "hello"},
+			want:        "hello",
+			wantChanges: []string{"hello"},
+		},
+		{
+			desc: "inserts the pasted text at the cursor",
+			events: []*terminalapi.Keyboard{
+				{Key: 'a'},
+				{Key: 'b'},
+				{Key: keyboard.KeyArrowLeft},
+			},
+			paste:       &terminalapi.Paste{Text: "123"},
+			want:        "a123b",
+			wantChanges: []string{"a", "ab", "a123b"},
+		},
+		{
+			desc:        "drops line breaks and control runes",
+			paste:       &terminalapi.Paste{Text: "a\nb\tc\x00d"},
+			want:        "abcd",
+			wantChanges: []string{"abcd"},
+		},
+		{
+			desc: "drops runes rejected by the filter",
+			opts: []Option{
+				Filter(func(r rune) bool {
+					return r >= '0' && r <= '9'
+				}),
+			},
+			paste:       &terminalapi.Paste{Text: "1a2b3"},
+			want:        "123",
+			wantChanges: []string{"123"},
+		},
+		{
+			desc: "doesn't report a change when nothing was inserted",
+			opts: []Option{
+				Filter(func(r rune) bool {
+					return r >= '0' && r <= '9'
+				}),
+			},
+			paste: &terminalapi.Paste{Text: "abc\n"},
+			want:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &changeTracker{}
+			ti, err := New(append(tc.opts, OnChange(ct.change))...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				if err := ti.Keyboard(ev, &widgetapi.EventMeta{}); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+			if err := ti.Paste(tc.paste, &widgetapi.EventMeta{}); err != nil {
+				t.Fatalf("Paste => unexpected error: %v", err)
+			}
+
+			if got := ti.Read(); got != tc.want {
+				t.Errorf("Read => %q, want %q", got, tc.want)
+			}
+			if diff := pretty.Compare(tc.wantChanges, ct.texts); diff != "" {
+				t.Errorf("ChangeFn => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1673,6 +2671,32 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeWidget,
 			},
 		},
+		{
+			desc: "asks for space for the dropdown with suggestions",
+			opts: []Option{
+				Border(linestyle.Light),
+				Suggestions(fruits),
+				SuggestionRows(3),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{6, 3},
+				MaximumSize:  image.Point{0, 6},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "asks for space for the error message with validation",
+			opts: []Option{
+				Validate(noX),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{4, 1},
+				MaximumSize:  image.Point{0, 2},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
 		{
 			desc: "no label and no border, max width specified",
 			opts: []Option{
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/mum4k/termdash"
@@ -112,6 +113,20 @@ func rollText(ctx context.Context, sd *segmentdisplay.SegmentDisplay, updateText
 	}
 }
 
+// words are the words suggested when typing into the text input field.
+var words = []string{"Termdash", "Terminal", "Text", "Dashboard", "Widget"}
+
+// suggest returns the words that start with the prefix.
+func suggest(prefix string) []string {
+	var res []string
+	for _, w := range words {
+		if strings.HasPrefix(strings.ToLower(w), strings.ToLower(prefix)) {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
 func main() {
 	t, err := tcell.New()
 	if err != nil {
@@ -139,6 +154,8 @@ func main() {
 		textinput.MaxWidthCells(20),
 		textinput.Border(linestyle.Light),
 		textinput.PlaceHolder("Enter any text"),
+		textinput.History(10),
+		textinput.Suggestions(suggest),
 		textinput.OnChange(func(data string) {
 			mirror.Reset()
 			mirror.Write(data)
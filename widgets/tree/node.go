@@ -0,0 +1,186 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+// node.go contains the nodes of the tree and their options.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// TextChunk is a part of the label of a node with its own cell options.
+type TextChunk struct {
+	text  string
+	cOpts []cell.Option
+}
+
+// NewChunk creates a new text chunk. Each chunk of text can have its own cell
+// options.
+func NewChunk(text string, cOpts ...cell.Option) *TextChunk {
+	return &TextChunk{
+		text:  text,
+		cOpts: cOpts,
+	}
+}
+
+// NodeOption is used to provide options to NewNode().
+type NodeOption interface {
+	// set sets the provided option.
+	set(*Node)
+}
+
+// nodeOption implements NodeOption.
+type nodeOption func(*Node)
+
+// set implements NodeOption.set.
+func (no nodeOption) set(n *Node) {
+	no(n)
+}
+
+// Children sets the child nodes of the node.
+func Children(nodes ...*Node) NodeOption {
+	return nodeOption(func(n *Node) {
+		n.children = nodes
+	})
+}
+
+// Expanded makes the node initially expanded so that its children are
+// displayed.
+// By default nodes are collapsed.
+func Expanded() NodeOption {
+	return nodeOption(func(n *Node) {
+		n.expanded = true
+	})
+}
+
+// Lazy indicates that the children of the node are loaded by the function
+// provided via the LoadChildren option when the node is expanded for the first
+// time. Children provided via the Children option are ignored.
+func Lazy() NodeOption {
+	return nodeOption(func(n *Node) {
+		n.lazy = true
+	})
+}
+
+// Value sets a value that identifies the node, e.g. a pointer to the data the
+// node represents. Available to the callbacks via Node.Value.
+func Value(v interface{}) NodeOption {
+	return nodeOption(func(n *Node) {
+		n.value = v
+	})
+}
+
+// Node is a single node of the tree.
+//
+// Once the node is provided to the tree, it is owned by the tree. The same
+// node can be provided again to the same tree, but must not appear in the tree
+// more than once or be provided to a different tree.
+type Node struct {
+	// chunks are the parts of the label of the node.
+	chunks []*TextChunk
+	// value is the value set with the Value option.
+	value interface{}
+
+	// parent is the parent node or nil for the roots of the tree.
+	parent *Node
+	// children are the child nodes.
+	children []*Node
+
+	// expanded indicates that the children are displayed.
+	expanded bool
+	// lazy indicates that the children are loaded when the node is expanded
+	// for the first time.
+	lazy bool
+	// loading indicates that the function that loads the children is running.
+	loading bool
+}
+
+// NewNode returns a new node with the provided label.
+func NewNode(label string, opts ...NodeOption) *Node {
+	return NewNodeFromChunks([]*TextChunk{NewChunk(label)}, opts...)
+}
+
+// NewNodeFromChunks is like NewNode, but allows specifying cell options for
+// individual chunks of the label.
+func NewNodeFromChunks(chunks []*TextChunk, opts ...NodeOption) *Node {
+	n := &Node{
+		chunks: chunks,
+	}
+	for _, o := range opts {
+		o.set(n)
+	}
+	if n.lazy {
+		n.children = nil
+	}
+	return n
+}
+
+// Label returns the text of the label of the node.
+func (n *Node) Label() string {
+	var b strings.Builder
+	for _, c := range n.chunks {
+		b.WriteString(c.text)
+	}
+	return b.String()
+}
+
+// Value returns the value set with the Value option or nil.
+func (n *Node) Value() interface{} {
+	return n.value
+}
+
+// expandable asserts whether the node has or can have children.
+func (n *Node) expandable() bool {
+	return n.lazy || len(n.children) > 0
+}
+
+// validate validates the node and its children and sets their parent.
+func (n *Node) validate(parent *Node, seen map[*Node]bool) error {
+	if n == nil {
+		return errors.New("the node cannot be nil")
+	}
+	if seen[n] {
+		return fmt.Errorf("the node %q was provided more than once", n.Label())
+	}
+	seen[n] = true
+
+	if len(n.chunks) == 0 {
+		return errors.New("the node must have at least one text chunk")
+	}
+	for i, c := range n.chunks {
+		if c == nil {
+			return fmt.Errorf("the text chunk[%d] of the node cannot be nil", i)
+		}
+		if strings.ContainsRune(c.text, '\n') {
+			return fmt.Errorf("the label %q cannot contain newline characters", n.Label())
+		}
+	}
+	if err := wrap.ValidText(n.Label()); err != nil {
+		return fmt.Errorf("invalid label: %v", err)
+	}
+
+	n.parent = parent
+	for _, c := range n.children {
+		if err := c.validate(n, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
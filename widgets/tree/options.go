@@ -0,0 +1,224 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+// options.go contains configurable options for Tree.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/private/draw"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the provided options.
+type options struct {
+	lineStyle        linestyle.LineStyle
+	lineCellOpts     []cell.Option
+	selectedCellOpts []cell.Option
+	onSelect         NodeFn
+	onActivate       NodeFn
+	loadChildren     LoadFn
+	keyUp            keyboard.Shortcut
+	keyDown          keyboard.Shortcut
+	keyPgUp          keyboard.Shortcut
+	keyPgDown        keyboard.Shortcut
+	keyCollapse      keyboard.Shortcut
+	keyExpand        keyboard.Shortcut
+	keyActivate      keyboard.Shortcut
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		lineStyle:        DefaultLineStyle,
+		selectedCellOpts: []cell.Option{cell.Inverse()},
+		keyUp:            keyboard.Shortcut{Key: DefaultSelectKeyUp},
+		keyDown:          keyboard.Shortcut{Key: DefaultSelectKeyDown},
+		keyPgUp:          keyboard.Shortcut{Key: DefaultSelectKeyPageUp},
+		keyPgDown:        keyboard.Shortcut{Key: DefaultSelectKeyPageDown},
+		keyCollapse:      keyboard.Shortcut{Key: DefaultCollapseKey},
+		keyExpand:        keyboard.Shortcut{Key: DefaultExpandKey},
+		keyActivate:      keyboard.Shortcut{Key: DefaultActivateKey},
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.lineStyle != linestyle.None {
+		if _, err := draw.TreeLineRunes(o.lineStyle); err != nil {
+			return fmt.Errorf("invalid LineStyle: %v", err)
+		}
+	}
+	keys := map[keyboard.Shortcut]bool{
+		o.keyUp:       true,
+		o.keyDown:     true,
+		o.keyPgUp:     true,
+		o.keyPgDown:   true,
+		o.keyCollapse: true,
+		o.keyExpand:   true,
+		o.keyActivate: true,
+	}
+	if len(keys) != 7 {
+		return fmt.Errorf("invalid SelectShortcuts(up:%v, down:%v, pageUp:%v, pageDown:%v), ExpandShortcuts(collapse:%v, expand:%v) and ActivateShortcut(%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown, o.keyCollapse, o.keyExpand, o.keyActivate)
+	}
+	return nil
+}
+
+// DefaultLineStyle is the default value for the LineStyle option.
+const DefaultLineStyle = linestyle.Light
+
+// LineStyle sets the style of the lines that connect the nodes. Use
+// linestyle.None to only indent the nodes without drawing any lines.
+// Defaults to DefaultLineStyle.
+func LineStyle(ls linestyle.LineStyle) Option {
+	return option(func(opts *options) {
+		opts.lineStyle = ls
+	})
+}
+
+// LineCellOpts sets the cell options for the lines that connect the nodes and
+// the markers that show whether a node is expanded.
+func LineCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.lineCellOpts = cOpts
+	})
+}
+
+// SelectedCellOpts sets the cell options for the label of the selected node.
+// These are applied on top of the options of the text chunks.
+// Defaults to inverse text.
+func SelectedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedCellOpts = cOpts
+	})
+}
+
+// NodeFn is a function called with a node of the tree.
+//
+// The function must be light-weight and thread-safe as the keyboard or mouse
+// events that trigger it are processed in a separate goroutine. The function
+// must not modify the node.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type NodeFn func(n *Node) error
+
+// OnSelect sets a function that is called when the user selects a node with
+// the keyboard or the mouse.
+func OnSelect(fn NodeFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
+
+// OnActivate sets a function that is called when the user presses the
+// activate key or double clicks on a node.
+func OnActivate(fn NodeFn) Option {
+	return option(func(opts *options) {
+		opts.onActivate = fn
+	})
+}
+
+// LoadFn is a function that returns the children of a node created with the
+// Lazy option. The returned nodes must not be nil and must not be already
+// used in the tree.
+//
+// The same requirements apply to this function as to the NodeFn. The node is
+// displayed as expanded without children until the function returns.
+type LoadFn func(n *Node) ([]*Node, error)
+
+// LoadChildren sets a function that loads the children of nodes created with
+// the Lazy option when they are expanded for the first time.
+// Nodes created with the Lazy option have no children if this option isn't
+// provided.
+func LoadChildren(fn LoadFn) Option {
+	return option(func(opts *options) {
+		opts.loadChildren = fn
+	})
+}
+
+// The default keys that move the selection.
+const (
+	DefaultSelectKeyUp       = keyboard.KeyArrowUp
+	DefaultSelectKeyDown     = keyboard.KeyArrowDown
+	DefaultSelectKeyPageUp   = keyboard.KeyPgUp
+	DefaultSelectKeyPageDown = keyboard.KeyPgDn
+)
+
+// SelectShortcuts configures the keyboard shortcuts that move the selection
+// up and down by one node or by one page.
+// The provided shortcuts must be unique.
+// Defaults to DefaultSelectKeyUp, DefaultSelectKeyDown,
+// DefaultSelectKeyPageUp and DefaultSelectKeyPageDown.
+func SelectShortcuts(up, down, pageUp, pageDown keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
+
+// The default keys that collapse and expand the selected node.
+const (
+	DefaultCollapseKey = keyboard.KeyArrowLeft
+	DefaultExpandKey   = keyboard.KeyArrowRight
+)
+
+// ExpandShortcuts configures the keyboard shortcuts that collapse and expand
+// the selected node. Collapsing a node that is already collapsed selects its
+// parent and expanding a node that is already expanded selects its first
+// child.
+// Defaults to DefaultCollapseKey and DefaultExpandKey.
+func ExpandShortcuts(collapse, expand keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyCollapse = collapse
+		opts.keyExpand = expand
+	})
+}
+
+// DefaultActivateKey is the default key that activates the selected node.
+const DefaultActivateKey = keyboard.KeyEnter
+
+// ActivateShortcut configures the keyboard shortcut that activates the
+// selected node.
+// Defaults to DefaultActivateKey.
+func ActivateShortcut(s keyboard.Shortcut) Option {
+	return option(func(opts *options) {
+		opts.keyActivate = s
+	})
+}
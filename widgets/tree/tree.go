@@ -0,0 +1,581 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tree contains a widget that displays a hierarchy of nodes that can
+// be collapsed and expanded.
+package tree
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/mouse/gesture"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The markers displayed in front of nodes that have children.
+const (
+	collapsedMarker = '▸'
+	expandedMarker  = '▾'
+)
+
+// row is a single displayed line of the tree.
+type row struct {
+	// node is the node displayed on the line.
+	node *Node
+	// depth is the depth of the node, zero for the roots.
+	depth int
+	// prefix are the lines drawn in front of the node.
+	prefix []rune
+}
+
+// markerX returns the horizontal position of the marker on the line.
+func (r *row) markerX() int {
+	return len(r.prefix)
+}
+
+// Tree displays a hierarchy of nodes.
+//
+// The user selects nodes with the keyboard or the mouse, collapses and
+// expands the selected node with the keyboard or by clicking on the marker in
+// front of the node. The nodes scroll to keep the selected node visible, the
+// mouse wheel scrolls the nodes.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Tree struct {
+	// roots are the top level nodes.
+	roots []*Node
+	// selected is the selected node or nil if the tree is empty.
+	selected *Node
+
+	// offset is the index of the first displayed row.
+	offset int
+	// followSelection indicates that the next draw should scroll the rows so
+	// that the selected node is visible.
+	followSelection bool
+	// bodyRows is the height of the canvas during the last draw.
+	bodyRows int
+
+	// lines are the runes used to draw the lines that connect the nodes.
+	lines *draw.TreeLines
+
+	// gestures recognizes mouse clicks and double clicks on the nodes.
+	gestures *gesture.Recognizer
+
+	// invalidator is used to request a redraw when the data changes.
+	// Retained from the last call to Draw.
+	invalidator widgetapi.Invalidator
+
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new empty tree.
+func New(opts ...Option) (*Tree, error) {
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	lines := &draw.TreeLines{
+		Vertical:   ' ',
+		Branch:     ' ',
+		LastBranch: ' ',
+		Horizontal: ' ',
+	}
+	if opt.lineStyle != linestyle.None {
+		l, err := draw.TreeLineRunes(opt.lineStyle)
+		if err != nil {
+			return nil, err
+		}
+		lines = l
+	}
+	return &Tree{
+		lines:    lines,
+		gestures: gesture.New(mouse.ButtonLeft, image.ZR),
+		opts:     opt,
+	}, nil
+}
+
+// SetRoots replaces the nodes displayed in the tree.
+// The selection remains on the selected node if it is still in the tree,
+// otherwise the first root is selected. The OnSelect function isn't called.
+// This method is thread-safe and can be called while the dashboard is running.
+func (t *Tree) SetRoots(roots []*Node) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := map[*Node]bool{}
+	for _, n := range roots {
+		if err := n.validate(nil, seen); err != nil {
+			return err
+		}
+	}
+
+	t.roots = roots
+	if !seen[t.selected] {
+		t.selected = nil
+		if len(roots) > 0 {
+			t.selected = roots[0]
+		}
+	}
+	t.followSelection = true
+	t.invalidator.Invalidate()
+	return nil
+}
+
+// Selected returns the selected node. Returns false if the tree is empty.
+func (t *Tree) Selected() (*Node, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.selected, t.selected != nil
+}
+
+// nodes returns all the nodes in the tree.
+// Caller must hold t.mu.
+func (t *Tree) nodes() map[*Node]bool {
+	res := map[*Node]bool{}
+	var walk func(nodes []*Node)
+	walk = func(nodes []*Node) {
+		for _, n := range nodes {
+			res[n] = true
+			walk(n.children)
+		}
+	}
+	walk(t.roots)
+	return res
+}
+
+// rows returns the lines with the displayed nodes, i.e. the roots and the
+// children of expanded nodes.
+// Caller must hold t.mu.
+func (t *Tree) rows() []*row {
+	var res []*row
+	var walk func(nodes []*Node, depth int, cont []rune)
+	walk = func(nodes []*Node, depth int, cont []rune) {
+		for i, n := range nodes {
+			last := i == len(nodes)-1
+			prefix := append([]rune{}, cont...)
+			if depth > 0 {
+				branch := t.lines.Branch
+				if last {
+					branch = t.lines.LastBranch
+				}
+				prefix = append(prefix, branch, t.lines.Horizontal)
+			}
+			res = append(res, &row{
+				node:   n,
+				depth:  depth,
+				prefix: prefix,
+			})
+
+			if !n.expanded || len(n.children) == 0 {
+				continue
+			}
+			childCont := cont
+			if depth > 0 {
+				vertical := t.lines.Vertical
+				if last {
+					vertical = ' '
+				}
+				childCont = append(append([]rune{}, cont...), vertical, ' ')
+			}
+			walk(n.children, depth+1, childCont)
+		}
+	}
+	walk(t.roots, 0, nil)
+	return res
+}
+
+// selectedRow returns the index of the row with the selected node or -1 if
+// the selected node isn't displayed.
+func (t *Tree) selectedRow(rows []*row) int {
+	for i, r := range rows {
+		if r.node == t.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// maxOffset returns the largest offset that still fills the canvas.
+// Caller must hold t.mu.
+func (t *Tree) maxOffset(rows []*row) int {
+	if max := len(rows) - t.bodyRows; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// scroll updates the offset so that the selected node is visible if requested
+// and the offset is within the rows.
+// Caller must hold t.mu.
+func (t *Tree) scroll(rows []*row) {
+	if sel := t.selectedRow(rows); t.followSelection && sel >= 0 && t.bodyRows > 0 {
+		if sel < t.offset {
+			t.offset = sel
+		}
+		if sel >= t.offset+t.bodyRows {
+			t.offset = sel - t.bodyRows + 1
+		}
+	}
+	t.followSelection = false
+
+	if max := t.maxOffset(rows); t.offset > max {
+		t.offset = max
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+}
+
+// marker returns the rune displayed in front of the label of the node.
+func (t *Tree) marker(r *row) rune {
+	switch {
+	case r.node.expandable() && r.node.expanded:
+		return expandedMarker
+	case r.node.expandable():
+		return collapsedMarker
+	case r.depth > 0:
+		return t.lines.Horizontal
+	default:
+		return ' '
+	}
+}
+
+// drawLabel draws the label of the node starting at the point, trimmed to the
+// width of the canvas.
+func (t *Tree) drawLabel(cvs *canvas.Canvas, n *Node, start image.Point) error {
+	width := cvs.Area().Dx() - start.X
+	if width <= 0 {
+		return nil
+	}
+
+	// The options of each rune in the label.
+	var runeOpts [][]cell.Option
+	for _, c := range n.chunks {
+		cOpts := c.cOpts
+		if n == t.selected {
+			cOpts = append(append([]cell.Option{}, c.cOpts...), t.opts.selectedCellOpts...)
+		}
+		for range c.text {
+			runeOpts = append(runeOpts, cOpts)
+		}
+	}
+
+	trimmed, err := draw.TrimText(n.Label(), width, draw.OverrunModeThreeDot)
+	if err != nil {
+		return err
+	}
+	cur := start
+	for i, r := range []rune(trimmed) {
+		cells, err := cvs.SetCell(cur, r, runeOpts[i]...)
+		if err != nil {
+			return err
+		}
+		cur.X += cells
+	}
+	return nil
+}
+
+// drawRow draws the row at the provided line of the canvas.
+func (t *Tree) drawRow(cvs *canvas.Canvas, r *row, y int) error {
+	width := cvs.Area().Dx()
+	x := 0
+	for _, l := range append(append([]rune{}, r.prefix...), t.marker(r), ' ') {
+		if x >= width {
+			return nil
+		}
+		if _, err := cvs.SetCell(image.Point{x, y}, l, t.opts.lineCellOpts...); err != nil {
+			return err
+		}
+		x += runewidth.RuneWidth(l)
+	}
+	return t.drawLabel(cvs, r.node, image.Point{x, y})
+}
+
+// Draw draws the Tree widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (t *Tree) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.invalidator = meta.Invalidator
+
+	ar := cvs.Area()
+	t.gestures.UpdateArea(ar)
+	t.bodyRows = ar.Dy()
+
+	rows := t.rows()
+	t.scroll(rows)
+	for y := 0; y < t.bodyRows; y++ {
+		i := t.offset + y
+		if i >= len(rows) {
+			break
+		}
+		if err := t.drawRow(cvs, rows[i], y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chain returns a function that calls all the non-nil functions until one
+// of them returns an error. Returns nil if all the functions are nil.
+func chain(fns ...func() error) func() error {
+	var nonNil []func() error
+	for _, fn := range fns {
+		if fn != nil {
+			nonNil = append(nonNil, fn)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return func() error {
+		for _, fn := range nonNil {
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// selectNode selects the node. Returns the callback that must be called after
+// t.mu is released.
+// Caller must hold t.mu.
+func (t *Tree) selectNode(n *Node) func() error {
+	t.followSelection = true
+	if n == t.selected {
+		return nil
+	}
+	t.selected = n
+	if fn := t.opts.onSelect; fn != nil {
+		return func() error { return fn(n) }
+	}
+	return nil
+}
+
+// selectRow selects the node on the row at the index, the index is clamped to
+// the displayed rows.
+// Caller must hold t.mu.
+func (t *Tree) selectRow(rows []*row, i int) func() error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(rows) {
+		i = len(rows) - 1
+	}
+	return t.selectNode(rows[i].node)
+}
+
+// activate returns the callback that activates the node.
+// Caller must hold t.mu.
+func (t *Tree) activate(n *Node) func() error {
+	if fn := t.opts.onActivate; fn != nil && n != nil {
+		return func() error { return fn(n) }
+	}
+	return nil
+}
+
+// expand expands the node. Returns the function that loads the children of
+// lazy nodes, which must be called after t.mu is released.
+// Caller must hold t.mu.
+func (t *Tree) expand(n *Node) func() error {
+	n.expanded = true
+	if !n.lazy || n.loading {
+		return nil
+	}
+	if t.opts.loadChildren == nil {
+		n.lazy = false
+		return nil
+	}
+
+	n.loading = true
+	fn := t.opts.loadChildren
+	return func() error {
+		children, err := fn(n)
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		n.loading = false
+		if err != nil {
+			n.expanded = false
+			return err
+		}
+
+		seen := t.nodes()
+		for _, c := range children {
+			if err := c.validate(n, seen); err != nil {
+				n.expanded = false
+				return fmt.Errorf("LoadChildren returned an invalid node: %v", err)
+			}
+		}
+		n.children = children
+		n.lazy = false
+		t.invalidator.Invalidate()
+		return nil
+	}
+}
+
+// toggle collapses or expands the node.
+// Caller must hold t.mu.
+func (t *Tree) toggle(n *Node) func() error {
+	if n.expanded {
+		n.expanded = false
+		return nil
+	}
+	return t.expand(n)
+}
+
+// keyboard processes the keyboard event and returns the callback that must be
+// called after t.mu is released.
+func (t *Tree) keyboard(k *terminalapi.Keyboard) func() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := t.rows()
+	sel := t.selectedRow(rows)
+	page := t.bodyRows
+	if page < 1 {
+		page = 1
+	}
+
+	switch sc := k.Shortcut(); {
+	case sc == t.opts.keyUp:
+		return t.selectRow(rows, sel-1)
+	case sc == t.opts.keyDown:
+		return t.selectRow(rows, sel+1)
+	case sc == t.opts.keyPgUp:
+		return t.selectRow(rows, sel-page)
+	case sc == t.opts.keyPgDown:
+		return t.selectRow(rows, sel+page)
+	case sc == t.opts.keyActivate:
+		return t.activate(t.selected)
+	}
+
+	n := t.selected
+	if n == nil {
+		return nil
+	}
+	switch sc := k.Shortcut(); {
+	case sc == t.opts.keyCollapse:
+		if n.expandable() && n.expanded {
+			n.expanded = false
+			return nil
+		}
+		if n.parent != nil {
+			return t.selectNode(n.parent)
+		}
+	case sc == t.opts.keyExpand:
+		if !n.expandable() {
+			return nil
+		}
+		if !n.expanded {
+			return t.expand(n)
+		}
+		if len(n.children) > 0 {
+			return t.selectNode(n.children[0])
+		}
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events, moves the selection and collapses or
+// expands the selected node.
+// Implements widgetapi.Widget.Keyboard.
+func (t *Tree) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	if fn := t.keyboard(k); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// mouse processes the mouse event and returns the callback that must be
+// called after t.mu is released.
+func (t *Tree) mouse(m *terminalapi.Mouse) func() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := t.rows()
+	switch m.Button {
+	case mouse.ButtonWheelUp:
+		if t.offset > 0 {
+			t.offset--
+		}
+		return nil
+	case mouse.ButtonWheelDown:
+		if t.offset < t.maxOffset(rows) {
+			t.offset++
+		}
+		return nil
+	}
+
+	g := t.gestures.Event(m)
+	if g == gesture.None {
+		return nil
+	}
+	i := t.offset + m.Position.Y
+	if i >= len(rows) {
+		return nil
+	}
+
+	r := rows[i]
+	prev := t.selected
+	selectFn := t.selectNode(r.node)
+	if m.Position.X == r.markerX() && r.node.expandable() {
+		return chain(selectFn, t.toggle(r.node))
+	}
+	// Only activate when both clicks were on the same node.
+	if g == gesture.DoubleClick && prev == r.node {
+		return chain(selectFn, t.activate(r.node))
+	}
+	return selectFn
+}
+
+// Mouse processes mouse events, selects the clicked nodes, collapses or
+// expands nodes when their marker is clicked and scrolls the nodes.
+// Implements widgetapi.Widget.Mouse.
+func (t *Tree) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	if fn := t.mouse(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		return fn()
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (t *Tree) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// The marker and at least one full-width rune of the label.
+		MinimumSize:  image.Point{4, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,769 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks calls of the OnSelect, OnActivate and LoadChildren
+// functions.
+type callbackTracker struct {
+	// selected are the labels of the nodes OnSelect was called with.
+	selected []string
+	// activated are the labels of the nodes OnActivate was called with.
+	activated []string
+	// loaded are the labels of the nodes LoadChildren was called with.
+	loaded []string
+	// wantErr when set to true, makes the callbacks return an error.
+	wantErr bool
+}
+
+// onSelect is the OnSelect function.
+func (ct *callbackTracker) onSelect(n *Node) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.selected = append(ct.selected, n.Label())
+	return nil
+}
+
+// onActivate is the OnActivate function.
+func (ct *callbackTracker) onActivate(n *Node) error {
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.activated = append(ct.activated, n.Label())
+	return nil
+}
+
+// loadChildren is the LoadChildren function, returns two children of the
+// node.
+func (ct *callbackTracker) loadChildren(n *Node) ([]*Node, error) {
+	if ct.wantErr {
+		return nil, errors.New("ct.wantErr set to true")
+	}
+	ct.loaded = append(ct.loaded, n.Label())
+	return []*Node{
+		NewNode(n.Label() + "1"),
+		NewNode(n.Label() + "2"),
+	}, nil
+}
+
+// click returns the mouse events of a left click at the point.
+func click(x, y int) []terminalapi.Event {
+	return []terminalapi.Event{
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: image.Point{x, y}, Button: mouse.ButtonRelease},
+	}
+}
+
+// services returns the nodes used in the tests:
+//
+//	▾ services
+//	├─▾ api
+//	│ ├── 10.0.0.1
+//	│ └── 10.0.0.2
+//	└─▸ web
+//	  db
+func services() []*Node {
+	return []*Node{
+		NewNode("services",
+			Expanded(),
+			Children(
+				NewNode("api",
+					Expanded(),
+					Children(
+						NewNode("10.0.0.1"),
+						NewNode("10.0.0.2"),
+					),
+				),
+				NewNode("web",
+					Children(
+						NewNode("10.0.1.1"),
+					),
+				),
+			),
+		),
+		NewNode("db"),
+	}
+}
+
+// mustRow draws a row with the lines and the label at the line.
+func mustRow(c *canvas.Canvas, y int, lines, label string, selected bool) {
+	testdraw.MustText(c, lines, image.Point{0, y})
+	var cOpts []cell.Option
+	if selected {
+		cOpts = append(cOpts, cell.Inverse())
+	}
+	testdraw.MustText(c, label, image.Point{len([]rune(lines)), y}, draw.TextCellOpts(cOpts...))
+}
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// roots if not nil returns the nodes set before the first draw.
+		roots func() []*Node
+		// events are delivered after the first draw.
+		events []terminalapi.Event
+		// wantCallbackErr indicates that the callbacks should return an error
+		// and that the last event should return it.
+		wantCallbackErr bool
+		want            func(size image.Point) *faketerm.Terminal
+		wantSelected    []string
+		wantActivated   []string
+		wantLoaded      []string
+		wantErr         bool
+		wantRootsErr    bool
+	}{
+		{
+			desc: "fails on unsupported line style",
+			opts: []Option{
+				LineStyle(linestyle.LineStyle(-1)),
+			},
+			canvas:  image.Rect(0, 0, 14, 6),
+			wantErr: true,
+		},
+		{
+			desc: "fails when the keys aren't unique",
+			opts: []Option{
+				ExpandShortcuts(
+					keyboard.Shortcut{Key: keyboard.KeyArrowUp},
+					keyboard.Shortcut{Key: keyboard.KeyArrowRight},
+				),
+			},
+			canvas:  image.Rect(0, 0, 14, 6),
+			wantErr: true,
+		},
+		{
+			desc:   "SetRoots fails on nil node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots: func() []*Node {
+				return []*Node{NewNode("a", Children(nil))}
+			},
+			wantRootsErr: true,
+		},
+		{
+			desc:   "SetRoots fails on empty label",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots: func() []*Node {
+				return []*Node{NewNode("")}
+			},
+			wantRootsErr: true,
+		},
+		{
+			desc:   "SetRoots fails on label with a newline",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots: func() []*Node {
+				return []*Node{NewNodeFromChunks([]*TextChunk{NewChunk("a"), NewChunk("\nb")})}
+			},
+			wantRootsErr: true,
+		},
+		{
+			desc:   "SetRoots fails on nil text chunk",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots: func() []*Node {
+				return []*Node{NewNodeFromChunks([]*TextChunk{nil})}
+			},
+			wantRootsErr: true,
+		},
+		{
+			desc:   "SetRoots fails when a node appears more than once",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots: func() []*Node {
+				n := NewNode("a")
+				return []*Node{n, NewNode("b", Children(n))}
+			},
+			wantRootsErr: true,
+		},
+		{
+			desc:   "draws nothing without nodes",
+			canvas: image.Rect(0, 0, 14, 6),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws the nodes with light lines",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", true)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the nodes with round lines",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			opts: []Option{
+				LineStyle(linestyle.Round),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", true)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ ╰── ", "10.0.0.2", false)
+				mustRow(c, 4, "╰─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "only indents the nodes without lines",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			opts: []Option{
+				LineStyle(linestyle.None),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", true)
+				mustRow(c, 1, "  ▾ ", "api", false)
+				mustRow(c, 2, "      ", "10.0.0.1", false)
+				mustRow(c, 3, "      ", "10.0.0.2", false)
+				mustRow(c, 4, "  ▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws lines and labels with cell options",
+			canvas: image.Rect(0, 0, 10, 2),
+			roots: func() []*Node {
+				return []*Node{
+					NewNodeFromChunks([]*TextChunk{
+						NewChunk("a", cell.FgColor(cell.ColorRed)),
+						NewChunk("b", cell.FgColor(cell.ColorBlue)),
+					}),
+					NewNode("c", Lazy()),
+				}
+			},
+			opts: []Option{
+				LineCellOpts(cell.FgColor(cell.ColorGreen)),
+				SelectedCellOpts(cell.Bold()),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				lineOpts := draw.TextCellOpts(cell.FgColor(cell.ColorGreen))
+				testdraw.MustText(c, "  ", image.Point{0, 0}, lineOpts)
+				testdraw.MustText(c, "a", image.Point{2, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed), cell.Bold()))
+				testdraw.MustText(c, "b", image.Point{3, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue), cell.Bold()))
+				testdraw.MustText(c, "▸ ", image.Point{0, 1}, lineOpts)
+				testdraw.MustText(c, "c", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims labels that don't fit",
+			canvas: image.Rect(0, 0, 9, 6),
+			roots:  services,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "servic…", true)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10…", false)
+				mustRow(c, 3, "│ └── ", "10…", false)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves the selection down and scrolls",
+			canvas: image.Rect(0, 0, 14, 3),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 1, "└─▸ ", "web", false)
+				mustRow(c, 2, "  ", "db", true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"api", "10.0.0.1", "db"},
+		},
+		{
+			desc:   "the selection stops at the first node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", true)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"api", "services"},
+		},
+		{
+			desc:   "expands the selected node and selects its first child",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", false)
+				mustRow(c, 1, "├─▸ ", "api", false)
+				mustRow(c, 2, "└─▾ ", "web", false)
+				mustRow(c, 3, "  └── ", "10.0.1.1", true)
+				mustRow(c, 4, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"api", "web", "10.0.1.1"},
+		},
+		{
+			desc:   "collapsing a leaf selects its parent",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", true)
+				mustRow(c, 1, "├─▸ ", "api", false)
+				mustRow(c, 2, "└─▸ ", "web", false)
+				mustRow(c, 3, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"api", "10.0.0.1", "api", "services"},
+		},
+		{
+			desc:   "activates the selected node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", false)
+				mustRow(c, 1, "├─▾ ", "api", true)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:  []string{"api"},
+			wantActivated: []string{"api"},
+		},
+		{
+			desc:   "click selects a node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: click(8, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", false)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ └── ", "10.0.0.2", true)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"10.0.0.2"},
+		},
+		{
+			desc:   "click on the marker toggles the node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: append(click(2, 1), click(2, 2)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", false)
+				mustRow(c, 1, "├─▸ ", "api", false)
+				mustRow(c, 2, "└─▾ ", "web", true)
+				mustRow(c, 3, "  └── ", "10.0.1.1", false)
+				mustRow(c, 4, "  ", "db", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected: []string{"api", "web"},
+		},
+		{
+			desc:   "double click activates a node",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: append(click(3, 5), click(3, 5)...),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "services", false)
+				mustRow(c, 1, "├─▾ ", "api", false)
+				mustRow(c, 2, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 3, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 4, "└─▸ ", "web", false)
+				mustRow(c, 5, "  ", "db", true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantSelected:  []string{"db"},
+			wantActivated: []string{"db"},
+		},
+		{
+			desc:   "mouse wheel scrolls the nodes",
+			canvas: image.Rect(0, 0, 14, 3),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "│ ├── ", "10.0.0.1", false)
+				mustRow(c, 1, "│ └── ", "10.0.0.2", false)
+				mustRow(c, 2, "└─▸ ", "web", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "loads the children of a lazy node when first expanded",
+			canvas: image.Rect(0, 0, 14, 4),
+			roots: func() []*Node {
+				return []*Node{
+					NewNode("a", Lazy(), Children(NewNode("ignored"))),
+					NewNode("b"),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "▾ ", "a", true)
+				mustRow(c, 1, "├── ", "a1", false)
+				mustRow(c, 2, "└── ", "a2", false)
+				mustRow(c, 3, "  ", "b", false)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantLoaded: []string{"a"},
+		},
+		{
+			desc:   "lazy node without LoadChildren has no children",
+			canvas: image.Rect(0, 0, 14, 4),
+			roots: func() []*Node {
+				return []*Node{
+					NewNode("a", Lazy()),
+				}
+			},
+			opts: []Option{
+				LoadChildren(nil),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustRow(c, 0, "  ", "a", true)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "forwards errors from the LoadChildren function",
+			canvas: image.Rect(0, 0, 14, 4),
+			roots: func() []*Node {
+				return []*Node{
+					NewNode("a", Lazy()),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			wantCallbackErr: true,
+		},
+		{
+			desc:   "forwards errors from the OnSelect function",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			wantCallbackErr: true,
+		},
+		{
+			desc:   "forwards errors from the OnActivate function",
+			canvas: image.Rect(0, 0, 14, 6),
+			roots:  services,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantCallbackErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ct := &callbackTracker{wantErr: tc.wantCallbackErr}
+			opts := append([]Option{
+				OnSelect(ct.onSelect),
+				OnActivate(ct.onActivate),
+				LoadChildren(ct.loadChildren),
+			}, tc.opts...)
+
+			tr, err := New(opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.roots != nil {
+				err := tr.SetRoots(tc.roots())
+				if (err != nil) != tc.wantRootsErr {
+					t.Errorf("SetRoots => unexpected error: %v, wantRootsErr: %v", err, tc.wantRootsErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so the widget knows the layout.
+			if err := tr.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = tr.Keyboard(e, &widgetapi.EventMeta{Focused: true})
+				case *terminalapi.Mouse:
+					err = tr.Mouse(e, &widgetapi.EventMeta{})
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if i == len(tc.events)-1 && tc.wantCallbackErr {
+					if err == nil {
+						t.Errorf("event %v => got nil error, want the error from the callback", ev)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("event %v => unexpected error: %v", ev, err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tr.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if diff := pretty.Compare(tc.wantSelected, ct.selected); diff != "" {
+				t.Errorf("OnSelect => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantActivated, ct.activated); diff != "" {
+				t.Errorf("OnActivate => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantLoaded, ct.loaded); diff != "" {
+				t.Errorf("LoadChildren => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetRoots(t *testing.T) {
+	tr, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if _, ok := tr.Selected(); ok {
+		t.Errorf("Selected => true, want false without nodes")
+	}
+
+	db := NewNode("db", Value(42))
+	if err := tr.SetRoots([]*Node{NewNode("api"), db}); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+	if got, ok := tr.Selected(); !ok || got.Label() != "api" {
+		t.Errorf("Selected => %v, %v, want the first root", got, ok)
+	}
+	if err := tr.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown}, &widgetapi.EventMeta{Focused: true}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+
+	// The selection remains on a node that is still in the tree.
+	if err := tr.SetRoots([]*Node{NewNode("web", Expanded(), Children(db))}); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+	got, ok := tr.Selected()
+	if !ok || got != db {
+		t.Fatalf("Selected => %v, %v, want the db node", got, ok)
+	}
+	if v := got.Value(); v != 42 {
+		t.Errorf("Value => %v, want 42", v)
+	}
+
+	// The first root is selected when the selected node is removed.
+	if err := tr.SetRoots([]*Node{NewNode("cache")}); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+	if got, ok := tr.Selected(); !ok || got.Label() != "cache" {
+		t.Errorf("Selected => %v, %v, want the first root", got, ok)
+	}
+}
+
+func TestRequestsRedraw(t *testing.T) {
+	tr, err := New(
+		LoadChildren(func(n *Node) ([]*Node, error) {
+			return []*Node{NewNode("child")}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	var requests int
+	c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+	if err := tr.Draw(c, &widgetapi.Meta{
+		Invalidator: func() { requests++ },
+	}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	if err := tr.SetRoots([]*Node{NewNode("lazy", Lazy())}); err != nil {
+		t.Fatalf("SetRoots => unexpected error: %v", err)
+	}
+	if err := tr.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowRight}, &widgetapi.EventMeta{Focused: true}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if got, want := requests, 2; got != want {
+		t.Errorf("Tree requested %d redraws, want %d", got, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tr, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := tr.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{4, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary treedemo displays a tree of services, their instances and endpoints.
+// The endpoints are loaded when an instance is expanded.
+// Exits when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/tcell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/text"
+	"github.com/mum4k/termdash/widgets/tree"
+)
+
+// instance is an instance of a service.
+type instance struct {
+	addr    string
+	healthy bool
+}
+
+// services maps the names of services to their instances.
+var services = map[string][]*instance{
+	"frontend": {
+		{addr: "10.0.0.1", healthy: true},
+		{addr: "10.0.0.2", healthy: true},
+	},
+	"backend": {
+		{addr: "10.0.1.1", healthy: true},
+		{addr: "10.0.1.2", healthy: false},
+		{addr: "10.0.1.3", healthy: true},
+	},
+	"database": {
+		{addr: "10.0.2.1", healthy: true},
+	},
+}
+
+// instanceNode returns a node for the instance with its health status.
+func instanceNode(inst *instance) *tree.Node {
+	status := tree.NewChunk(" ok", cell.FgColor(cell.ColorGreen))
+	if !inst.healthy {
+		status = tree.NewChunk(" down", cell.FgColor(cell.ColorRed))
+	}
+	return tree.NewNodeFromChunks(
+		[]*tree.TextChunk{tree.NewChunk(inst.addr), status},
+		tree.Lazy(),
+		tree.Value(inst),
+	)
+}
+
+// endpoints simulates loading the endpoints exposed by an instance.
+func endpoints(n *tree.Node) ([]*tree.Node, error) {
+	inst, ok := n.Value().(*instance)
+	if !ok {
+		return nil, nil
+	}
+	var res []*tree.Node
+	for _, path := range []string{"/healthz", "/metrics", "/api"} {
+		if path == "/api" && rand.Intn(2) == 0 {
+			continue
+		}
+		res = append(res, tree.NewNode(fmt.Sprintf("%s:8080%s", inst.addr, path)))
+	}
+	return res, nil
+}
+
+func main() {
+	t, err := tcell.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	status, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := status.Write("Navigate with the arrow keys, click on ▸ to expand a node, press Enter or double click to activate it."); err != nil {
+		panic(err)
+	}
+
+	tr, err := tree.New(
+		tree.LineStyle(linestyle.Round),
+		tree.LineCellOpts(cell.FgColor(cell.ColorNumber(244))),
+		tree.LoadChildren(endpoints),
+		tree.OnSelect(func(n *tree.Node) error {
+			return status.Write(fmt.Sprintf("Selected %s.", n.Label()), text.WriteReplace())
+		}),
+		tree.OnActivate(func(n *tree.Node) error {
+			return status.Write(fmt.Sprintf("Activated %s.", n.Label()), text.WriteReplace())
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	var roots []*tree.Node
+	for _, name := range []string{"frontend", "backend", "database"} {
+		var instances []*tree.Node
+		for _, inst := range services[name] {
+			instances = append(instances, instanceNode(inst))
+		}
+		roots = append(roots, tree.NewNodeFromChunks(
+			[]*tree.TextChunk{tree.NewChunk(name, cell.Bold())},
+			tree.Expanded(),
+			tree.Children(instances...),
+		))
+	}
+	if err := tr.SetRoots(roots); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(linestyle.Light),
+				container.BorderTitle("Services"),
+				container.PlaceWidget(tr),
+				container.Focused(),
+			),
+			container.Bottom(
+				container.PlaceWidget(status),
+			),
+			container.SplitPercent(80),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}